@@ -14,7 +14,9 @@ import (
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/core/vm"
 	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
 	"github.com/clearmatics/autonity/log"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 var ErrAutonityContract = errors.New("could not call Autonity contract")
@@ -24,6 +26,18 @@ var ContractAddress = crypto.CreateAddress(Deployer, 0)
 
 const ABISPEC = "ABISPEC"
 
+// inmemoryBLSKeys is the number of registered BLS keys whose proof of
+// possession is kept verified.
+const inmemoryBLSKeys = 1000
+
+// inmemoryMinGasPrices is the number of blocks whose minimum gas price, and
+// contract code hash to tell the upgrades apart, are kept cached.
+const inmemoryMinGasPrices = 256
+
+// inmemoryUpgrades is the number of contract upgrades kept until the block
+// running them is committed.
+const inmemoryUpgrades = 16
+
 // EVMProvider provides a new evm. This allows us to decouple the contract from *params.ChainConfig which is required to build a new evm.
 type EVMProvider interface {
 	EVM(header *types.Header, origin common.Address, statedb *state.StateDB) *vm.EVM
@@ -34,6 +48,8 @@ type Blockchainer interface {
 	ReadEnodeWhitelist() *types.Nodes
 
 	PutKeyValue(key []byte, value []byte) error
+	WriteAutonityContractABI(number uint64, abi string)
+	ReadAutonityContractABI(number uint64) string
 }
 
 type Contract struct {
@@ -42,6 +58,11 @@ type Contract struct {
 	initialMinGasPrice uint64
 	contractABI        *abi.ABI
 	stringABI          string
+	historicABIs       map[string]*abi.ABI
+	blsKeys            *lru.Cache // registered BLS key -> public key, nil if the proof is invalid
+	minGasPrices       *lru.Cache // block hash -> minimum gas price of the transactions on top of the block
+	codeHashes         *lru.Cache // block hash -> code hash of the contract on top of the block
+	upgrades           *lru.Cache // code hash of an upgraded contract -> its ABI, until its block is committed
 	bc                 Blockchainer
 	metrics            EconomicMetrics
 
@@ -55,8 +76,17 @@ func NewAutonityContract(
 	ABI string,
 	evmProvider EVMProvider,
 ) (*Contract, error) {
+	blsKeys, _ := lru.New(inmemoryBLSKeys)
+	minGasPrices, _ := lru.New(inmemoryMinGasPrices)
+	codeHashes, _ := lru.New(inmemoryMinGasPrices)
+	upgrades, _ := lru.New(inmemoryUpgrades)
 	contract := Contract{
 		stringABI:          ABI,
+		blsKeys:            blsKeys,
+		minGasPrices:       minGasPrices,
+		codeHashes:         codeHashes,
+		upgrades:           upgrades,
+		historicABIs:       make(map[string]*abi.ABI),
 		operator:           operator,
 		initialMinGasPrice: minGasPrice,
 		bc:                 bc,
@@ -66,6 +96,53 @@ func NewAutonityContract(
 	return &contract, err
 }
 
+// WithEVMProvider returns a copy of the contract building its EVMs with the
+// given provider, e.g. to trace the calls finalizing the block of header. The
+// copy uses the ABI active when the block is finalized, which is the one of
+// its parent as an upgrade only takes effect after the finalize call
+// triggering it. The copy does not write to the blockchain, so that replaying
+// a contract upgrade with it leaves the node untouched.
+func (ac *Contract) WithEVMProvider(header *types.Header, evmProvider EVMProvider) (*Contract, error) {
+	number := header.Number.Uint64()
+	if number > 0 {
+		number--
+	}
+	contractABI, err := ac.ABIAt(number)
+	if err != nil {
+		return nil, err
+	}
+	stringABI := ac.StringABIAt(number)
+
+	upgrades, _ := lru.New(inmemoryUpgrades)
+
+	ac.RLock()
+	defer ac.RUnlock()
+	return &Contract{
+		evmProvider:        evmProvider,
+		operator:           ac.operator,
+		initialMinGasPrice: ac.initialMinGasPrice,
+		contractABI:        contractABI,
+		stringABI:          stringABI,
+		historicABIs:       make(map[string]*abi.ABI),
+		blsKeys:            ac.blsKeys,
+		minGasPrices:       ac.minGasPrices,
+		codeHashes:         ac.codeHashes,
+		upgrades:           upgrades,
+		bc:                 readOnlyBlockchain{ac.bc},
+	}, nil
+}
+
+// readOnlyBlockchain drops the writes of a contract copy to the blockchain.
+type readOnlyBlockchain struct {
+	Blockchainer
+}
+
+func (readOnlyBlockchain) UpdateEnodeWhitelist(*types.Nodes) {}
+
+func (readOnlyBlockchain) PutKeyValue([]byte, []byte) error { return nil }
+
+func (readOnlyBlockchain) WriteAutonityContractABI(uint64, string) {}
+
 // measure metrics of user's meta data by regarding of network economic.
 func (ac *Contract) MeasureMetricsOfNetworkEconomic(header *types.Header, stateDB *state.StateDB) error {
 	// prepare abi and evm context
@@ -112,6 +189,49 @@ func (ac *Contract) MeasureMetricsOfNetworkEconomic(header *types.Header, stateD
 	return nil
 }
 
+// RewardDistribution returns the rewards distributed by the finalization of
+// the block of header, as recorded by the Rewarded events in the finalize
+// receipt closing the given receipts of the block.
+func (ac *Contract) RewardDistribution(header *types.Header, receipts types.Receipts) (*types.RewardDistribution, error) {
+	rewards := &types.RewardDistribution{
+		Number: header.Number.Uint64(),
+		Hash:   header.Hash(),
+		Amount: new(big.Int),
+	}
+	if len(receipts) == 0 || receipts[len(receipts)-1].TxHash != common.ACHash(header.Number) {
+		return rewards, nil
+	}
+	contractABI, err := ac.ABIAt(header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	event, ok := contractABI.Events["Rewarded"]
+	if !ok {
+		return rewards, nil
+	}
+	for _, l := range receipts[len(receipts)-1].Logs {
+		if l.Address != ContractAddress || len(l.Topics) == 0 || l.Topics[0] != event.ID {
+			continue
+		}
+		var reward struct {
+			Address common.Address `abi:"_address"`
+			Amount  *big.Int       `abi:"_amount"`
+		}
+		if err := contractABI.UnpackIntoInterface(&reward, "Rewarded", l.Data); err != nil {
+			return nil, err
+		}
+		rewards.Rewards = append(rewards.Rewards, types.Reward{Address: reward.Address, Amount: reward.Amount})
+		rewards.Amount.Add(rewards.Amount, reward.Amount)
+	}
+	return rewards, nil
+}
+
+// MeasureRewardDistribution records the rewards distributed by a block in the
+// economic metrics.
+func (ac *Contract) MeasureRewardDistribution(rewards *types.RewardDistribution) {
+	ac.metrics.SubmitRewardDistributionMetrics(rewards)
+}
+
 func (ac *Contract) GetCommittee(header *types.Header, statedb *state.StateDB) (types.Committee, error) {
 	// The Autonity Contract is not deployed yet at block #1, we return an error if this
 	// function is called at this height. In a past version we were returning the genesis committee field
@@ -129,6 +249,38 @@ func (ac *Contract) GetCommittee(header *types.Header, statedb *state.StateDB) (
 	return committeeSet, err
 }
 
+// FillCommitteeBLSKeys sets the BLS public key of the committee members which
+// registered one with a valid proof of possession.
+func (ac *Contract) FillCommitteeBLSKeys(header *types.Header, statedb *state.StateDB, committee types.Committee) error {
+	registered, err := ac.callGetBLSKeys(ac.contractABI, statedb, header)
+	if err != nil {
+		return err
+	}
+	for i := range committee {
+		committee[i].BLSKey = ac.blsPublicKey(registered[committee[i].Address])
+	}
+	return nil
+}
+
+// blsPublicKey returns the public key of a registered BLS key, or nil if the
+// proof of possession of the key does not verify.
+func (ac *Contract) blsPublicKey(registered []byte) []byte {
+	if len(registered) == 0 {
+		return nil
+	}
+	if key, ok := ac.blsKeys.Get(string(registered)); ok {
+		return key.([]byte)
+	}
+	var key []byte
+	if pk, err := bls.PublicKeyFromRegistration(registered); err == nil {
+		key = pk.Bytes()
+	} else {
+		log.Debug("Ignoring invalid BLS key", "err", err)
+	}
+	ac.blsKeys.Add(string(registered), key)
+	return key
+}
+
 func (ac *Contract) UpdateEnodesWhitelist(state *state.StateDB, block *types.Block) error {
 	newWhitelist, err := ac.GetWhitelist(block, state)
 	if err != nil {
@@ -157,12 +309,93 @@ func (ac *Contract) GetWhitelist(block *types.Block, db *state.StateDB) (*types.
 	return newWhitelist, err
 }
 
-func (ac *Contract) GetMinimumGasPrice(block *types.Block, db *state.StateDB) (uint64, error) {
-	if block.Number().Uint64() <= 1 {
+// GetMinimumGasPrice returns the minimum gas price of the transactions on top
+// of the block of header, the one set in the contract in the given state of
+// the block. It is cached per block, the state is only read on a cache miss.
+func (ac *Contract) GetMinimumGasPrice(header *types.Header, db *state.StateDB) (uint64, error) {
+	if header.Number.Uint64() == 0 {
 		return ac.initialMinGasPrice, nil
 	}
+	hash := header.Hash()
+	if price, ok := ac.minGasPrices.Get(hash); ok {
+		return price.(uint64), nil
+	}
+	price, err := ac.callGetMinimumGasPrice(db, header)
+	if err != nil {
+		return 0, err
+	}
+	ac.minGasPrices.Add(hash, price)
+	return price, nil
+}
 
-	return ac.callGetMinimumGasPrice(db, block.Header())
+// ReadMinimumGasPrice returns the minimum gas price read from the contract in
+// db, the state on top of the block of header, without going through the
+// cache, for the validation of the blocks not to rely on the events.
+func (ac *Contract) ReadMinimumGasPrice(header *types.Header, db *state.StateDB) (uint64, error) {
+	return ac.callGetMinimumGasPrice(db, header)
+}
+
+// UpdateMinimumGasPrice caches the minimum gas price on top of the block of
+// header from the MinimumGasPriceUpdated events in the given receipts of the
+// block, the finalize one included, or carries the price on top of the parent
+// block over if the block did not change it and the contract emits the event.
+// The price on top of a block
+// upgrading the contract, told by its ContractUpgraded event or by a change of
+// the contract code in statedb, the state on top of the block, is left to be
+// read from the state as the new contract is deployed without events.
+func (ac *Contract) UpdateMinimumGasPrice(header *types.Header, statedb *state.StateDB, receipts types.Receipts) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil
+	}
+	codeHash := statedb.GetCodeHash(ContractAddress)
+	ac.codeHashes.Add(header.Hash(), codeHash)
+	parentCodeHash, ok := ac.codeHashes.Get(header.ParentHash)
+	if number > 1 && (!ok || parentCodeHash.(common.Hash) != codeHash) {
+		ac.minGasPrices.Remove(header.Hash())
+		return nil
+	}
+	// The events of the block are emitted by the contract of the parent.
+	contractABI, err := ac.ABIAt(number - 1)
+	if err != nil {
+		return err
+	}
+	upgraded, upgradable := contractABI.Events["ContractUpgraded"]
+	updated, updatable := contractABI.Events["MinimumGasPriceUpdated"]
+	var price *big.Int
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			if l.Address != ContractAddress || len(l.Topics) == 0 {
+				continue
+			}
+			switch {
+			case upgradable && l.Topics[0] == upgraded.ID:
+				ac.minGasPrices.Remove(header.Hash())
+				return nil
+			case updatable && l.Topics[0] == updated.ID:
+				price = new(big.Int)
+				if err := contractABI.UnpackIntoInterface(&price, "MinimumGasPriceUpdated", l.Data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	switch {
+	case price != nil:
+		ac.minGasPrices.Add(header.Hash(), price.Uint64())
+	case number == 1:
+		ac.minGasPrices.Add(header.Hash(), ac.initialMinGasPrice)
+	case updatable:
+		if parent, ok := ac.minGasPrices.Get(header.ParentHash); ok {
+			ac.minGasPrices.Add(header.Hash(), parent)
+			return nil
+		}
+		ac.minGasPrices.Remove(header.Hash())
+	default:
+		// A contract without the event can change its price silently.
+		ac.minGasPrices.Remove(header.Hash())
+	}
+	return nil
 }
 
 func (ac *Contract) GetProposerFromAC(header *types.Header, db *state.StateDB, height uint64, round int64) common.Address {
@@ -177,7 +410,12 @@ func (ac *Contract) SetMinimumGasPrice(block *types.Block, db *state.StateDB, pr
 	return ac.callSetMinimumGasPrice(db, block.Header(), price)
 }
 
-func (ac *Contract) FinalizeAndGetCommittee(transactions types.Transactions, receipts types.Receipts, header *types.Header, statedb *state.StateDB) (types.Committee, *types.Receipt, error) {
+// FinalizeAndGetCommittee runs the finalization of the block of header in the
+// contract and returns the committee of the next block. The committee members
+// which missed the parent block, if given, are reported to the contract first.
+// The committee is only elected anew if header is an epoch header, so that the
+// committee of the contract is the one of the consensus.
+func (ac *Contract) FinalizeAndGetCommittee(transactions types.Transactions, receipts types.Receipts, header *types.Header, statedb *state.StateDB, absentees []common.Address, epochHeader bool) (types.Committee, *types.Receipt, error) {
 	if header.Number.Uint64() == 0 {
 		return nil, nil, nil
 	}
@@ -191,6 +429,18 @@ func (ac *Contract) FinalizeAndGetCommittee(transactions types.Transactions, rec
 		"block", header.Number.Uint64(),
 		"gas", blockGas.Uint64())
 
+	if len(absentees) > 0 {
+		if err := ac.callReportDowntime(statedb, header, absentees); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if epochHeader {
+		if err := ac.callComputeCommittee(statedb, header); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	upgradeContract, committee, err := ac.callFinalize(statedb, header, blockGas)
 	if err != nil {
 		return nil, nil, err
@@ -234,19 +484,29 @@ func (ac *Contract) performContractUpgrade(statedb *state.StateDB, header *types
 		return errContract
 	}
 
+	// the BLS keys are not part of the dumped state, they are set again after the deployment.
+	blsKeys, errKeys := ac.callGetBLSKeys(ac.contractABI, statedb, header)
+	if errKeys != nil {
+		return errKeys
+	}
+	newContractABI, errABI := abi.JSON(strings.NewReader(newAbi))
+	if errABI != nil {
+		return errABI
+	}
+
 	// take snapshot in case of roll back to former view.
 	snapshot := statedb.Snapshot()
 
 	// Create account will delete previous the AC stateobject and carry over the balance
 	statedb.CreateAccount(ContractAddress)
 
-	if err := ac.updateAutonityContract(header, statedb, bytecode, stateBefore); err != nil {
+	gasUsed, err := ac.updateAutonityContract(header, statedb, bytecode, stateBefore)
+	if err != nil {
 		statedb.RevertToSnapshot(snapshot)
 		return err
 	}
 
-	// save new abi in persistent, once node reset, it load from persistent level db.
-	if err := ac.bc.PutKeyValue([]byte(ABISPEC), []byte(newAbi)); err != nil {
+	if err := setBLSKeys(&newContractABI, ac.evmProvider.EVM(header, Deployer, statedb), blsKeys); err != nil {
 		statedb.RevertToSnapshot(snapshot)
 		return err
 	}
@@ -256,7 +516,35 @@ func (ac *Contract) performContractUpgrade(statedb *state.StateDB, header *types
 		statedb.RevertToSnapshot(snapshot)
 		return err
 	}
-	log.Info("Autonity Contract upgrade success")
+
+	// the new abi is only persisted by CommitUpgrade, once the block is committed.
+	ac.upgrades.Add(statedb.GetCodeHash(ContractAddress), newAbi)
+	log.Info("Autonity Contract upgrade success", "gas", gasUsed)
+	return nil
+}
+
+// CommitUpgrade persists the ABI of the contract upgraded by the block of
+// header, statedb being the state on top of the block, as the current ABI and
+// as the one active from this block on. It must only be called for the blocks
+// of the canonical chain, as the upgrade also runs for the proposals which are
+// never committed.
+func (ac *Contract) CommitUpgrade(header *types.Header, statedb *state.StateDB) error {
+	upgraded, ok := ac.upgrades.Get(statedb.GetCodeHash(ContractAddress))
+	if !ok {
+		return nil
+	}
+	newAbi := upgraded.(string)
+	number := header.Number.Uint64()
+	if number > 0 && ac.bc.ReadAutonityContractABI(number-1) == newAbi {
+		// An earlier block already upgraded the contract to this code.
+		return nil
+	}
+	// save new abi in persistent, once node reset, it load from persistent level db.
+	if err := ac.bc.PutKeyValue([]byte(ABISPEC), []byte(newAbi)); err != nil {
+		return err
+	}
+	// keep track of the block the new abi is active from, to still decode the previous blocks with the former one.
+	ac.bc.WriteAutonityContractABI(number, newAbi)
 	return nil
 }
 
@@ -282,3 +570,34 @@ func (ac *Contract) StringABI() string {
 func (ac *Contract) ABI() *abi.ABI {
 	return ac.contractABI
 }
+
+// StringABIAt returns the autonity contract ABI active at the given block
+// number in string format. The current ABI is returned if no ABI was recorded
+// up to this block, which is the case of the chains upgraded before the ABI
+// history was kept.
+func (ac *Contract) StringABIAt(number uint64) string {
+	if historic := ac.bc.ReadAutonityContractABI(number); historic != "" {
+		return historic
+	}
+	return ac.StringABI()
+}
+
+// ABIAt returns the autonity contract's ABI active at the given block number.
+func (ac *Contract) ABIAt(number uint64) (*abi.ABI, error) {
+	stringABI := ac.StringABIAt(number)
+
+	ac.Lock()
+	defer ac.Unlock()
+	if stringABI == ac.stringABI {
+		return ac.contractABI, nil
+	}
+	if parsed, ok := ac.historicABIs[stringABI]; ok {
+		return parsed, nil
+	}
+	parsed, err := abi.JSON(strings.NewReader(stringABI))
+	if err != nil {
+		return nil, err
+	}
+	ac.historicABIs[stringABI] = &parsed
+	return &parsed, nil
+}
@@ -0,0 +1,223 @@
+package autonity
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/stretchr/testify/require"
+)
+
+const rewardedABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"_address","type":"address"},{"indexed":false,"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"Rewarded","type":"event"}]`
+
+type fakeBlockchain struct {
+	Blockchainer
+}
+
+func (fakeBlockchain) ReadAutonityContractABI(number uint64) string { return "" }
+
+// upgradedBlockchain records an upgrade of the contract from the ABI before
+// at the block upgrade.
+type upgradedBlockchain struct {
+	fakeBlockchain
+	upgrade uint64
+	before  string
+}
+
+func (bc upgradedBlockchain) ReadAutonityContractABI(number uint64) string {
+	if number < bc.upgrade {
+		return bc.before
+	}
+	return ""
+}
+
+// dbBlockchain keeps the ABIs of the contract in a database.
+type dbBlockchain struct {
+	fakeBlockchain
+	db ethdb.Database
+}
+
+func (bc dbBlockchain) PutKeyValue(key []byte, value []byte) error { return bc.db.Put(key, value) }
+
+func (bc dbBlockchain) WriteAutonityContractABI(number uint64, abi string) {
+	rawdb.WriteAutonityContractABI(bc.db, number, abi)
+}
+
+func (bc dbBlockchain) ReadAutonityContractABI(number uint64) string {
+	return rawdb.ReadAutonityContractABI(bc.db, number)
+}
+
+func TestRewardDistribution(t *testing.T) {
+	ac, err := NewAutonityContract(fakeBlockchain{}, common.Address{}, 0, rewardedABI, nil)
+	require.NoError(t, err)
+	event := ac.ABI().Events["Rewarded"]
+	header := &types.Header{Number: big.NewInt(5)}
+	address1, address2 := common.HexToAddress(testAddress1), common.HexToAddress(testAddress2)
+
+	rewarded := func(address common.Address, amount int64) *types.Log {
+		data, err := event.Inputs.NonIndexed().Pack(address, big.NewInt(amount))
+		require.NoError(t, err)
+		return &types.Log{Address: ContractAddress, Topics: []common.Hash{event.ID}, Data: data}
+	}
+	receipts := types.Receipts{
+		// Rewarded events of a transaction are not rewards of the block.
+		{TxHash: common.HexToHash("01"), Logs: []*types.Log{rewarded(address1, 100)}},
+		{TxHash: common.ACHash(header.Number), Logs: []*types.Log{
+			rewarded(address1, 1),
+			{Address: ContractAddress, Topics: []common.Hash{common.HexToHash("02")}},
+			rewarded(address2, 2),
+		}},
+	}
+
+	rewards, err := ac.RewardDistribution(header, receipts)
+	require.NoError(t, err)
+	require.Equal(t, &types.RewardDistribution{
+		Number: 5,
+		Hash:   header.Hash(),
+		Amount: big.NewInt(3),
+		Rewards: []types.Reward{
+			{Address: address1, Amount: big.NewInt(1)},
+			{Address: address2, Amount: big.NewInt(2)},
+		},
+	}, rewards)
+
+	// A block without finalize receipt distributed nothing.
+	rewards, err = ac.RewardDistribution(header, receipts[:1])
+	require.NoError(t, err)
+	require.Empty(t, rewards.Rewards)
+	require.Equal(t, big.NewInt(0), rewards.Amount)
+}
+
+func TestWithEVMProvider(t *testing.T) {
+	ac, err := NewAutonityContract(upgradedBlockchain{upgrade: 5, before: minGasPriceUpdatedABI}, common.Address{}, 0, rewardedABI, nil)
+	require.NoError(t, err)
+	traced, err := ac.WithEVMProvider(&types.Header{Number: big.NewInt(6)}, nil)
+	require.NoError(t, err)
+	require.Equal(t, ac.StringABI(), traced.StringABI())
+
+	// The block upgrading the contract is finalized with the previous ABI.
+	upgrading, err := ac.WithEVMProvider(&types.Header{Number: big.NewInt(5)}, nil)
+	require.NoError(t, err)
+	require.Equal(t, minGasPriceUpdatedABI, upgrading.StringABI())
+	require.Contains(t, upgrading.ABI().Events, "MinimumGasPriceUpdated")
+
+	// fakeBlockchain panics on writes, the copy must drop them.
+	require.NoError(t, traced.bc.PutKeyValue([]byte(ABISPEC), []byte("[]")))
+	traced.bc.WriteAutonityContractABI(5, "[]")
+	traced.bc.UpdateEnodeWhitelist(&types.Nodes{})
+
+	// An upgrade replayed by the copy does not change the contract.
+	require.NoError(t, traced.upgradeAbiCache("[]"))
+	require.Equal(t, "[]", traced.StringABI())
+	require.Equal(t, rewardedABI, ac.StringABI())
+}
+
+func TestCommitUpgrade(t *testing.T) {
+	bc := dbBlockchain{db: rawdb.NewMemoryDatabase()}
+	bc.WriteAutonityContractABI(0, rewardedABI)
+	ac, err := NewAutonityContract(bc, common.Address{}, 0, rewardedABI, nil)
+	require.NoError(t, err)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(ContractAddress, []byte{0x01})
+
+	// A block not upgrading the contract leaves the ABI history untouched.
+	require.NoError(t, ac.CommitUpgrade(&types.Header{Number: big.NewInt(4)}, statedb))
+	require.Equal(t, rewardedABI, bc.ReadAutonityContractABI(4))
+
+	// The ABI of an upgrade is only persisted once its block is committed.
+	statedb.SetCode(ContractAddress, []byte{0x02})
+	ac.upgrades.Add(statedb.GetCodeHash(ContractAddress), minGasPriceUpdatedABI)
+	require.Equal(t, rewardedABI, bc.ReadAutonityContractABI(5))
+	require.NoError(t, ac.CommitUpgrade(&types.Header{Number: big.NewInt(5)}, statedb))
+	require.Equal(t, rewardedABI, bc.ReadAutonityContractABI(4))
+	require.Equal(t, minGasPriceUpdatedABI, bc.ReadAutonityContractABI(5))
+	spec, err := bc.db.Get([]byte(ABISPEC))
+	require.NoError(t, err)
+	require.Equal(t, minGasPriceUpdatedABI, string(spec))
+
+	// The next blocks run the upgraded code without upgrading the contract.
+	require.NoError(t, ac.CommitUpgrade(&types.Header{Number: big.NewInt(6)}, statedb))
+	// Block 6 has no entry of its own, it reads the one of block 5.
+	bc.WriteAutonityContractABI(5, "[]")
+	require.Equal(t, "[]", bc.ReadAutonityContractABI(6))
+}
+
+const minGasPriceUpdatedABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"uint256","name":"gasPrice","type":"uint256"}],"name":"MinimumGasPriceUpdated","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"string","name":"version","type":"string"}],"name":"ContractUpgraded","type":"event"}]`
+
+func TestUpdateMinimumGasPrice(t *testing.T) {
+	ac, err := NewAutonityContract(fakeBlockchain{}, common.Address{}, 5, minGasPriceUpdatedABI, nil)
+	require.NoError(t, err)
+	event := ac.ABI().Events["MinimumGasPriceUpdated"]
+	updated := func(price int64) *types.Log {
+		data, err := event.Inputs.Pack(big.NewInt(price))
+		require.NoError(t, err)
+		return &types.Log{Address: ContractAddress, Topics: []common.Hash{event.ID}, Data: data}
+	}
+	// The state is never read as the prices are cached.
+	price := func(header *types.Header) uint64 {
+		price, err := ac.GetMinimumGasPrice(header, nil)
+		require.NoError(t, err)
+		return price
+	}
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(ContractAddress, []byte{0x01})
+
+	genesis := &types.Header{Number: big.NewInt(0)}
+	require.Equal(t, uint64(5), price(genesis))
+
+	// A block not updating the price carries the one of its parent over.
+	header1 := &types.Header{Number: big.NewInt(1), ParentHash: genesis.Hash()}
+	require.NoError(t, ac.UpdateMinimumGasPrice(header1, statedb, nil))
+	require.Equal(t, uint64(5), price(header1))
+
+	// The last update of a block prevails, the events of other contracts are ignored.
+	header2 := &types.Header{Number: big.NewInt(2), ParentHash: header1.Hash()}
+	other := updated(1)
+	other.Address = common.HexToAddress("0x01")
+	receipts := types.Receipts{
+		{Logs: []*types.Log{updated(7)}},
+		{Logs: []*types.Log{updated(9), other}},
+	}
+	require.NoError(t, ac.UpdateMinimumGasPrice(header2, statedb, receipts))
+	require.Equal(t, uint64(9), price(header2))
+
+	header3 := &types.Header{Number: big.NewInt(3), ParentHash: header2.Hash()}
+	require.NoError(t, ac.UpdateMinimumGasPrice(header3, statedb, nil))
+	require.Equal(t, uint64(9), price(header3))
+
+	// The price on top of an upgrade, told by the event or by the new code,
+	// is read from the state.
+	upgradeEvent := ac.ABI().Events["ContractUpgraded"]
+	data, err := upgradeEvent.Inputs.Pack("v2")
+	require.NoError(t, err)
+	header4 := &types.Header{Number: big.NewInt(4), ParentHash: header3.Hash()}
+	receipts = types.Receipts{{Logs: []*types.Log{{Address: ContractAddress, Topics: []common.Hash{upgradeEvent.ID}, Data: data}}}}
+	require.NoError(t, ac.UpdateMinimumGasPrice(header4, statedb, receipts))
+	require.False(t, ac.minGasPrices.Contains(header4.Hash()))
+
+	statedb.SetCode(ContractAddress, []byte{0x02})
+	header5 := &types.Header{Number: big.NewInt(5), ParentHash: header3.Hash()}
+	require.NoError(t, ac.UpdateMinimumGasPrice(header5, statedb, nil))
+	require.False(t, ac.minGasPrices.Contains(header5.Hash()))
+
+	// Nor is a price carried over from a parent whose code is unknown.
+	header6 := &types.Header{Number: big.NewInt(6), ParentHash: common.HexToHash("0x06")}
+	ac.minGasPrices.Add(header6.ParentHash, uint64(9))
+	require.NoError(t, ac.UpdateMinimumGasPrice(header6, statedb, nil))
+	require.False(t, ac.minGasPrices.Contains(header6.Hash()))
+
+	// A contract without the event can update the price silently, so the
+	// price on top of its blocks is always read from the state.
+	legacy, err := NewAutonityContract(fakeBlockchain{}, common.Address{}, 5, `[]`, nil)
+	require.NoError(t, err)
+	require.NoError(t, legacy.UpdateMinimumGasPrice(header1, statedb, nil))
+	require.NoError(t, legacy.UpdateMinimumGasPrice(header2, statedb, nil))
+	require.False(t, legacy.minGasPrices.Contains(header2.Hash()))
+}
@@ -16,20 +16,20 @@ import (
 )
 
 /*
- * ContractState is a unified structure to represent the autonity contract state.
+ * ContractState is a unified structure to represent the autonity contract state,
+ * as dumped by getState to be passed to the constructor of an upgraded contract.
  * By using a unified structure, the new state meta introduced in the Autonity.sol
  * should be synced with this structure.
  */
 type ContractState struct {
-	Users           []common.Address `abi:"users"`
-	Enodes          []string         `abi:"enodes"`
-	Types           []*big.Int       `abi:"types"`
-	Stakes          []*big.Int       `abi:"stakes"`
-	CommissionRates []*big.Int       `abi:"commisionrates"`
-	Operator        common.Address   `abi:"operator"`
-	Deployer        common.Address   `abi:"deployer"`
-	MinGasPrice     *big.Int         `abi:"mingasprice"`
-	BondingPeriod   *big.Int         `abi:"bondingperiod"`
+	Users         []common.Address `abi:"_addr"            json:"users"`
+	Enodes        []string         `abi:"_enode"           json:"enodes"`
+	Types         []*big.Int       `abi:"_userType"        json:"types"`
+	Stakes        []*big.Int       `abi:"_stake"           json:"stakes"`
+	Operator      common.Address   `abi:"_operatorAccount" json:"operator"`
+	MinGasPrice   *big.Int         `abi:"_minGasPrice"     json:"minGasPrice"`
+	CommitteeSize *big.Int         `abi:"_committeeSize"   json:"committeeSize"`
+	Version       string           `abi:"_contractVersion" json:"version"`
 }
 
 type raw []byte
@@ -80,29 +80,69 @@ func DeployContract(abi *abi.ABI, autonityConfig *params.AutonityContractGenesis
 	}
 	log.Info("Deployed Autonity Contract", "Address", ContractAddress.String())
 
+	blsKeys := make(map[common.Address][]byte)
+	for _, v := range autonityConfig.Users {
+		if len(v.BLSKey) > 0 {
+			blsKeys[*v.Address] = v.BLSKey
+		}
+	}
+	return setBLSKeys(abi, evm, blsKeys)
+}
+
+// setBLSKeys registers the given BLS keys in the autonity contract, it does
+// nothing if the contract does not support BLS keys.
+func setBLSKeys(contractABI *abi.ABI, evm *vm.EVM, keys map[common.Address][]byte) error {
+	if _, ok := contractABI.Methods["setBLSKey"]; !ok || len(keys) == 0 {
+		return nil
+	}
+	addresses := make(common.Addresses, 0, len(keys))
+	for address := range keys {
+		addresses = append(addresses, address)
+	}
+	// Keep the contract state independent of the map iteration order.
+	sort.Sort(addresses)
+	for _, address := range addresses {
+		input, err := contractABI.Pack("setBLSKey", address, keys[address])
+		if err != nil {
+			return err
+		}
+		_, _, vmerr := evm.Call(vm.AccountRef(Deployer), ContractAddress, input, uint64(0xFFFFFFFF), new(big.Int))
+		if vmerr != nil {
+			log.Error("Error Autonity Contract setBLSKey()", "address", address, "err", vmerr)
+			return vmerr
+		}
+	}
 	return nil
 }
 
-func (ac *Contract) updateAutonityContract(header *types.Header, statedb *state.StateDB, bytecode string, state []byte) error {
+// updateAutonityContract deploys bytecode at the address of the autonity
+// contract with the given state as constructor arguments, and returns the gas
+// the deployment used.
+func (ac *Contract) updateAutonityContract(header *types.Header, statedb *state.StateDB, bytecode string, state []byte) (uint64, error) {
 	evm := ac.evmProvider.EVM(header, Deployer, statedb)
 	contractBytecode := common.Hex2Bytes(bytecode)
 	data := append(contractBytecode, state...)
 	gas := uint64(0xFFFFFFFF)
 	value := new(big.Int).SetUint64(0x00)
-	_, _, _, vmerr := evm.CreateWithAddress(vm.AccountRef(Deployer), data, gas, value, ContractAddress)
+	_, _, leftOverGas, vmerr := evm.CreateWithAddress(vm.AccountRef(Deployer), data, gas, value, ContractAddress)
 	if vmerr != nil {
 		log.Error("updateAutonityContract evm.Create", "err", vmerr)
-		return vmerr
+		return gas - leftOverGas, vmerr
 	}
-	return nil
+	return gas - leftOverGas, nil
 }
 
 // AutonityContractCall calls the specified function of the autonity contract
 // with the given args, and returns the output unpacked into the result
 // interface.
 func (ac *Contract) AutonityContractCall(statedb *state.StateDB, header *types.Header, function string, result interface{}, args ...interface{}) error {
+	return ac.contractCall(ac.contractABI, statedb, header, function, result, args...)
+}
 
-	packedArgs, err := ac.contractABI.Pack(function, args...)
+// contractCall is AutonityContractCall with the given contract ABI, which need
+// not be the current one.
+func (ac *Contract) contractCall(contractABI *abi.ABI, statedb *state.StateDB, header *types.Header, function string, result interface{}, args ...interface{}) error {
+	packedArgs, err := contractABI.Pack(function, args...)
 	if err != nil {
 		return err
 	}
@@ -118,7 +158,7 @@ func (ac *Contract) AutonityContractCall(statedb *state.StateDB, header *types.H
 		return nil
 	}
 
-	if err := ac.contractABI.UnpackIntoInterface(result, function, ret); err != nil {
+	if err := contractABI.UnpackIntoInterface(result, function, ret); err != nil {
 		log.Error("Could not unpack returned value", "function", function)
 		return err
 	}
@@ -178,11 +218,64 @@ func (ac *Contract) callFinalize(state *state.StateDB, header *types.Header, blo
 		return false, nil, err
 	}
 	sort.Sort(committee)
-	// submit the final reward distribution metrics.
-	//ac.metrics.SubmitRewardDistributionMetrics(&v, header.Number.Uint64())
 	return updateReady, committee, nil
 }
 
+// callGetBLSKeys returns the BLS keys registered in the contract of the given
+// ABI, followed by their proof of possession. It returns no key if the
+// contract does not support them.
+func (ac *Contract) callGetBLSKeys(contractABI *abi.ABI, state *state.StateDB, header *types.Header) (map[common.Address][]byte, error) {
+	if _, ok := contractABI.Methods["getBLSKeys"]; !ok {
+		return nil, nil
+	}
+	var addresses []common.Address
+	var keys [][]byte
+	err := ac.contractCall(contractABI, state, header, "getBLSKeys", &[]interface{}{&addresses, &keys})
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) != len(keys) {
+		return nil, ErrWrongParameter
+	}
+	blsKeys := make(map[common.Address][]byte, len(addresses))
+	for i, address := range addresses {
+		blsKeys[address] = keys[i]
+	}
+	return blsKeys, nil
+}
+
+// callReportDowntime reports the committee members which missed the parent of
+// header to the contract, it does nothing if the contract does not track
+// downtime.
+func (ac *Contract) callReportDowntime(state *state.StateDB, header *types.Header, absentees []common.Address) error {
+	if _, ok := ac.contractABI.Methods["reportDowntime"]; !ok {
+		return nil
+	}
+	input, err := ac.contractABI.Pack("reportDowntime", absentees)
+	if err != nil {
+		return err
+	}
+	if _, err := ac.CallContractFunc(state, header, "reportDowntime", input); err != nil {
+		log.Error("Error Autonity Contract reportDowntime()", "err", err)
+		return err
+	}
+	return nil
+}
+
+// callComputeCommittee elects the committee of the next block in the contract,
+// which finalize returns from then on.
+func (ac *Contract) callComputeCommittee(state *state.StateDB, header *types.Header) error {
+	input, err := ac.contractABI.Pack("computeCommittee")
+	if err != nil {
+		return err
+	}
+	if _, err := ac.CallContractFunc(state, header, "computeCommittee", input); err != nil {
+		log.Error("Error Autonity Contract computeCommittee()", "err", err)
+		return err
+	}
+	return nil
+}
+
 func (ac *Contract) callRetrieveState(statedb *state.StateDB, header *types.Header) ([]byte, error) {
 	var state raw
 
@@ -42,6 +42,13 @@ contract Autonity is IERC20 {
 
     mapping (address => mapping (address => uint256)) private allowances;
 
+    /* BLS public keys followed by their proof of possession, carried over by the protocol during a contract upgrade. */
+    mapping (address => bytes) private blsKeys;
+    uint256 constant BLS_KEY_LENGTH = 288;
+
+    /* Number of blocks each user missed while in the committee, reset by a contract upgrade. */
+    mapping (address => uint256) private downtime;
+
     /* State data that will be recomputed during a contract upgrade. */
     address[] private validators;
     address[] private stakeholders;
@@ -71,6 +78,8 @@ contract Autonity is IERC20 {
     event MintedStake(address _address, uint256 _amount);
     event BurnedStake(address _address, uint256 _amount);
     event Rewarded(address _address, uint256 _amount);
+    event BLSKeyRegistered(address _address);
+    event Downtime(address _address, uint256 _missedBlocks);
 
     /**
      * @dev Emitted when the Minimum Gas Price was updated and set to `gasPrice`.
@@ -148,6 +157,37 @@ contract Autonity is IERC20 {
         emit UserAdded(_address, _role, _stake);
     }
 
+    /**
+    * @notice Register the BLS key of the caller, used to aggregate its committed seals.
+    * @param _key the BLS public key followed by its proof of possession. The proof is
+    * checked by the protocol, a key with an invalid proof is ignored.
+    * @dev emit a {BLSKeyRegistered} event.
+    */
+    function registerBLSKey(bytes memory _key) public {
+        require(users[msg.sender].addr != address(0), "user must exist");
+        _setBLSKey(msg.sender, _key);
+    }
+
+    /**
+    * @notice Set the BLS key of a user. Called by the protocol at genesis and during a contract upgrade.
+    */
+    function setBLSKey(address _address, bytes memory _key) external onlyProtocol(msg.sender) {
+        require(users[_address].addr != address(0), "user must exist");
+        _setBLSKey(_address, _key);
+    }
+
+    /**
+    * @notice Report the committee members which missed the previous block. Called by the protocol
+    * before the finalization of each block.
+    * @dev emit a {Downtime} event for every member reported.
+    */
+    function reportDowntime(address[] memory _absentees) external onlyProtocol(msg.sender) {
+        for (uint256 i = 0; i < _absentees.length; i++) {
+            downtime[_absentees[i]]++;
+            emit Downtime(_absentees[i], downtime[_absentees[i]]);
+        }
+    }
+
     /**
     * @notice Change the user account type. Restricted to the operator account.
     */
@@ -278,14 +318,14 @@ contract Autonity is IERC20 {
     *
     * @param amount The amount of transaction fees collected for this block.
     * @return upgrade Set to true if an autonity contract upgrade is available.
-    * @return committee The next block consensus committee.
+    * @return committee The next block consensus committee. It only changes at the epoch
+    * headers, where the protocol calls computeCommittee beforehand.
     */
     function finalize(uint256 amount) external onlyProtocol(msg.sender)
         returns(bool , CommitteeMember[] memory) {
 
         _performRedistribution(amount);
         bool _updateAvailable = bytes(bytecode).length != 0;
-        computeCommittee();
         return (_updateAvailable, committee);
     }
 
@@ -386,6 +426,36 @@ contract Autonity is IERC20 {
         return users[_account];
     }
 
+    /**
+    * @notice Returns the users having registered a BLS key along with their key.
+    */
+    function getBLSKeys() external view returns (address[] memory, bytes[] memory) {
+        uint256 _count = 0;
+        for (uint256 i = 0; i < usersList.length; i++) {
+            if (blsKeys[usersList[i]].length != 0) {
+                _count++;
+            }
+        }
+        address[] memory _addr = new address[](_count);
+        bytes[] memory _keys = new bytes[](_count);
+        uint256 _j = 0;
+        for (uint256 i = 0; i < usersList.length; i++) {
+            if (blsKeys[usersList[i]].length != 0) {
+                _addr[_j] = usersList[i];
+                _keys[_j] = blsKeys[usersList[i]];
+                _j++;
+            }
+        }
+        return (_addr, _keys);
+    }
+
+    /**
+    * @return Returns the number of blocks `_account` missed while in the committee.
+    */
+    function getDowntime(address _account) external view returns (uint256) {
+        return downtime[_account];
+    }
+
     /**
     * @return Returns the maximum size of the consensus committee.
     */
@@ -457,10 +527,9 @@ contract Autonity is IERC20 {
 
     /**
     * @notice update the current committee by selecting top staking validators.
-    * Restricted to the protocol.
+    * Called by the protocol before finalize at the epoch headers. Restricted to the protocol.
     */
     function computeCommittee() public onlyProtocol(msg.sender) {
-        // Left public for testing purposes.
         require(validators.length > 0, "There must be validators");
         uint _len = validators.length;
         uint256 _committeeLength = committeeSize;
@@ -615,8 +684,10 @@ contract Autonity is IERC20 {
         if(newUserType == UserType.Participant){
             require(u.stake == 0);
         }
+        bytes memory _blsKey = blsKeys[u.addr];
         _removeUser(u.addr);
         _createUser(u.addr, u.enode, newUserType, u.stake);
+        blsKeys[u.addr] = _blsKey;
 
         emit ChangedUserType(u.addr , u.userType , newUserType);
     }
@@ -647,6 +718,7 @@ contract Autonity is IERC20 {
         stakeSupply = stakeSupply.sub(u.stake);
         _removeFromArray(u.addr, usersList);
         delete users[_address];
+        delete blsKeys[_address];
         emit RemovedUser(_address, u.userType);
     }
 
@@ -680,6 +752,12 @@ contract Autonity is IERC20 {
 
 
 
+    function _setBLSKey(address _address, bytes memory _key) internal {
+        require(_key.length == BLS_KEY_LENGTH, "invalid BLS key length");
+        blsKeys[_address] = _key;
+        emit BLSKeyRegistered(_address);
+    }
+
     /**
     * @dev Order validators by stake
     */
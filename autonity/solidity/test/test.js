@@ -700,6 +700,84 @@ contract('Autonity', function (accounts) {
         });
     });
 
+    describe('BLS keys', function() {
+        const blsKey = '0x' + 'ab'.repeat(288);
+
+        beforeEach(async function(){
+            token = await utils.deployContract(validatorsList, whiteList,
+                userTypes, stakes, operator, minGasPrice, committeeSize, version, {from: deployer});
+        });
+
+        it('test user can register its BLS key', async function () {
+            await token.registerBLSKey(blsKey, {from: validatorsList[0]});
+            let keys = await token.getBLSKeys();
+            assert.deepEqual(keys[0], [validatorsList[0]]);
+            assert.deepEqual(keys[1], [blsKey]);
+        });
+
+        it('test BLS key of invalid length is rejected', async function () {
+            try {
+                await token.registerBLSKey('0x' + 'ab'.repeat(96), {from: validatorsList[0]});
+                assert.fail('Expected throw not received');
+            } catch (e) {
+                assert(e.message.includes("invalid BLS key length"), e.message);
+            }
+        });
+
+        it('test non user cannot register a BLS key', async function () {
+            try {
+                await token.registerBLSKey(blsKey, {from: accounts[7]});
+                assert.fail('Expected throw not received');
+            } catch (e) {
+                assert(e.message.includes("user must exist"), e.message);
+            }
+        });
+
+        it('test only the protocol can set the BLS key of a user', async function () {
+            try {
+                await token.setBLSKey(validatorsList[1], blsKey, {from: operator});
+                assert.fail('Expected throw not received');
+            } catch (e) {
+                assert(e.message.includes("function restricted to the protocol"), e.message);
+            }
+            await token.setBLSKey(validatorsList[1], blsKey, {from: deployer});
+            let keys = await token.getBLSKeys();
+            assert.deepEqual(keys[0], [validatorsList[1]]);
+        });
+
+        it('test BLS key is removed with its user', async function () {
+            await token.registerBLSKey(blsKey, {from: validatorsList[0]});
+            await token.removeUser(validatorsList[0], {from: operator});
+            let keys = await token.getBLSKeys();
+            assert.deepEqual(keys[0], []);
+        });
+    });
+
+    describe('Downtime', function() {
+
+        beforeEach(async function(){
+            token = await utils.deployContract(validatorsList, whiteList,
+                userTypes, stakes, operator, minGasPrice, committeeSize, version, {from: deployer});
+        });
+
+        it('test protocol can report the members which missed a block', async function () {
+            await token.reportDowntime([validatorsList[0], validatorsList[1]], {from: deployer});
+            await token.reportDowntime([validatorsList[0]], {from: deployer});
+            assert.equal(await token.getDowntime(validatorsList[0]), 2);
+            assert.equal(await token.getDowntime(validatorsList[1]), 1);
+            assert.equal(await token.getDowntime(validatorsList[2]), 0);
+        });
+
+        it('test only the protocol can report downtime', async function () {
+            try {
+                await token.reportDowntime([validatorsList[0]], {from: operator});
+                assert.fail('Expected throw not received');
+            } catch (e) {
+                assert(e.message.includes("function restricted to the protocol"), e.message);
+            }
+        });
+    });
+
     describe('Proposer selection, Normal case.', function() {
 
         beforeEach(async function(){
@@ -0,0 +1,217 @@
+package autonity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/clearmatics/autonity/accounts/abi"
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/types"
+)
+
+// requiredFunctions are the functions of the autonity contract called by the
+// node, an upgraded contract missing one of them would stall the chain, could
+// not be upgraded again or would silently drop the epoch committees or the
+// BLS keys of the validators.
+var requiredFunctions = []string{
+	"finalize",
+	"computeCommittee",
+	"getCommittee",
+	"getProposer",
+	"getMinimumGasPrice",
+	"getWhitelist",
+	"dumpEconomicMetrics",
+	"getState",
+	"getNewContract",
+	"getBLSKeys",
+	"setBLSKey",
+}
+
+// UpgradeReport is the outcome of an upgrade of the autonity contract run by
+// SimulateUpgrade.
+type UpgradeReport struct {
+	Number           uint64          `json:"number"`
+	Success          bool            `json:"success"` // Whether the new contract was deployed
+	Error            string          `json:"error,omitempty"`
+	GasUsed          uint64          `json:"gasUsed"`
+	MissingFunctions []string        `json:"missingFunctions"`
+	StateBefore      *ContractState  `json:"stateBefore"`
+	StateAfter       *ContractState  `json:"stateAfter"`
+	CommitteeBefore  types.Committee `json:"committeeBefore"`
+	CommitteeAfter   types.Committee `json:"committeeAfter"`
+	Diff             []string        `json:"diff"`
+	Warnings         []string        `json:"warnings"` // Calls to the new contract which failed
+}
+
+// SimulateUpgrade upgrades the autonity contract to the given bytecode and ABI
+// on a copy of the state of the block of header, the way ApplyFinalize would
+// if the upgrade was triggered in that block, and reports the outcome along
+// with the changes to the contract state and the committee. An error is only
+// returned if the current contract could not be read, an upgrade which fails
+// is reported as such.
+func (ac *Contract) SimulateUpgrade(statedb *state.StateDB, header *types.Header, bytecode, newAbi string) (*UpgradeReport, error) {
+	statedb = statedb.Copy()
+	report := &UpgradeReport{Number: header.Number.Uint64()}
+
+	currentABI, err := ac.ABIAt(header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	var stateBefore raw
+	if err := ac.contractCall(currentABI, statedb, header, "getState", &stateBefore); err != nil {
+		return nil, err
+	}
+	if report.StateBefore, err = unpackState(currentABI, stateBefore); err != nil {
+		return nil, err
+	}
+	if err := ac.contractCall(currentABI, statedb, header, "getCommittee", &report.CommitteeBefore); err != nil {
+		return nil, err
+	}
+	sort.Sort(report.CommitteeBefore)
+	blsKeys, err := ac.callGetBLSKeys(currentABI, statedb, header)
+	if err != nil {
+		return nil, err
+	}
+
+	newContractABI, err := abi.JSON(strings.NewReader(newAbi))
+	if err != nil {
+		report.Error = fmt.Sprintf("invalid ABI: %v", err)
+		return report, nil
+	}
+	report.MissingFunctions = missingFunctions(&newContractABI)
+
+	statedb.CreateAccount(ContractAddress)
+	report.GasUsed, err = ac.updateAutonityContract(header, statedb, bytecode, stateBefore)
+	if err != nil {
+		report.Error = err.Error()
+		return report, nil
+	}
+	if err := setBLSKeys(&newContractABI, ac.evmProvider.EVM(header, Deployer, statedb), blsKeys); err != nil {
+		report.Error = fmt.Sprintf("setting the BLS keys: %v", err)
+		return report, nil
+	}
+	report.Success = true
+
+	// The missing functions are already reported.
+	if _, ok := newContractABI.Methods["getState"]; ok {
+		var stateAfter raw
+		if err := ac.contractCall(&newContractABI, statedb, header, "getState", &stateAfter); err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("getState: %v", err))
+		} else if report.StateAfter, err = unpackState(&newContractABI, stateAfter); err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("getState: %v", err))
+		} else {
+			report.Diff = append(report.Diff, diffStates(report.StateBefore, report.StateAfter)...)
+		}
+	}
+	if _, ok := newContractABI.Methods["getCommittee"]; ok {
+		if err := ac.contractCall(&newContractABI, statedb, header, "getCommittee", &report.CommitteeAfter); err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("getCommittee: %v", err))
+		} else {
+			sort.Sort(report.CommitteeAfter)
+			report.Diff = append(report.Diff, diffCommittees(report.CommitteeBefore, report.CommitteeAfter)...)
+		}
+	}
+	return report, nil
+}
+
+// unpackState decodes the output of getState.
+func unpackState(contractABI *abi.ABI, packed []byte) (*ContractState, error) {
+	var contractState ContractState
+	if err := contractABI.UnpackIntoInterface(&contractState, "getState", packed); err != nil {
+		return nil, err
+	}
+	n := len(contractState.Users)
+	if len(contractState.Enodes) != n || len(contractState.Types) != n || len(contractState.Stakes) != n {
+		return nil, ErrWrongParameter
+	}
+	return &contractState, nil
+}
+
+// missingFunctions returns the required functions the contract ABI lacks.
+func missingFunctions(contractABI *abi.ABI) []string {
+	var missing []string
+	for _, name := range requiredFunctions {
+		if _, ok := contractABI.Methods[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// diffStates describes the changes from one contract state to another, one
+// change per line.
+func diffStates(before, after *ContractState) []string {
+	var diff []string
+	index := func(s *ContractState) map[common.Address]int {
+		users := make(map[common.Address]int, len(s.Users))
+		for i, user := range s.Users {
+			users[user] = i
+		}
+		return users
+	}
+	usersBefore, usersAfter := index(before), index(after)
+
+	for i, user := range before.Users {
+		j, ok := usersAfter[user]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("user %s removed", user.Hex()))
+			continue
+		}
+		if before.Enodes[i] != after.Enodes[j] {
+			diff = append(diff, fmt.Sprintf("user %s enode: %s -> %s", user.Hex(), before.Enodes[i], after.Enodes[j]))
+		}
+		if before.Types[i].Cmp(after.Types[j]) != 0 {
+			diff = append(diff, fmt.Sprintf("user %s type: %v -> %v", user.Hex(), before.Types[i], after.Types[j]))
+		}
+		if before.Stakes[i].Cmp(after.Stakes[j]) != 0 {
+			diff = append(diff, fmt.Sprintf("user %s stake: %v -> %v", user.Hex(), before.Stakes[i], after.Stakes[j]))
+		}
+	}
+	for j, user := range after.Users {
+		if _, ok := usersBefore[user]; !ok {
+			diff = append(diff, fmt.Sprintf("user %s added with type %v and stake %v", user.Hex(), after.Types[j], after.Stakes[j]))
+		}
+	}
+
+	if before.Operator != after.Operator {
+		diff = append(diff, fmt.Sprintf("operator: %s -> %s", before.Operator.Hex(), after.Operator.Hex()))
+	}
+	if before.MinGasPrice.Cmp(after.MinGasPrice) != 0 {
+		diff = append(diff, fmt.Sprintf("minimum gas price: %v -> %v", before.MinGasPrice, after.MinGasPrice))
+	}
+	if before.CommitteeSize.Cmp(after.CommitteeSize) != 0 {
+		diff = append(diff, fmt.Sprintf("committee size: %v -> %v", before.CommitteeSize, after.CommitteeSize))
+	}
+	if before.Version != after.Version {
+		diff = append(diff, fmt.Sprintf("version: %s -> %s", before.Version, after.Version))
+	}
+	return diff
+}
+
+// diffCommittees describes the changes from one committee to another, one
+// change per line.
+func diffCommittees(before, after types.Committee) []string {
+	var diff []string
+	members := make(map[common.Address]*types.CommitteeMember, len(after))
+	for i := range after {
+		members[after[i].Address] = &after[i]
+	}
+	for _, member := range before {
+		next, ok := members[member.Address]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("committee member %s removed", member.Address.Hex()))
+		case member.VotingPower.Cmp(next.VotingPower) != 0:
+			diff = append(diff, fmt.Sprintf("committee member %s voting power: %v -> %v", member.Address.Hex(), member.VotingPower, next.VotingPower))
+		}
+		delete(members, member.Address)
+	}
+	for _, member := range after {
+		if _, ok := members[member.Address]; ok {
+			diff = append(diff, fmt.Sprintf("committee member %s added with voting power %v", member.Address.Hex(), member.VotingPower))
+		}
+	}
+	return diff
+}
@@ -0,0 +1,103 @@
+package autonity
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/clearmatics/autonity/accounts/abi"
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const getStateABI = `[{"inputs":[],"name":"getState","outputs":[{"internalType":"address[]","name":"_addr","type":"address[]"},{"internalType":"string[]","name":"_enode","type":"string[]"},{"internalType":"uint256[]","name":"_userType","type":"uint256[]"},{"internalType":"uint256[]","name":"_stake","type":"uint256[]"},{"internalType":"address","name":"_operatorAccount","type":"address"},{"internalType":"uint256","name":"_minGasPrice","type":"uint256"},{"internalType":"uint256","name":"_committeeSize","type":"uint256"},{"internalType":"string","name":"_contractVersion","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getCommittee","outputs":[],"stateMutability":"view","type":"function"}]`
+
+func TestUnpackState(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(getStateABI))
+	require.NoError(t, err)
+	user := common.HexToAddress(testAddress1)
+	operator := common.HexToAddress(testAddress2)
+
+	packed, err := contractABI.Methods["getState"].Outputs.Pack(
+		[]common.Address{user}, []string{"enode"}, []*big.Int{big.NewInt(2)}, []*big.Int{big.NewInt(10)},
+		operator, big.NewInt(5), big.NewInt(21), "v1.0.0")
+	require.NoError(t, err)
+	contractState, err := unpackState(&contractABI, packed)
+	require.NoError(t, err)
+	require.Equal(t, &ContractState{
+		Users:         []common.Address{user},
+		Enodes:        []string{"enode"},
+		Types:         []*big.Int{big.NewInt(2)},
+		Stakes:        []*big.Int{big.NewInt(10)},
+		Operator:      operator,
+		MinGasPrice:   big.NewInt(5),
+		CommitteeSize: big.NewInt(21),
+		Version:       "v1.0.0",
+	}, contractState)
+
+	packed, err = contractABI.Methods["getState"].Outputs.Pack(
+		[]common.Address{user}, []string{}, []*big.Int{big.NewInt(2)}, []*big.Int{big.NewInt(10)},
+		operator, big.NewInt(5), big.NewInt(21), "v1.0.0")
+	require.NoError(t, err)
+	_, err = unpackState(&contractABI, packed)
+	require.Equal(t, ErrWrongParameter, err)
+}
+
+func TestMissingFunctions(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(getStateABI))
+	require.NoError(t, err)
+	require.Equal(t, []string{"finalize", "computeCommittee", "getProposer", "getMinimumGasPrice", "getWhitelist", "dumpEconomicMetrics", "getNewContract", "getBLSKeys", "setBLSKey"},
+		missingFunctions(&contractABI))
+}
+
+func TestDiffStates(t *testing.T) {
+	kept, removed, added := common.HexToAddress(testAddress1), common.HexToAddress(testAddress2), common.HexToAddress(testAddress3)
+	before := &ContractState{
+		Users:         []common.Address{kept, removed},
+		Enodes:        []string{"a", "b"},
+		Types:         []*big.Int{big.NewInt(2), big.NewInt(2)},
+		Stakes:        []*big.Int{big.NewInt(10), big.NewInt(20)},
+		Operator:      kept,
+		MinGasPrice:   big.NewInt(5),
+		CommitteeSize: big.NewInt(21),
+		Version:       "v1.0.0",
+	}
+	require.Empty(t, diffStates(before, before))
+
+	after := &ContractState{
+		Users:         []common.Address{added, kept},
+		Enodes:        []string{"c", "a"},
+		Types:         []*big.Int{big.NewInt(0), big.NewInt(2)},
+		Stakes:        []*big.Int{big.NewInt(0), big.NewInt(15)},
+		Operator:      kept,
+		MinGasPrice:   big.NewInt(5),
+		CommitteeSize: big.NewInt(21),
+		Version:       "v2.0.0",
+	}
+	require.Equal(t, []string{
+		"user " + kept.Hex() + " stake: 10 -> 15",
+		"user " + removed.Hex() + " removed",
+		"user " + added.Hex() + " added with type 0 and stake 0",
+		"version: v1.0.0 -> v2.0.0",
+	}, diffStates(before, after))
+}
+
+func TestDiffCommittees(t *testing.T) {
+	kept, removed, added := common.HexToAddress(testAddress1), common.HexToAddress(testAddress2), common.HexToAddress(testAddress3)
+	before := types.Committee{
+		{Address: kept, VotingPower: big.NewInt(10)},
+		{Address: removed, VotingPower: big.NewInt(20)},
+	}
+	require.Empty(t, diffCommittees(before, before))
+
+	after := types.Committee{
+		{Address: added, VotingPower: big.NewInt(5)},
+		{Address: kept, VotingPower: big.NewInt(15)},
+	}
+	require.Equal(t, []string{
+		"committee member " + kept.Hex() + " voting power: 10 -> 15",
+		"committee member " + removed.Hex() + " removed",
+		"committee member " + added.Hex() + " added with voting power 5",
+	}, diffCommittees(before, after))
+}
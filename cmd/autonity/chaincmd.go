@@ -18,14 +18,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	"github.com/clearmatics/autonity/metrics"
 	"github.com/davecgh/go-spew/spew"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -170,6 +173,25 @@ Remove blockchain and state databases`,
 		Description: `
 The arguments are interpreted as block numbers or hashes.
 Use "ethereum dump 0" to dump the genesis block.`,
+	}
+	simulateUpgradeCommand = cli.Command{
+		Action:    utils.MigrateFlags(simulateUpgrade),
+		Name:      "simulate-upgrade",
+		Usage:     "Simulate an upgrade of the Autonity contract",
+		ArgsUsage: "<bytecodeFile> <abiFile> [<blockHash> | <blockNum>]",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.CacheFlag,
+			utils.SyncModeFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Upgrades the Autonity contract to the bytecode and ABI of the given files on a
+copy of the state of the given block, the head block by default, as it would
+happen if the upgrade was triggered in that block. It prints whether the new
+contract was deployed, the gas it used, the contract functions the node calls
+it is missing, and how the contract state and the committee change.
+The command fails if the upgrade would fail or miss functions.`,
 	}
 	inspectCommand = cli.Command{
 		Action:    utils.MigrateFlags(inspect),
@@ -582,6 +604,56 @@ func dump(ctx *cli.Context) error {
 	return nil
 }
 
+func simulateUpgrade(ctx *cli.Context) error {
+	if len(ctx.Args()) < 2 || len(ctx.Args()) > 3 {
+		utils.Fatalf("This command requires the bytecode and ABI files and an optional block.")
+	}
+	bytecode, err := ioutil.ReadFile(ctx.Args().Get(0))
+	if err != nil {
+		utils.Fatalf("Could not read bytecode: %v", err)
+	}
+	contractABI, err := ioutil.ReadFile(ctx.Args().Get(1))
+	if err != nil {
+		utils.Fatalf("Could not read ABI: %v", err)
+	}
+
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack, true)
+	defer chainDb.Close()
+	contract := chain.GetAutonityContract()
+	if contract == nil {
+		utils.Fatalf("No Autonity contract")
+	}
+	block := chain.CurrentBlock()
+	if arg := ctx.Args().Get(2); arg != "" {
+		if hashish(arg) {
+			block = chain.GetBlockByHash(common.HexToHash(arg))
+		} else {
+			num, _ := strconv.Atoi(arg)
+			block = chain.GetBlockByNumber(uint64(num))
+		}
+	}
+	if block == nil {
+		utils.Fatalf("block not found")
+	}
+	statedb, err := chain.StateAt(block.Root())
+	if err != nil {
+		utils.Fatalf("could not create new state: %v", err)
+	}
+	report, err := contract.SimulateUpgrade(statedb, block.Header(), strings.TrimSpace(string(bytecode)), string(contractABI))
+	if err != nil {
+		utils.Fatalf("Could not simulate the upgrade: %v", err)
+	}
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+	if !report.Success || len(report.MissingFunctions) > 0 {
+		return errors.New("the upgrade would break the contract")
+	}
+	return nil
+}
+
 func inspect(ctx *cli.Context) error {
 	node, _ := makeConfigNode(ctx)
 	defer node.Close()
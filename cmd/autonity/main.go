@@ -132,6 +132,12 @@ var (
 		utils.LegacyMinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerNoVerfiyFlag,
+		utils.TendermintTraceFlag,
+		utils.TendermintTraceSizeFlag,
+		utils.TendermintSentriesFlag,
+		utils.TendermintSentryValidatorsFlag,
+		utils.TendermintSignerFlag,
+		utils.TendermintSafetyMarginFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV5Flag,
@@ -216,6 +222,7 @@ func init() {
 		removedbCommand,
 		dumpCommand,
 		inspectCommand,
+		simulateUpgradeCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -230,6 +237,8 @@ func init() {
 		licenseCommand,
 		// See config.go
 		dumpConfigCommand,
+		// See snapshot.go
+		snapshotCommand,
 		// See retesteth.go
 		retestethCommand,
 		// See cmd/utils/flags_legacy.go
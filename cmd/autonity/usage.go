@@ -180,6 +180,17 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.MinerNoVerfiyFlag,
 		},
 	},
+	{
+		Name: "TENDERMINT",
+		Flags: []cli.Flag{
+			utils.TendermintTraceFlag,
+			utils.TendermintTraceSizeFlag,
+			utils.TendermintSentriesFlag,
+			utils.TendermintSentryValidatorsFlag,
+			utils.TendermintSignerFlag,
+			utils.TendermintSafetyMarginFlag,
+		},
+	},
 	{
 		Name: "GAS PRICE ORACLE",
 		Flags: []cli.Flag{
@@ -90,7 +90,8 @@ func NewGenesis(minGasPrice uint64, users []*User) (*core.Genesis, error) {
 			PetersburgBlock:     big.NewInt(0),
 			IstanbulBlock:       big.NewInt(0),
 			Tendermint: &config.Config{
-				BlockPeriod: 1,
+				BlockPeriod:    1,
+				ProposerPolicy: config.WeightedRandomSampling,
 			},
 			AutonityContractConfig: &params.AutonityContractGenesis{
 				MinGasPrice: minGasPrice,
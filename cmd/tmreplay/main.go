@@ -0,0 +1,103 @@
+// tmreplay replays a consensus trace recorded with --tendermint.trace and
+// reports where the replayed state machine decides differently than the node.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/clearmatics/autonity/consensus/tendermint/core"
+)
+
+var (
+	divergedOnly = flag.Bool("diverged", false, "print only the steps whose decisions diverge")
+	stopFirst    = flag.Bool("stop", false, "stop at the first diverging step")
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-diverged] [-stop] [filename]")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, `
+Feeds the inputs of a consensus trace, one at a time, to the state machine and
+prints its state and decisions after each of them. The decisions the node
+recorded are printed when they differ, and the exit status is 1 if any did.
+If the filename is omitted, the trace is read from stdin.`)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	var r io.Reader
+	switch {
+	case flag.NArg() == 0:
+		r = os.Stdin
+
+	case flag.NArg() == 1:
+		fd, err := os.Open(flag.Arg(0))
+		if err != nil {
+			die(err)
+		}
+		defer fd.Close()
+		r = fd
+
+	default:
+		fmt.Fprintln(os.Stderr, "Error: too many arguments")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	replayer := core.NewReplayer(bufio.NewReader(r))
+	diverged := false
+	for n := 1; ; n++ {
+		step, err := replayer.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			die(err)
+		}
+		if step.Diverged() {
+			diverged = true
+		} else if *divergedOnly {
+			continue
+		}
+		printStep(n, step)
+		if diverged && *stopFirst {
+			break
+		}
+	}
+	if diverged {
+		os.Exit(1)
+	}
+}
+
+func printStep(n int, step *core.ReplayStep) {
+	fmt.Printf("#%d %s %s\n", n, step.Time.Format("15:04:05.000000"), step.Input)
+	if step.Skipped {
+		fmt.Println("    skipped, before the first height of the trace")
+		return
+	}
+	fmt.Printf("    height=%v round=%d step=%s lockedRound=%d validRound=%d\n", step.Height, step.Round, step.Step, step.LockedRound, step.ValidRound)
+	for _, decision := range step.Replayed {
+		fmt.Println("    >", decision)
+	}
+	if step.Diverged() {
+		fmt.Println("    DIVERGED, the node decided:")
+		for _, decision := range step.Recorded {
+			fmt.Println("    <", decision)
+		}
+		if len(step.Recorded) == 0 {
+			fmt.Println("    < nothing")
+		}
+	}
+}
+
+func die(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
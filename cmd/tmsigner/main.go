@@ -0,0 +1,95 @@
+// tmsigner is a signing service holding the consensus key of a validator, so
+// that the values signed are recorded apart from the node. The node connects
+// to it with --tendermint.signer. The key must be the node key, as the peers
+// of a validator know it by its enode.
+package main
+
+import (
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/clearmatics/autonity/consensus/tendermint/signer"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/rpc"
+)
+
+var (
+	keyFile   = flag.String("key", "", "file holding the validator key")
+	keyHex    = flag.String("keyhex", "", "validator key as hex (for testing)")
+	ipcPath   = flag.String("ipc", "", "path of the socket to serve on")
+	stateFile = flag.String("state", "", "file storing the values signed")
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "(-key file | -keyhex hex) -ipc path -state file")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, `
+Signs the consensus messages, committed seals and blocks of a validator on
+request of its node. A value is never signed for a step of a round of a
+height another value was signed for, nor for a height below the last one
+signed for. The values signed are stored in the state file before their
+signatures are returned, the same file must be given across restarts.
+
+The service is only served on a socket, which only the user running it can
+connect to, as it does not authenticate its clients. A node on another host
+reaches it through a forwarded socket, e.g. with ssh -L.`)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	var (
+		key *ecdsa.PrivateKey
+		err error
+	)
+	switch {
+	case *keyFile != "" && *keyHex != "":
+		die("Options -key and -keyhex are mutually exclusive")
+	case *keyFile != "":
+		key, err = crypto.LoadECDSA(*keyFile)
+	case *keyHex != "":
+		key, err = crypto.HexToECDSA(*keyHex)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		die("Invalid key:", err)
+	}
+	if *ipcPath == "" || *stateFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	service, err := signer.NewService(signer.NewLocal(key), *stateFile)
+	if err != nil {
+		die(err)
+	}
+	listener, server, err := rpc.StartIPCEndpoint(*ipcPath, []rpc.API{{
+		Namespace: signer.Namespace,
+		Version:   "1.0",
+		Service:   service,
+		Public:    true,
+	}})
+	if err != nil {
+		die(err)
+	}
+	defer server.Stop()
+	defer listener.Close()
+	fmt.Println("Signing for", crypto.PubkeyToAddress(key.PublicKey).Hex())
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	<-sigc
+}
+
+func die(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/clearmatics/autonity/common/fdlimit"
 	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/consensus/ethash"
+	tendermintConfig "github.com/clearmatics/autonity/consensus/tendermint/config"
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/vm"
 	"github.com/clearmatics/autonity/crypto"
@@ -178,7 +179,7 @@ var (
 	defaultSyncMode = eth.DefaultConfig.SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:  "syncmode",
-		Usage: `Blockchain sync mode ("fast", "full", or "light")`,
+		Usage: `Blockchain sync mode ("fast", "full", "snap" or "light")`,
 		Value: &defaultSyncMode,
 	}
 	GCModeFlag = cli.StringFlag{
@@ -195,6 +196,11 @@ var (
 		Usage: "Number of recent blocks to maintain transactions index by-hash for (default = index all blocks)",
 		Value: 0,
 	}
+	BloomFilterSizeFlag = cli.Uint64Flag{
+		Name:  "bloomfilter.size",
+		Usage: "Megabytes of memory allocated to bloom-filter for pruning",
+		Value: 2048,
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -421,6 +427,32 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	// Tendermint settings
+	TendermintTraceFlag = cli.StringFlag{
+		Name:  "tendermint.trace",
+		Usage: "File to record the consensus messages, timeouts and committed blocks to, for offline replay",
+	}
+	TendermintTraceSizeFlag = cli.Uint64Flag{
+		Name:  "tendermint.trace.size",
+		Usage: "Size in megabytes above which the consensus trace file is rotated",
+		Value: tendermintConfig.DefaultTraceFileSize,
+	}
+	TendermintSentriesFlag = cli.StringFlag{
+		Name:  "tendermint.sentries",
+		Usage: "Comma separated enode URLs of the sentry nodes this validator exchanges consensus messages through, it connects to them only",
+	}
+	TendermintSentryValidatorsFlag = cli.StringFlag{
+		Name:  "tendermint.sentry.validators",
+		Usage: "Comma separated enode URLs of the validators this node is a sentry of, it relays their consensus messages",
+	}
+	TendermintSignerFlag = cli.StringFlag{
+		Name:  "tendermint.signer",
+		Usage: "Socket path of the signing service holding the validator keys, which must be the node key",
+	}
+	TendermintSafetyMarginFlag = cli.Uint64Flag{
+		Name:  "tendermint.safetymargin",
+		Usage: "Number of blocks to see committed past the last signed height before signing consensus messages again after a restart",
+	}
 	// Account settings
 	UnlockedAccountFlag = cli.StringFlag{
 		Name:  "unlock",
@@ -1243,6 +1275,27 @@ func setEthash(ctx *cli.Context, cfg *eth.Config) {
 	}
 }
 
+func setTendermint(ctx *cli.Context, cfg *tendermintConfig.Config) {
+	if ctx.GlobalIsSet(TendermintTraceFlag.Name) {
+		cfg.TraceFile = ctx.GlobalString(TendermintTraceFlag.Name)
+	}
+	if ctx.GlobalIsSet(TendermintTraceSizeFlag.Name) {
+		cfg.TraceFileSize = ctx.GlobalUint64(TendermintTraceSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(TendermintSentriesFlag.Name) {
+		cfg.Sentries = SplitAndTrim(ctx.GlobalString(TendermintSentriesFlag.Name))
+	}
+	if ctx.GlobalIsSet(TendermintSentryValidatorsFlag.Name) {
+		cfg.SentryValidators = SplitAndTrim(ctx.GlobalString(TendermintSentryValidatorsFlag.Name))
+	}
+	if ctx.GlobalIsSet(TendermintSignerFlag.Name) {
+		cfg.Signer = ctx.GlobalString(TendermintSignerFlag.Name)
+	}
+	if ctx.GlobalIsSet(TendermintSafetyMarginFlag.Name) {
+		cfg.SafetyMargin = ctx.GlobalUint64(TendermintSafetyMarginFlag.Name)
+	}
+}
+
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.GlobalIsSet(MinerNotifyFlag.Name) {
 		cfg.Notify = strings.Split(ctx.GlobalString(MinerNotifyFlag.Name), ",")
@@ -1371,6 +1424,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	setTxPool(ctx, &cfg.TxPool)
 	setEthash(ctx, cfg)
 	setMiner(ctx, &cfg.Miner)
+	setTendermint(ctx, &cfg.Tendermint)
 	setWhitelist(ctx, cfg)
 	setLes(ctx, cfg)
 
@@ -26,6 +26,7 @@ import (
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/core/state"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/core/vm"
 	"github.com/clearmatics/autonity/params"
 	"github.com/clearmatics/autonity/rpc"
 )
@@ -159,6 +160,15 @@ type BFT interface {
 	Start(ctx context.Context) error
 }
 
+// FinalizeTracer is implemented by the engines finalizing the blocks with EVM
+// calls, which can then be traced.
+type FinalizeTracer interface {
+	// TraceFinalize runs Finalize with EVMs of the given config, without
+	// writing to the engine nor to the database, and returns its receipt.
+	TraceFinalize(chain ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction,
+		receipts []*types.Receipt, vmConfig vm.Config) (*types.Receipt, error)
+}
+
 type Syncer interface {
 	SyncPeer(address common.Address)
 
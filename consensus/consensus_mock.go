@@ -9,6 +9,7 @@ import (
 	common "github.com/clearmatics/autonity/common"
 	state "github.com/clearmatics/autonity/core/state"
 	types "github.com/clearmatics/autonity/core/types"
+	vm "github.com/clearmatics/autonity/core/vm"
 	p2p "github.com/clearmatics/autonity/p2p"
 	params "github.com/clearmatics/autonity/params"
 	rpc "github.com/clearmatics/autonity/rpc"
@@ -875,6 +876,44 @@ func (mr *MockBFTMockRecorder) Start(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockBFT)(nil).Start), ctx)
 }
 
+// MockFinalizeTracer is a mock of FinalizeTracer interface
+type MockFinalizeTracer struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinalizeTracerMockRecorder
+}
+
+// MockFinalizeTracerMockRecorder is the mock recorder for MockFinalizeTracer
+type MockFinalizeTracerMockRecorder struct {
+	mock *MockFinalizeTracer
+}
+
+// NewMockFinalizeTracer creates a new mock instance
+func NewMockFinalizeTracer(ctrl *gomock.Controller) *MockFinalizeTracer {
+	mock := &MockFinalizeTracer{ctrl: ctrl}
+	mock.recorder = &MockFinalizeTracerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFinalizeTracer) EXPECT() *MockFinalizeTracerMockRecorder {
+	return m.recorder
+}
+
+// TraceFinalize mocks base method
+func (m *MockFinalizeTracer) TraceFinalize(chain ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt, vmConfig vm.Config) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceFinalize", chain, header, state, txs, receipts, vmConfig)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceFinalize indicates an expected call of TraceFinalize
+func (mr *MockFinalizeTracerMockRecorder) TraceFinalize(chain, header, state, txs, receipts, vmConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceFinalize", reflect.TypeOf((*MockFinalizeTracer)(nil).TraceFinalize), chain, header, state, txs, receipts, vmConfig)
+}
+
 // MockSyncer is a mock of Syncer interface
 type MockSyncer struct {
 	ctrl     *gomock.Controller
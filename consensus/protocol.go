@@ -28,6 +28,8 @@ type Broadcaster interface {
 	Enqueue(id string, block *types.Block)
 	// FindPeers retrives connected peers by addresses
 	FindPeers(map[common.Address]struct{}) map[common.Address]Peer
+	// Peers retrieves all the connected peers by address
+	Peers() map[common.Address]Peer
 }
 
 // Peer defines the interface to communicate with peer
@@ -60,6 +60,20 @@ func (mr *MockBroadcasterMockRecorder) FindPeers(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPeers", reflect.TypeOf((*MockBroadcaster)(nil).FindPeers), arg0)
 }
 
+// Peers mocks base method
+func (m *MockBroadcaster) Peers() map[common.Address]Peer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].(map[common.Address]Peer)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers
+func (mr *MockBroadcasterMockRecorder) Peers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockBroadcaster)(nil).Peers))
+}
+
 // MockPeer is a mock of Peer interface
 type MockPeer struct {
 	ctrl     *gomock.Controller
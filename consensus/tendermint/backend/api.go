@@ -17,10 +17,14 @@
 package backend
 
 import (
+	"context"
+
 	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/consensus/tendermint/core"
+	ethcore "github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/rpc"
 )
@@ -63,9 +67,17 @@ func (api *API) GetContractAddress() common.Address {
 	return autonity.ContractAddress
 }
 
-// Get Autonity contract ABI
-func (api *API) GetContractABI() string {
-	return api.tendermint.GetContractABI()
+// GetContractABI retrieves the Autonity contract ABI active at the specified
+// block, or the current one if no block is given.
+func (api *API) GetContractABI(number *rpc.BlockNumber) (string, error) {
+	if number == nil || isHead(*number) || *number == rpc.PendingBlockNumber {
+		return api.tendermint.GetContractABI(), nil
+	}
+	header := api.chain.GetHeaderByNumber(uint64(*number))
+	if header == nil {
+		return "", errUnknownBlock
+	}
+	return api.tendermint.GetContractABIAt(header.Number.Uint64()), nil
 }
 
 // Get current white list
@@ -77,3 +89,137 @@ func (api *API) GetWhitelist() []string {
 func (api *API) GetCoreState() core.TendermintState {
 	return api.tendermint.CoreState()
 }
+
+// GetBLSKey returns the BLS public key of the node followed by its proof of
+// possession, to be registered in the autonity contract or the genesis.
+func (api *API) GetBLSKey() (hexutil.Bytes, error) {
+	return api.tendermint.BLSKeyRegistration()
+}
+
+// GetValidatorUptime returns the number of blocks each committee member missed
+// over the given number of latest blocks, 100 if none is given.
+func (api *API) GetValidatorUptime(window *uint64) (*Uptime, error) {
+	w := uint64(defaultUptimeWindow)
+	if window != nil {
+		w = *window
+	}
+	return api.tendermint.Uptime(api.chain, api.chain.CurrentHeader(), w)
+}
+
+// GetEvidence retrieves the misbehaviour evidence recorded at the given height,
+// or at every height if none is given.
+func (api *API) GetEvidence(number *rpc.BlockNumber) ([]*core.Evidence, error) {
+	if number == nil {
+		return api.tendermint.AllEvidence()
+	}
+	height := uint64(*number)
+	switch *number {
+	case rpc.LatestBlockNumber, rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+		height = api.chain.CurrentHeader().Number.Uint64()
+	case rpc.PendingBlockNumber:
+		// Evidence of the height being decided.
+		height = api.chain.CurrentHeader().Number.Uint64() + 1
+	}
+	return api.tendermint.Evidence(height)
+}
+
+// GetBlockSigners returns the committee members which sealed the given block,
+// or the latest one if none is given, with their voting power.
+func (api *API) GetBlockSigners(number *rpc.BlockNumber) (*types.BlockSigners, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return nil, err
+	}
+	return api.tendermint.BlockSigners(api.chain, header)
+}
+
+// GetBlockRound returns the consensus round the given block, or the latest
+// one if none is given, was decided at.
+func (api *API) GetBlockRound(number *rpc.BlockNumber) (hexutil.Uint64, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Round), nil
+}
+
+// GetProposer returns the committee member which proposed the given block, or
+// the latest one if none is given.
+func (api *API) GetProposer(number *rpc.BlockNumber) (common.Address, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return api.tendermint.Author(header)
+}
+
+// GetQuorum returns the voting power needed to decide the given block, or the
+// latest one if none is given.
+func (api *API) GetQuorum(number *rpc.BlockNumber) (uint64, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return 0, err
+	}
+	return api.tendermint.Quorum(api.chain, header)
+}
+
+// NewFinalizedBlocks sends a notification with the signers of every block
+// added to the chain, which is final once added.
+func (api *API) NewFinalizedBlocks(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	bc := api.tendermint.BlockChain()
+	if bc == nil {
+		return &rpc.Subscription{}, errUnknownBlock
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		blocks := make(chan ethcore.ChainEvent, 10)
+		sub := bc.SubscribeChainEvent(blocks)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-blocks:
+				signers, err := api.tendermint.BlockSigners(bc, ev.Block.Header())
+				if err != nil {
+					api.tendermint.logger.Debug("Could not get the signers of a finalized block", "number", ev.Block.Number(), "err", err)
+					continue
+				}
+				notifier.Notify(rpcSub.ID, signers)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// isHead reports whether the block number tag resolves to the chain head. The
+// blocks of the chain are final as soon as they are committed, so the latest
+// final block is the head.
+func isHead(number rpc.BlockNumber) bool {
+	return number == rpc.LatestBlockNumber || number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber
+}
+
+// header returns the header of the given block, or of the latest one if none
+// is given.
+func (api *API) header(number *rpc.BlockNumber) (*types.Header, error) {
+	var header *types.Header
+	if number == nil || isHead(*number) || *number == rpc.PendingBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(*number))
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return header, nil
+}
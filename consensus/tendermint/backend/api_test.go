@@ -10,6 +10,7 @@ import (
 	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/common/acdefault"
+	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/rpc"
@@ -97,11 +98,23 @@ func TestAPIGetContractABI(t *testing.T) {
 	want := acdefault.ABI()
 
 	API := &API{
+		chain:      chain,
 		tendermint: engine,
 	}
 
-	got := API.GetContractABI()
+	got, err := API.GetContractABI(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// The genesis ABI is recorded as active from the genesis block.
+	genesis := rpc.BlockNumber(0)
+	got, err = API.GetContractABI(&genesis)
+	assert.NoError(t, err)
 	assert.Equal(t, want, got)
+
+	unknown := rpc.BlockNumber(10)
+	_, err = API.GetContractABI(&unknown)
+	assert.Equal(t, errUnknownBlock, err)
 }
 
 func TestAPIGetContractAddress(t *testing.T) {
@@ -138,3 +151,44 @@ func TestAPIGetWhitelist(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestGetBlockRound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := consensus.NewMockChainReader(ctrl)
+	c.EXPECT().GetHeaderByNumber(uint64(1)).Return(&types.Header{Number: big.NewInt(1), Round: 2})
+	c.EXPECT().GetHeaderByNumber(uint64(2)).Return(nil)
+	c.EXPECT().CurrentHeader().Return(&types.Header{Number: big.NewInt(3), Round: 1})
+	API := &API{chain: c}
+
+	bn := rpc.BlockNumber(1)
+	round, err := API.GetBlockRound(&bn)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(2), round)
+
+	bn = rpc.BlockNumber(2)
+	_, err = API.GetBlockRound(&bn)
+	assert.Equal(t, errUnknownBlock, err)
+
+	round, err = API.GetBlockRound(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(1), round)
+}
+
+func TestGetBlockRoundFinalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The blocks of the chain are final once committed, the finalized and safe
+	// tags resolve to the head.
+	c := consensus.NewMockChainReader(ctrl)
+	c.EXPECT().CurrentHeader().Return(&types.Header{Number: big.NewInt(3), Round: 1}).Times(2)
+	API := &API{chain: c}
+
+	for _, bn := range []rpc.BlockNumber{rpc.FinalizedBlockNumber, rpc.SafeBlockNumber} {
+		round, err := API.GetBlockRound(&bn)
+		assert.NoError(t, err)
+		assert.Equal(t, hexutil.Uint64(1), round)
+	}
+}
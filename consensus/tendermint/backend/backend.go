@@ -17,6 +17,7 @@
 package backend
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"errors"
@@ -29,10 +30,10 @@ import (
 	tendermintConfig "github.com/clearmatics/autonity/consensus/tendermint/config"
 	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
 	"github.com/clearmatics/autonity/consensus/tendermint/events"
+	"github.com/clearmatics/autonity/consensus/tendermint/signer"
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/core/vm"
-	"github.com/clearmatics/autonity/crypto"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/log"
@@ -58,15 +59,35 @@ var (
 
 // New creates an Ethereum Backend for BFT core engine.
 func New(config *tendermintConfig.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database, chainConfig *params.ChainConfig, vmConfig *vm.Config) *Backend {
+	return NewWithSigner(config, signer.NewLocal(privateKey), db, chainConfig, vmConfig)
+}
+
+// NewWithSigner creates an Ethereum Backend for BFT core engine whose consensus
+// messages and blocks are signed by the given signer.
+func NewWithSigner(config *tendermintConfig.Config, signer signer.Signer, db ethdb.Database, chainConfig *params.ChainConfig, vmConfig *vm.Config) *Backend {
 	if chainConfig.Tendermint.BlockPeriod != 0 {
 		config.BlockPeriod = chainConfig.Tendermint.BlockPeriod
 	}
+	// The timeouts of the genesis take precedence so that all validators use the same ones.
+	config.OverrideTimeouts(chainConfig.Tendermint)
+	config.AggregatedSealBlock = chainConfig.Tendermint.AggregatedSealBlock
+	config.DowntimeBlock = chainConfig.Tendermint.DowntimeBlock
+	config.EpochBlock = chainConfig.Tendermint.EpochBlock
+	config.EpochLength = chainConfig.Tendermint.EpochLength
+	// The proposer policy of the genesis takes precedence as well, so that all validators use the same one.
+	// The zero policy is the one of the genesis files predating the field, whose networks keep the policy
+	// of the node configuration, unlike the networks with epochs which can't use the default one.
+	if chainConfig.Tendermint.ProposerPolicy != tendermintConfig.RoundRobin || chainConfig.Tendermint.EpochBlock != nil {
+		config.ProposerPolicy = chainConfig.Tendermint.ProposerPolicy
+	}
 
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
+	sealSignersIndex, _ := lru.New(inmemorySigners)
+	epochCommittees, _ := lru.New(inmemoryEpochs)
 
-	pub := crypto.PubkeyToAddress(privateKey.PublicKey).String()
+	pub := signer.Address().String()
 	logger := log.New("addr", pub)
 
 	logger.Warn("new backend with public key")
@@ -74,8 +95,8 @@ func New(config *tendermintConfig.Config, privateKey *ecdsa.PrivateKey, db ethdb
 	backend := &Backend{
 		config:         config,
 		eventMux:       event.NewTypeMuxSilent(logger),
-		privateKey:     privateKey,
-		address:        crypto.PubkeyToAddress(privateKey.PublicKey),
+		signer:         signer,
+		address:        signer.Address(),
 		logger:         logger,
 		db:             db,
 		recents:        recents,
@@ -83,10 +104,16 @@ func New(config *tendermintConfig.Config, privateKey *ecdsa.PrivateKey, db ethdb
 		recentMessages: recentMessages,
 		knownMessages:  knownMessages,
 		vmConfig:       vmConfig,
+
+		sealSignersIndex: sealSignersIndex,
+		epochCommittees:  epochCommittees,
+
+		sentries:         sentryAddresses(config.Sentries, logger),
+		sentryValidators: sentryAddresses(config.SentryValidators, logger),
 	}
 
 	backend.pendingMessages.SetCapacity(ringCapacity)
-	backend.core = tendermintCore.New(backend, config)
+	backend.core = tendermintCore.New(backend, config, db)
 	return backend
 }
 
@@ -95,7 +122,7 @@ func New(config *tendermintConfig.Config, privateKey *ecdsa.PrivateKey, db ethdb
 type Backend struct {
 	config       *tendermintConfig.Config
 	eventMux     *event.TypeMuxSilent
-	privateKey   *ecdsa.PrivateKey
+	signer       signer.Signer
 	address      common.Address
 	logger       log.Logger
 	db           ethdb.Database
@@ -126,6 +153,20 @@ type Backend struct {
 
 	contractsMu sync.RWMutex
 	vmConfig    *vm.Config
+
+	// the committee members which sealed the recent blocks, by block hash
+	sealSignersIndex *lru.Cache
+
+	// the committees of the recent epochs, by committee hash
+	epochCommittees *lru.Cache
+
+	// the penalties of the peers which sent abusive consensus messages
+	peerScores peerScores
+
+	// the sentry nodes this validator reaches the network through, and the
+	// validators this sentry node relays the consensus messages of
+	sentries         map[common.Address]struct{}
+	sentryValidators map[common.Address]struct{}
 }
 
 func (sb *Backend) BlockChain() *core.BlockChain {
@@ -156,10 +197,15 @@ func (sb *Backend) postEvent(event interface{}) {
 func (sb *Backend) AskSync(header *types.Header) {
 	sb.logger.Info("Broadcasting consensus synchronization request")
 
-	targets := make(map[common.Address]struct{})
-	for _, val := range header.Committee {
-		if val.Address != sb.Address() {
-			targets[val.Address] = struct{}{}
+	// A validator behind sentries asks them all, they relay the request to
+	// the committee.
+	targets := sb.sentries
+	if len(targets) == 0 {
+		targets = make(map[common.Address]struct{})
+		for _, val := range header.Committee {
+			if val.Address != sb.Address() {
+				targets[val.Address] = struct{}{}
+			}
 		}
 	}
 
@@ -185,6 +231,9 @@ func (sb *Backend) AskSync(header *types.Header) {
 				}
 				sb.logger.Info("Asking sync to", "addr", addr)
 				go p.Send(tendermintSyncMsg, []byte{}) //nolint
+				if len(sb.sentries) > 0 {
+					continue
+				}
 
 				member := header.CommitteeMember(addr)
 				if member == nil {
@@ -202,16 +251,15 @@ func (sb *Backend) AskSync(header *types.Header) {
 func (sb *Backend) Gossip(ctx context.Context, committee types.Committee, payload []byte) {
 	hash := types.RLPHash(payload)
 	sb.knownMessages.Add(hash, true)
+	sb.send(committee, tendermintMsg, hash, payload)
+}
 
-	targets := make(map[common.Address]struct{})
-	for _, val := range committee {
-		if val.Address != sb.Address() {
-			targets[val.Address] = struct{}{}
-		}
-	}
-
-	if sb.broadcaster != nil && len(targets) > 0 {
-		ps := sb.broadcaster.FindPeers(targets)
+// send sends the payload with the given message code to the connected members
+// of the committee, or to the peers standing for them in sentry mode, except
+// those who already sent or received it.
+func (sb *Backend) send(committee types.Committee, code uint64, hash common.Hash, payload []byte) {
+	if sb.broadcaster != nil {
+		ps := sb.gossipPeers(committee)
 		for addr, p := range ps {
 			ms, ok := sb.recentMessages.Get(addr)
 			var m *lru.ARCCache
@@ -228,7 +276,7 @@ func (sb *Backend) Gossip(ctx context.Context, committee types.Committee, payloa
 			m.Add(hash, true)
 			sb.recentMessages.Add(addr, m)
 
-			go p.Send(tendermintMsg, payload) //nolint
+			go p.Send(code, payload) //nolint
 		}
 	}
 }
@@ -250,6 +298,20 @@ func (sb *Backend) Commit(proposal *types.Block, round int64, seals [][]byte) er
 		return err
 	}
 
+	return sb.commit(proposal, h, round)
+}
+
+// CommitAggregated implements tendermint.Backend.CommitAggregated
+func (sb *Backend) CommitAggregated(proposal *types.Block, round int64, seal []byte, signers []byte) error {
+	h := proposal.Header()
+	if err := types.WriteAggregatedSeal(h, seal, signers); err != nil {
+		return err
+	}
+	return sb.commit(proposal, h, round)
+}
+
+// commit writes the round into the sealed header h of proposal and delivers it.
+func (sb *Backend) commit(proposal *types.Block, h *types.Header, round int64) error {
 	if err := types.WriteRound(h, round); err != nil {
 		return err
 	}
@@ -345,6 +407,15 @@ func (sb *Backend) VerifyProposal(proposal types.Block) (time.Duration, error) {
 			return 0, err
 		}
 
+		// Within an epoch the header carries the hash of the committee only.
+		if !sb.config.IsEpochHeader(header.Number) {
+			if header.CommitteeHash != committeeSet.Hash() {
+				sb.logger.Error("wrong committee hash", "proposalNumber", proposalNumber, "hash", header.CommitteeHash, "current", committeeSet)
+				return 0, consensus.ErrInconsistentCommitteeSet
+			}
+			return 0, nil
+		}
+
 		//Perform the actual comparison
 		if len(header.Committee) != len(committeeSet) {
 			sb.logger.Error("wrong committee set",
@@ -359,7 +430,8 @@ func (sb *Backend) VerifyProposal(proposal types.Block) (time.Duration, error) {
 
 		for i := range committeeSet {
 			if header.Committee[i].Address != committeeSet[i].Address ||
-				header.Committee[i].VotingPower.Cmp(committeeSet[i].VotingPower) != 0 {
+				header.Committee[i].VotingPower.Cmp(committeeSet[i].VotingPower) != 0 ||
+				!bytes.Equal(header.Committee[i].BLSKey, committeeSet[i].BLSKey) {
 				sb.logger.Error("wrong committee member in the set",
 					"index", i,
 					"currentVerifier", sb.address.String(),
@@ -383,8 +455,18 @@ func (sb *Backend) VerifyProposal(proposal types.Block) (time.Duration, error) {
 
 // Sign implements tendermint.Backend.Sign
 func (sb *Backend) Sign(data []byte) ([]byte, error) {
-	hashData := crypto.Keccak256(data)
-	return crypto.Sign(hashData, sb.privateKey)
+	return sb.signer.Sign(data)
+}
+
+// SignBLS implements tendermint.Backend.SignBLS
+func (sb *Backend) SignBLS(data []byte) ([]byte, error) {
+	return sb.signer.SignBLS(data)
+}
+
+// BLSKeyRegistration returns the BLS public key of the node followed by its
+// proof of possession, as expected by the autonity contract.
+func (sb *Backend) BLSKeyRegistration() ([]byte, error) {
+	return sb.signer.BLSKeyRegistration()
 }
 
 // CheckSignature implements tendermint.Backend.CheckSignature
@@ -403,6 +485,15 @@ func (sb *Backend) CheckSignature(data []byte, address common.Address, sig []byt
 
 func (sb *Backend) LastCommittedProposal() (*types.Block, common.Address) {
 	block := sb.currentBlock()
+	// The consensus relies on the committee of the last block.
+	if block.Header().CommitteeHash != (common.Hash{}) {
+		header, err := sb.resolveCommittee(sb.blockchain, block.Header())
+		if err != nil {
+			sb.logger.Error("Failed to resolve the committee of the last block", "err", err)
+			return new(types.Block), common.Address{}
+		}
+		block = block.WithSeal(header)
+	}
 
 	var proposer common.Address
 	if block.Number().Cmp(common.Big0) > 0 {
@@ -426,16 +517,35 @@ func (sb *Backend) HasBadProposal(hash common.Hash) bool {
 }
 
 func (sb *Backend) GetContractABI() string {
+	if sb.blockchain == nil {
+		// light clients do not hold the state the contract lives in.
+		return ""
+	}
 	// after the contract is upgradable, call it from contract object rather than from conf.
 	return sb.blockchain.GetAutonityContract().StringABI()
 }
 
+// GetContractABIAt returns the autonity contract ABI active at the given block number.
+func (sb *Backend) GetContractABIAt(number uint64) string {
+	if sb.blockchain == nil {
+		return ""
+	}
+	return sb.blockchain.GetAutonityContract().StringABIAt(number)
+}
+
 func (sb *Backend) CoreState() tendermintCore.TendermintState {
+	if sb.blockchain == nil {
+		// the core never runs on a light client.
+		return tendermintCore.TendermintState{}
+	}
 	return sb.core.CoreState()
 }
 
 // Whitelist for the current block
 func (sb *Backend) WhiteList() []string {
+	if sb.blockchain == nil {
+		return nil
+	}
 	db, err := sb.blockchain.State()
 	if err != nil {
 		sb.logger.Error("Failed to get block white list", "err", err)
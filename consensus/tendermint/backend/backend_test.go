@@ -566,11 +566,15 @@ func (slice Keys) Swap(i, j int) {
 // in this test, we can set n to 1, and it means we can process Istanbul and commit a
 // block by one node. Otherwise, if n is larger than 1, we have to generate
 // other fake events to process Istanbul.
+// testNodeKeys are the keys of the backends created by newBlockChain, by address.
+var testNodeKeys = make(map[common.Address]*ecdsa.PrivateKey)
+
 func newBlockChain(n int) (*core.BlockChain, *Backend) {
 	genesis, nodeKeys := getGenesisAndKeys(n)
 	memDB := rawdb.NewMemoryDatabase()
 	// Use the first key as private key
 	b := New(genesis.Config.Tendermint, nodeKeys[0], memDB, genesis.Config, &vm.Config{})
+	testNodeKeys[b.Address()] = nodeKeys[0]
 
 	genesis.MustCommit(memDB)
 	blockchain, err := core.NewBlockChain(memDB, nil, genesis.Config, b, vm.Config{}, nil, core.NewTxSenderCacher(), nil)
@@ -689,7 +693,7 @@ func makeBlockWithoutSeal(chain *core.BlockChain, engine *Backend, parent *types
 	for i := range txs {
 		amount := new(big.Int).SetUint64((nonce + 1) * 1000000000)
 		tx := types.NewTransaction(nonce, common.Address{}, amount, params.TxGas, gasPrice, []byte{})
-		tx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(1)), engine.privateKey)
+		tx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(1)), testNodeKeys[engine.address])
 		if err != nil {
 			return nil, err
 		}
@@ -21,15 +21,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/clearmatics/autonity/trie"
 
 	"github.com/clearmatics/autonity/consensus/tendermint/bft"
-	"github.com/clearmatics/autonity/consensus/tendermint/crypto"
 	"github.com/clearmatics/autonity/core"
 
+	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/consensus"
@@ -37,6 +40,9 @@ import (
 	"github.com/clearmatics/autonity/consensus/tendermint/events"
 	"github.com/clearmatics/autonity/core/state"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/core/vm"
+	"github.com/clearmatics/autonity/crypto/bls"
+	"github.com/clearmatics/autonity/metrics"
 	"github.com/clearmatics/autonity/rpc"
 )
 
@@ -55,6 +61,8 @@ var (
 	// errUnknownBlock is returned when the list of committee is requested for a block
 	// that is not part of the local blockchain.
 	errUnknownBlock = errors.New("unknown block")
+	// errNoContract is returned when the Autonity contract is needed on a light client.
+	errNoContract = errors.New("no Autonity contract on light clients")
 	// errUnauthorized is returned if a header is signed by a non authorized entity.
 	errUnauthorized = errors.New("unauthorized")
 	// errInvalidCoindbase is returned if the signer is not the coinbase address,
@@ -69,8 +77,11 @@ var (
 	errInvalidUncleHash = errors.New("non empty uncle hash")
 	// errInvalidTimestamp is returned if the timestamp of a block is lower than the previous block's timestamp + the minimum block period.
 	errInvalidTimestamp = errors.New("invalid timestamp")
-	// errInvalidRound is returned if the round exceed maximum round number.
+	// errInvalidRound is returned if the round does not fit a consensus round.
 	errInvalidRound = errors.New("invalid round")
+	// errInvalidPastCommittedSeals is returned if a header does not carry the
+	// committed seals of its parent when it should or carries them when it should not.
+	errInvalidPastCommittedSeals = errors.New("invalid past committed seals")
 )
 var (
 	defaultDifficulty = big.NewInt(1)
@@ -93,17 +104,28 @@ func (sb *Backend) Author(header *types.Header) (common.Address, error) {
 // given engine. Verifying the seal may be done optionally here, or explicitly
 // via the VerifySeal method.
 func (sb *Backend) VerifyHeader(chain consensus.ChainHeaderReader, header *types.Header, _ bool) error {
-	return sb.verifyHeader(header, chain.GetHeaderByHash(header.ParentHash))
+	parent, err := sb.resolveCommittee(chain, chain.GetHeaderByHash(header.ParentHash))
+	if err != nil {
+		return err
+	}
+	var grandparent *types.Header
+	if parent != nil && sb.config.IsDowntime(header.Number) {
+		if grandparent, err = sb.resolveCommittee(chain, chain.GetHeaderByHash(parent.ParentHash)); err != nil {
+			return err
+		}
+	}
+	return sb.verifyHeader(header, parent, grandparent)
 }
 
 // verifyHeader checks whether a header conforms to the consensus rules. It
 // expects the parent header to be provided unless header is the genesis
-// header.
-func (sb *Backend) verifyHeader(header, parent *types.Header) error {
+// header, and the grandparent header once headers carry the committed seals
+// of their parent, both holding the committee of their epoch.
+func (sb *Backend) verifyHeader(header, parent, grandparent *types.Header) error {
 	if header.Number == nil {
 		return errUnknownBlock
 	}
-	if header.Round > tendermintCore.MaxRound {
+	if header.Round > math.MaxInt64 {
 		return errInvalidRound
 	}
 	// Don't waste time checking blocks from the future
@@ -137,11 +159,11 @@ func (sb *Backend) verifyHeader(header, parent *types.Header) error {
 	if parent == nil {
 		return errUnknownBlock
 	}
-	return sb.verifyHeaderAgainstParent(header, parent)
+	return sb.verifyHeaderAgainstParent(header, parent, grandparent)
 }
 
 // verifyHeaderAgainstParent verifies that the given header is valid with respect to its parent.
-func (sb *Backend) verifyHeaderAgainstParent(header, parent *types.Header) error {
+func (sb *Backend) verifyHeaderAgainstParent(header, parent, grandparent *types.Header) error {
 	if parent.Number.Uint64() != header.Number.Uint64()-1 || parent.Hash() != header.ParentHash {
 		return consensus.ErrUnknownAncestor
 	}
@@ -149,40 +171,181 @@ func (sb *Backend) verifyHeaderAgainstParent(header, parent *types.Header) error
 	if parent.Time+sb.config.BlockPeriod > header.Time {
 		return errInvalidTimestamp
 	}
+	if err := sb.verifyCommitteeHash(header, parent); err != nil {
+		return err
+	}
 	if err := sb.verifySigner(header, parent); err != nil {
 		return err
 	}
+	if err := sb.verifyPastCommittedSeals(header, parent, grandparent); err != nil {
+		return err
+	}
 
 	return sb.verifyCommittedSeals(header, parent)
 }
 
+// verifyPastCommittedSeals validates that the committed seals of the parent
+// carried by header are a quorum of the committee of the grandparent.
+func (sb *Backend) verifyPastCommittedSeals(header, parent, grandparent *types.Header) error {
+	if !sb.config.IsDowntime(header.Number) {
+		if len(header.PastCommittedSeals) != 0 {
+			return errInvalidPastCommittedSeals
+		}
+		return nil
+	}
+	if grandparent == nil {
+		return errUnknownBlock
+	}
+	past, err := sb.withPastCommittedSeals(header, parent, grandparent)
+	if err != nil {
+		return err
+	}
+	if err := sb.verifyCommittedSeals(past, grandparent); err != nil {
+		return errInvalidPastCommittedSeals
+	}
+	return nil
+}
+
+// pastCommittedSeals returns the committed seals of parent to carry in the
+// header of its child, the aggregated seal followed by the bitmap of its
+// signers once seals are aggregated.
+func (sb *Backend) pastCommittedSeals(parent, grandparent *types.Header) [][]byte {
+	if sb.isAggregatedSeal(parent.Number, grandparent) {
+		return [][]byte{parent.AggregatedSeal, parent.SealSigners}
+	}
+	return parent.CommittedSeals
+}
+
+// withPastCommittedSeals returns a copy of parent sealed with the past
+// committed seals carried by header.
+func (sb *Backend) withPastCommittedSeals(header, parent, grandparent *types.Header) (*types.Header, error) {
+	past := types.CopyHeader(parent)
+	if sb.isAggregatedSeal(parent.Number, grandparent) {
+		if len(header.PastCommittedSeals) != 2 {
+			return nil, errInvalidPastCommittedSeals
+		}
+		past.CommittedSeals = nil
+		past.AggregatedSeal = header.PastCommittedSeals[0]
+		past.SealSigners = header.PastCommittedSeals[1]
+	} else {
+		past.CommittedSeals = header.PastCommittedSeals
+	}
+	return past, nil
+}
+
 // VerifyHeaders is similar to VerifyHeader, but verifies a batch of headers
 // concurrently. The method returns a quit channel to abort the operations and
 // a results channel to retrieve the async verifications (the order is that of
 // the input slice).
+//
+// The committed seals are always verified whatever seals says: the committee
+// of a header can only be trusted through the quorum of seals of the committee
+// of its parent, which is all a light client has to follow committee changes.
+// As the difficulty is always 1 there is no total difficulty to rely on either.
+//
+// The headers are verified by a pool of workers, each against the committee of
+// the header preceding it in the batch, and the results are delivered in order.
+// The committed seals of the parent carried by a header are verified against
+// the committee of the header two before it. The committees of the headers
+// within an epoch are resolved beforehand, in order.
 func (sb *Backend) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
 	abort := make(chan struct{}, 1)
 	results := make(chan error, len(headers))
-	go func() {
-		for i, header := range headers {
-			var parent *types.Header
-			switch {
-			case i > 0:
-				parent = headers[i-1]
-			case i == 0:
-				parent = chain.GetHeaderByHash(header.ParentHash)
+	if len(headers) == 0 {
+		return abort, results
+	}
+	resolved := sb.resolveCommittees(chain, headers)
+
+	// Spawn as many workers as allowed threads
+	workers := runtime.GOMAXPROCS(0)
+	if len(headers) < workers {
+		workers = len(headers)
+	}
+	var (
+		inputs = make(chan int)
+		done   = make(chan int, workers)
+		errs   = make([]error, len(headers))
+	)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for index := range inputs {
+				var (
+					parent, grandparent *types.Header
+					err                 error
+				)
+				if index == 0 {
+					parent, err = sb.resolveCommittee(chain, chain.GetHeaderByHash(headers[0].ParentHash))
+				} else {
+					parent = resolved[index-1]
+				}
+				if index > 1 {
+					grandparent = resolved[index-2]
+				} else if err == nil && parent != nil && sb.config.IsDowntime(headers[index].Number) {
+					grandparent, err = sb.resolveCommittee(chain, chain.GetHeaderByHash(parent.ParentHash))
+				}
+				if err != nil {
+					errs[index] = err
+				} else {
+					errs[index] = sb.verifyHeader(headers[index], parent, grandparent)
+				}
+				done <- index
 			}
-			err := sb.verifyHeader(header, parent)
+		}()
+	}
+
+	go func() {
+		defer close(inputs)
+		var (
+			in, out = 0, 0
+			checked = make([]bool, len(headers))
+			inputs  = inputs
+		)
+		for {
 			select {
+			case inputs <- in:
+				if in++; in == len(headers) {
+					// Reached end of headers. Stop sending to workers.
+					inputs = nil
+				}
+			case index := <-done:
+				for checked[index] = true; checked[out]; out++ {
+					results <- errs[out]
+					if out == len(headers)-1 {
+						return
+					}
+				}
 			case <-abort:
 				return
-			case results <- err:
 			}
 		}
 	}()
 	return abort, results
 }
 
+// resolveCommittees returns the headers of the batch holding the committee of
+// their epoch. A header within an epoch holds the committee of the header
+// preceding it in the batch if it carries its hash, which is verified along
+// with the header.
+func (sb *Backend) resolveCommittees(chain consensus.ChainHeaderReader, headers []*types.Header) []*types.Header {
+	resolved := make([]*types.Header, len(headers))
+	for i, header := range headers {
+		switch {
+		case header.CommitteeHash == (common.Hash{}):
+			resolved[i] = header
+		case i > 0 && resolved[i-1].Committee.Hash() == header.CommitteeHash:
+			resolved[i] = withCommittee(header, resolved[i-1].Committee)
+		default:
+			// The header fails its verification if its committee is
+			// unknown, the one of its child is left unresolved.
+			var err error
+			if resolved[i], err = sb.resolveCommittee(chain, header); err != nil {
+				resolved[i] = header
+			}
+		}
+	}
+	return resolved
+}
+
 // VerifyUncles verifies that the given block's uncles conform to the consensus
 // rules of a given engine.
 func (sb *Backend) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
@@ -216,6 +379,13 @@ func (sb *Backend) verifySigner(header, parent *types.Header) error {
 // committee members and that the voting power of the committed seals constitutes
 // a quorum.
 func (sb *Backend) verifyCommittedSeals(header, parent *types.Header) error {
+	if sb.isAggregatedSeal(header.Number, parent) {
+		return sb.verifyAggregatedSeal(header, parent)
+	}
+	if len(header.AggregatedSeal) != 0 || len(header.SealSigners) != 0 {
+		return types.ErrInvalidAggregatedSeal
+	}
+
 	// The length of Committed seals should be larger than 0
 	if len(header.CommittedSeals) == 0 {
 		return types.ErrEmptyCommittedSeals
@@ -235,15 +405,15 @@ func (sb *Backend) verifyCommittedSeals(header, parent *types.Header) error {
 	// The data that was sined over for this block
 	headerSeal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
 
-	// 1. Get committed seals from current header
-	for _, signedSeal := range header.CommittedSeals {
-		// 2. Get the address from signature
-		addr, err := types.GetSignatureAddress(headerSeal, signedSeal)
-		if err != nil {
-			sb.logger.Error("not a valid address", "err", err)
-			return types.ErrInvalidSignature
-		}
+	// 1. Get the addresses from the committed seals of the current header
+	sealers, err := recoverSealers(headerSeal, header.CommittedSeals)
+	if err != nil {
+		sb.logger.Error("not a valid address", "err", err)
+		return types.ErrInvalidSignature
+	}
 
+	// 2. Check them against the parent committee
+	for _, addr := range sealers {
 		member := parent.CommitteeMember(addr)
 		if member == nil {
 			sb.logger.Error(fmt.Sprintf("block had seal from non committee member %q", addr))
@@ -266,6 +436,112 @@ func (sb *Backend) verifyCommittedSeals(header, parent *types.Header) error {
 	return nil
 }
 
+// minParallelSeals is the number of committed seals from which their signers
+// are recovered concurrently, below it the overhead is not worth it.
+const minParallelSeals = 8
+
+// recoverSealers returns the signers of the committed seals of the given
+// data, in the order of the seals. The signatures are recovered by a pool of
+// workers when there are enough of them.
+func recoverSealers(data []byte, seals [][]byte) ([]common.Address, error) {
+	sealers := make([]common.Address, len(seals))
+	workers := runtime.GOMAXPROCS(0)
+	if len(seals) < minParallelSeals || workers == 1 {
+		for i, seal := range seals {
+			addr, err := types.GetSignatureAddress(data, seal)
+			if err != nil {
+				return nil, err
+			}
+			sealers[i] = addr
+		}
+		return sealers, nil
+	}
+	if len(seals) < workers {
+		workers = len(seals)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, workers)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(seals); i += workers {
+				addr, err := types.GetSignatureAddress(data, seals[i])
+				if err != nil {
+					errs[w] = err
+					return
+				}
+				sealers[i] = addr
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sealers, nil
+}
+
+// isAggregatedSeal returns whether the committed seals of the given block,
+// whose committee is the one of parent, are aggregated. Past the fork, the
+// seals are only aggregated if every committee member has a BLS key, so that
+// the members which did not register one do not halt the chain.
+func (sb *Backend) isAggregatedSeal(number *big.Int, parent *types.Header) bool {
+	return sb.config.IsAggregatedSeal(number) && parent.Committee.HasBLSKeys()
+}
+
+// verifyAggregatedSeal validates that the aggregated seal of header is signed by
+// the parent committee members flagged as signers with their BLS key, and that
+// their voting power constitutes a quorum.
+func (sb *Backend) verifyAggregatedSeal(header, parent *types.Header) error {
+	if len(header.CommittedSeals) != 0 {
+		return types.ErrInvalidCommittedSeals
+	}
+	if len(header.AggregatedSeal) == 0 {
+		return types.ErrEmptyCommittedSeals
+	}
+	signers, err := types.SealSignerIndexes(len(parent.Committee), header.SealSigners)
+	if err != nil {
+		return err
+	}
+
+	var power uint64
+	keys := make([]*bls.PublicKey, 0, len(signers))
+	for _, i := range signers {
+		member := parent.Committee[i]
+		key, err := bls.PublicKeyFromBytes(member.BLSKey)
+		if err != nil {
+			sb.logger.Error(fmt.Sprintf("block had seal from committee member %q without BLS key", member.Address))
+			return types.ErrInvalidAggregatedSeal
+		}
+		keys = append(keys, key)
+		power += member.VotingPower.Uint64()
+	}
+	// We need at least a quorum for the block to be considered valid
+	if power < bft.Quorum(parent.TotalVotingPower()) {
+		return types.ErrInvalidCommittedSeals
+	}
+
+	key, err := bls.AggregatePublicKeys(keys)
+	if err != nil {
+		return types.ErrInvalidAggregatedSeal
+	}
+	seal, err := bls.SignatureFromBytes(header.AggregatedSeal)
+	if err != nil {
+		return types.ErrInvalidAggregatedSeal
+	}
+	headerSeal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+	if !key.Verify(headerSeal, seal) {
+		return types.ErrInvalidSignature
+	}
+	return nil
+}
+
 // VerifySeal checks whether the crypto seal on a header is valid according to
 // the consensus rules of the given engine.
 func (sb *Backend) VerifySeal(chain consensus.ChainHeaderReader, header *types.Header) error {
@@ -286,6 +562,10 @@ func (sb *Backend) VerifySeal(chain consensus.ChainHeaderReader, header *types.H
 		// TODO make this ErrUnknownAncestor
 		return errUnknownBlock
 	}
+	parent, err := sb.resolveCommittee(chain, parent)
+	if err != nil {
+		return err
+	}
 	return sb.verifySigner(header, parent)
 }
 
@@ -306,6 +586,17 @@ func (sb *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 	// use the same difficulty for all blocks
 	header.Difficulty = defaultDifficulty
 
+	if sb.config.IsDowntime(header.Number) {
+		grandparent, err := sb.resolveCommittee(chain, chain.GetHeader(parent.ParentHash, number-2))
+		if err != nil {
+			return err
+		}
+		if grandparent == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		header.PastCommittedSeals = sb.pastCommittedSeals(parent, grandparent)
+	}
+
 	// set header's timestamp
 	header.Time = new(big.Int).Add(big.NewInt(int64(parent.Time)), new(big.Int).SetUint64(sb.config.BlockPeriod)).Uint64()
 	if int64(header.Time) < time.Now().Unix() {
@@ -344,22 +635,68 @@ func (sb *Backend) FinalizeAndAssemble(chain consensus.ChainReader, header *type
 	header.UncleHash = nilUncleHash
 
 	// add committee to extraData's committee section
-	header.Committee = committeeSet
+	sb.setCommittee(header, committeeSet)
 	return types.NewBlock(header, txs, nil, *receipts, new(trie.Trie)), nil
 }
 
 // AutonityContractFinalize is called to deploy the Autonity Contract at block #1. it returns as well the
 // committee field containaining the list of committee members allowed to participate in consensus for the next block.
+// Within an epoch the committee of the contract is ignored, the committee of the parent is kept.
 func (sb *Backend) AutonityContractFinalize(header *types.Header, chain consensus.ChainReader, state *state.StateDB,
 	txs []*types.Transaction, receipts []*types.Receipt) (types.Committee, *types.Receipt, error) {
 	sb.contractsMu.Lock()
 	defer sb.contractsMu.Unlock()
+	return sb.finalize(header, chain, state, txs, receipts, sb.blockchain.GetAutonityContract())
+}
 
-	committeeSet, receipt, err := sb.blockchain.GetAutonityContract().FinalizeAndGetCommittee(txs, receipts, header, state)
+// TraceFinalize implements consensus.FinalizeTracer, the block is finalized
+// by a copy of the Autonity contract running in EVMs of the given config.
+func (sb *Backend) TraceFinalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB,
+	txs []*types.Transaction, receipts []*types.Receipt, vmConfig vm.Config) (*types.Receipt, error) {
+	if sb.blockchain == nil {
+		return nil, errNoContract
+	}
+	contract, err := sb.blockchain.GetAutonityContract().WithEVMProvider(header, core.NewEVMProvider(sb.blockchain, vmConfig))
+	if err != nil {
+		return nil, err
+	}
+	_, receipt, err := sb.finalize(header, chain, state, txs, receipts, contract)
+	return receipt, err
+}
+
+func (sb *Backend) finalize(header *types.Header, chain consensus.ChainReader, state *state.StateDB,
+	txs []*types.Transaction, receipts []*types.Receipt, contract *autonity.Contract) (types.Committee, *types.Receipt, error) {
+	var absentees []common.Address
+	if sb.config.IsDowntime(header.Number) {
+		var err error
+		if absentees, err = sb.pastAbsentees(chain, header); err != nil {
+			sb.logger.Error("Could not compute the absentees of the parent block", "err", err)
+			return nil, nil, err
+		}
+	}
+
+	committeeSet, receipt, err := contract.FinalizeAndGetCommittee(txs, receipts, header, state, absentees, sb.config.IsEpochHeader(header.Number))
 	if err != nil {
 		sb.logger.Error("Autonity Contract finalize returns err", "err", err)
 		return nil, nil, err
 	}
+	if !sb.config.IsEpochHeader(header.Number) {
+		parent, err := sb.resolveCommittee(chain, chain.GetHeader(header.ParentHash, header.Number.Uint64()-1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if parent == nil {
+			return nil, nil, consensus.ErrUnknownAncestor
+		}
+		return parent.Committee, receipt, nil
+	}
+	// The committed seals of the next block are verified against the BLS keys of this committee.
+	if committeeSet != nil && sb.config.IsAggregatedSeal(new(big.Int).Add(header.Number, common.Big1)) {
+		if err := contract.FillCommitteeBLSKeys(header, state, committeeSet); err != nil {
+			sb.logger.Error("Autonity Contract BLS keys returns err", "err", err)
+			return nil, nil, err
+		}
+	}
 	return committeeSet, receipt, nil
 }
 
@@ -376,7 +713,10 @@ func (sb *Backend) Seal(chain consensus.ChainReader, block *types.Block, results
 	// update the block header and signature and propose the block to core engine
 	header := block.Header()
 
-	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	parent, err := sb.resolveCommittee(chain, chain.GetHeader(header.ParentHash, header.Number.Uint64()-1))
+	if err != nil {
+		return err
+	}
 	if parent == nil {
 		sb.logger.Error("Error ancestor")
 		return consensus.ErrUnknownAncestor
@@ -387,7 +727,7 @@ func (sb *Backend) Seal(chain consensus.ChainReader, block *types.Block, results
 		return errUnauthorized
 	}
 
-	block, err := sb.AddSeal(block)
+	block, err = sb.AddSeal(block)
 	if err != nil {
 		sb.logger.Error("seal error updateBlock", "err", err.Error())
 		return err
@@ -450,10 +790,13 @@ func (sb *Backend) SetProposedBlockHash(hash common.Hash) {
 func (sb *Backend) AddSeal(block *types.Block) (*types.Block, error) {
 	header := block.Header()
 
-	err := crypto.SignHeader(header, sb.privateKey)
+	seal, err := sb.signer.SignHeader(header)
 	if err != nil {
 		return nil, err
 	}
+	if err := types.WriteSeal(header, seal); err != nil {
+		return nil, err
+	}
 
 	return block.WithSeal(header), nil
 }
@@ -463,17 +806,21 @@ func (sb *Backend) APIs(chain consensus.ChainReader) []rpc.API {
 	return []rpc.API{{
 		Namespace: "tendermint",
 		Version:   "1.0",
-		Service:   &API{chain: chain, tendermint: sb, getCommittee: getCommittee},
+		Service:   &API{chain: chain, tendermint: sb, getCommittee: sb.getCommittee},
 		Public:    true,
 	}}
 }
 
 // getCommittee retrieves the committee for the given header.
-func getCommittee(header *types.Header, chain consensus.ChainReader) (types.Committee, error) {
+func (sb *Backend) getCommittee(header *types.Header, chain consensus.ChainReader) (types.Committee, error) {
 	parent := chain.GetHeaderByHash(header.ParentHash)
 	if parent == nil {
 		return nil, errUnknownBlock
 	}
+	parent, err := sb.resolveCommittee(chain, parent)
+	if err != nil {
+		return nil, err
+	}
 	return parent.Committee, nil
 }
 
@@ -528,4 +875,8 @@ func (sb *Backend) SetBlockchain(bc *core.BlockChain) {
 
 	sb.currentBlock = bc.CurrentBlock
 	sb.hasBadBlock = bc.HasBadBlock
+
+	if metrics.Enabled {
+		go sb.livenessMetricsLoop(bc)
+	}
 }
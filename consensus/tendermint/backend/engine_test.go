@@ -19,6 +19,8 @@ package backend
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sync"
@@ -28,11 +30,17 @@ import (
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	tendermintCrypto "github.com/clearmatics/autonity/consensus/tendermint/crypto"
 	"github.com/clearmatics/autonity/consensus/tendermint/events"
+	"github.com/clearmatics/autonity/consensus/tendermint/signer"
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/params"
 	"github.com/golang/mock/gomock"
 )
 
@@ -227,7 +235,7 @@ func TestVerifySeal(t *testing.T) {
 
 	// unauthorized users but still can get correct signer address
 	privateKey, _ := crypto.GenerateKey()
-	engine.privateKey = privateKey
+	engine.signer = signer.NewLocal(privateKey)
 	err = engine.VerifySeal(chain, block.Header())
 	if err != nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
@@ -715,3 +723,437 @@ func TestBackendSealHash(t *testing.T) {
 		t.Fatalf("expected not empty string")
 	}
 }
+
+// headerChain is a header only chain, as held by a light client.
+type headerChain map[common.Hash]*types.Header
+
+func (hc headerChain) Config() *params.ChainConfig                        { return params.TestChainConfig }
+func (hc headerChain) CurrentHeader() *types.Header                       { return nil }
+func (hc headerChain) GetHeader(hash common.Hash, _ uint64) *types.Header { return hc[hash] }
+func (hc headerChain) GetHeaderByNumber(number uint64) *types.Header {
+	for _, h := range hc {
+		if h.Number.Uint64() == number {
+			return h
+		}
+	}
+	return nil
+}
+func (hc headerChain) GetHeaderByHash(hash common.Hash) *types.Header { return hc[hash] }
+
+// Headers must be verified with the committee of their parent alone, without
+// any state, so that a light client can follow the committee changes.
+func TestVerifyHeadersCommitteeChanges(t *testing.T) {
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	newCommittee := func(n int) types.Committee {
+		committee := make(types.Committee, n)
+		for i := range committee {
+			key, _ := crypto.GenerateKey()
+			committee[i] = types.CommitteeMember{
+				Address:     crypto.PubkeyToAddress(key.PublicKey),
+				VotingPower: big.NewInt(1),
+			}
+			keys[committee[i].Address] = key
+		}
+		return committee
+	}
+
+	// makeHeader returns a child of parent carrying the given committee, sealed
+	// by the first members of the parent committee.
+	makeHeader := func(parent *types.Header, committee types.Committee, sealers int) *types.Header {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 1,
+			Difficulty: defaultDifficulty,
+			MixDigest:  types.BFTDigest,
+			UncleHash:  nilUncleHash,
+			Coinbase:   parent.Committee[0].Address,
+			Committee:  committee,
+		}
+		if err := tendermintCrypto.SignHeader(header, keys[header.Coinbase]); err != nil {
+			t.Fatal(err)
+		}
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		seals := make([][]byte, sealers)
+		for i := range seals {
+			var err error
+			if seals[i], err = crypto.Sign(crypto.Keccak256(seal), keys[parent.Committee[i].Address]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := types.WriteCommittedSeals(header, seals); err != nil {
+			t.Fatal(err)
+		}
+		return header
+	}
+
+	genesis := &types.Header{
+		Number:     common.Big0,
+		Difficulty: defaultDifficulty,
+		MixDigest:  types.BFTDigest,
+		UncleHash:  nilUncleHash,
+		Committee:  newCommittee(4),
+	}
+	chain := headerChain{genesis.Hash(): genesis}
+	engine := &Backend{
+		config: &config.Config{},
+		logger: log.New("backend", "test", "id", 0),
+	}
+
+	verify := func(headers []*types.Header) error {
+		_, results := engine.VerifyHeaders(chain, headers, make([]bool, len(headers)))
+		for range headers {
+			if err := <-results; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The committee is entirely replaced over two heights.
+	rotated := append(types.Committee{genesis.Committee[2], genesis.Committee[3]}, newCommittee(2)...)
+	replaced := newCommittee(3)
+	h1 := makeHeader(genesis, rotated, 3)
+	h2 := makeHeader(h1, replaced, 3)
+	h3 := makeHeader(h2, replaced, 2)
+	if err := verify([]*types.Header{h1, h2, h3}); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+
+	t.Run("seal from a member removed from the committee", func(t *testing.T) {
+		header := makeHeader(h1, replaced, 3)
+		// The first member of the genesis committee is not part of the committee of h1 anymore.
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		removed, err := crypto.Sign(crypto.Keccak256(seal), keys[genesis.Committee[0].Address])
+		if err != nil {
+			t.Fatal(err)
+		}
+		header.CommittedSeals[0] = removed
+		if err := verify([]*types.Header{h1, header}); err != types.ErrInvalidCommittedSeals {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidCommittedSeals)
+		}
+	})
+
+	t.Run("proposer removed from the committee", func(t *testing.T) {
+		header := makeHeader(h1, replaced, 3)
+		header.Coinbase = genesis.Committee[0].Address
+		if err := tendermintCrypto.SignHeader(header, keys[header.Coinbase]); err != nil {
+			t.Fatal(err)
+		}
+		if err := verify([]*types.Header{h1, header}); err != errUnauthorized {
+			t.Errorf("error mismatch: have %v, want %v", err, errUnauthorized)
+		}
+	})
+
+	t.Run("seals below quorum", func(t *testing.T) {
+		// Quorum of a committee of 3 is 2.
+		header := makeHeader(h2, replaced, 1)
+		if err := verify([]*types.Header{h1, h2, header}); err != types.ErrInvalidCommittedSeals {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidCommittedSeals)
+		}
+	})
+}
+
+// Once the aggregated seal fork is active, headers carry a single BLS seal
+// verified against the BLS keys of the parent committee.
+func TestVerifyHeadersAggregatedSeal(t *testing.T) {
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	blsKeys := make(map[common.Address]*bls.SecretKey)
+	committee := make(types.Committee, 4)
+	for i := range committee {
+		key, _ := crypto.GenerateKey()
+		blsKey, err := bls.DeriveKey(crypto.FromECDSA(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		committee[i] = types.CommitteeMember{
+			Address:     crypto.PubkeyToAddress(key.PublicKey),
+			VotingPower: big.NewInt(1),
+			BLSKey:      blsKey.PublicKey().Bytes(),
+		}
+		keys[committee[i].Address] = key
+		blsKeys[committee[i].Address] = blsKey
+	}
+
+	// makeHeader returns a child of parent sealed by the parent committee
+	// members of the given indexes.
+	makeHeader := func(parent *types.Header, signers []int) *types.Header {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 1,
+			Difficulty: defaultDifficulty,
+			MixDigest:  types.BFTDigest,
+			UncleHash:  nilUncleHash,
+			Coinbase:   parent.Committee[0].Address,
+			Committee:  committee,
+		}
+		if err := tendermintCrypto.SignHeader(header, keys[header.Coinbase]); err != nil {
+			t.Fatal(err)
+		}
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		var seals []*bls.Signature
+		for _, i := range signers {
+			seals = append(seals, blsKeys[parent.Committee[i].Address].Sign(seal))
+		}
+		aggregated, err := bls.AggregateSignatures(seals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := types.WriteAggregatedSeal(header, aggregated.Bytes(), types.SealSignersBitmap(len(parent.Committee), signers)); err != nil {
+			t.Fatal(err)
+		}
+		return header
+	}
+
+	genesis := &types.Header{
+		Number:     common.Big0,
+		Difficulty: defaultDifficulty,
+		MixDigest:  types.BFTDigest,
+		UncleHash:  nilUncleHash,
+		Committee:  committee,
+	}
+	chain := headerChain{genesis.Hash(): genesis}
+	engine := &Backend{
+		config: &config.Config{AggregatedSealBlock: common.Big1},
+		logger: log.New("backend", "test", "id", 0),
+	}
+	verify := func(headers []*types.Header) error {
+		_, results := engine.VerifyHeaders(chain, headers, make([]bool, len(headers)))
+		for range headers {
+			if err := <-results; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	h1 := makeHeader(genesis, []int{0, 1, 3})
+	h2 := makeHeader(h1, []int{1, 2, 3})
+	if err := verify([]*types.Header{h1, h2}); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+
+	t.Run("signers below quorum", func(t *testing.T) {
+		header := makeHeader(genesis, []int{0, 1})
+		if err := verify([]*types.Header{header}); err != types.ErrInvalidCommittedSeals {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidCommittedSeals)
+		}
+	})
+
+	t.Run("signer not flagged in the bitmap", func(t *testing.T) {
+		header := makeHeader(genesis, []int{0, 1, 2})
+		header.SealSigners = types.SealSignersBitmap(len(genesis.Committee), []int{0, 1, 3})
+		if err := verify([]*types.Header{header}); err != types.ErrInvalidSignature {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidSignature)
+		}
+	})
+
+	t.Run("bitmap larger than the committee", func(t *testing.T) {
+		header := makeHeader(genesis, []int{0, 1, 2})
+		header.SealSigners = append(header.SealSigners, 0x01)
+		if err := verify([]*types.Header{header}); err != types.ErrInvalidAggregatedSeal {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidAggregatedSeal)
+		}
+	})
+
+	t.Run("committed seals after the fork", func(t *testing.T) {
+		header := makeHeader(genesis, []int{0, 1, 2})
+		header.CommittedSeals = [][]byte{make([]byte, types.BFTExtraSeal)}
+		if err := verify([]*types.Header{header}); err != types.ErrInvalidCommittedSeals {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidCommittedSeals)
+		}
+	})
+
+	t.Run("aggregated seal before the fork", func(t *testing.T) {
+		engine.config = &config.Config{AggregatedSealBlock: big.NewInt(2)}
+		defer func() { engine.config = &config.Config{AggregatedSealBlock: common.Big1} }()
+		if err := verify([]*types.Header{h1}); err != types.ErrInvalidAggregatedSeal {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidAggregatedSeal)
+		}
+	})
+
+	t.Run("committee member without BLS key", func(t *testing.T) {
+		parent := types.CopyHeader(genesis)
+		parent.Committee = make(types.Committee, len(committee))
+		copy(parent.Committee, committee)
+		parent.Committee[3].BLSKey = nil
+		chain[parent.Hash()] = parent
+		defer delete(chain, parent.Hash())
+
+		if err := verify([]*types.Header{makeHeader(parent, []int{0, 1, 2})}); err != types.ErrInvalidAggregatedSeal {
+			t.Errorf("error mismatch: have %v, want %v", err, types.ErrInvalidAggregatedSeal)
+		}
+
+		// The committee keeps sealing with ECDSA signatures past the fork.
+		header := makeHeader(parent, []int{0})
+		header.AggregatedSeal, header.SealSigners = nil, nil
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		var seals [][]byte
+		for _, member := range parent.Committee {
+			sig, err := crypto.Sign(crypto.Keccak256(seal), keys[member.Address])
+			if err != nil {
+				t.Fatal(err)
+			}
+			seals = append(seals, sig)
+		}
+		if err := types.WriteCommittedSeals(header, seals); err != nil {
+			t.Fatal(err)
+		}
+		if err := verify([]*types.Header{header}); err != nil {
+			t.Errorf("error mismatch: have %v, want nil", err)
+		}
+	})
+}
+
+// newSealedHeaders returns a chain of headers after a genesis, each sealed by
+// the whole committee of the given size.
+func newSealedHeaders(tb testing.TB, committeeSize, length int) (headerChain, []*types.Header) {
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	committee := make(types.Committee, committeeSize)
+	for i := range committee {
+		key, _ := crypto.GenerateKey()
+		committee[i] = types.CommitteeMember{
+			Address:     crypto.PubkeyToAddress(key.PublicKey),
+			VotingPower: big.NewInt(1),
+		}
+		keys[committee[i].Address] = key
+	}
+	genesis := &types.Header{
+		Number:     common.Big0,
+		Difficulty: defaultDifficulty,
+		MixDigest:  types.BFTDigest,
+		UncleHash:  nilUncleHash,
+		Committee:  committee,
+	}
+
+	headers := make([]*types.Header, length)
+	parent := genesis
+	for n := range headers {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 1,
+			Difficulty: defaultDifficulty,
+			MixDigest:  types.BFTDigest,
+			UncleHash:  nilUncleHash,
+			Coinbase:   committee[0].Address,
+			Committee:  committee,
+		}
+		if err := tendermintCrypto.SignHeader(header, keys[header.Coinbase]); err != nil {
+			tb.Fatal(err)
+		}
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		seals := make([][]byte, len(committee))
+		for i := range seals {
+			var err error
+			if seals[i], err = crypto.Sign(crypto.Keccak256(seal), keys[committee[i].Address]); err != nil {
+				tb.Fatal(err)
+			}
+		}
+		if err := types.WriteCommittedSeals(header, seals); err != nil {
+			tb.Fatal(err)
+		}
+		headers[n] = header
+		parent = header
+	}
+	return headerChain{genesis.Hash(): genesis}, headers
+}
+
+func TestVerifyHeadersParallel(t *testing.T) {
+	chain, headers := newSealedHeaders(t, 10, 50)
+	engine := &Backend{
+		config: &config.Config{},
+		logger: log.New("backend", "test", "id", 0),
+	}
+
+	// An invalid header amid the batch must be reported at its own index.
+	invalid := 27
+	headers[invalid] = types.CopyHeader(headers[invalid])
+	headers[invalid].CommittedSeals = headers[invalid].CommittedSeals[:1]
+
+	_, results := engine.VerifyHeaders(chain, headers, make([]bool, len(headers)))
+	for i := range headers {
+		select {
+		case err := <-results:
+			if i == invalid {
+				if err != types.ErrInvalidCommittedSeals {
+					t.Errorf("header %d: error mismatch: have %v, want %v", i, err, types.ErrInvalidCommittedSeals)
+				}
+			} else if err != nil {
+				t.Errorf("header %d: error mismatch: have %v, want nil", i, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for the result of header %d", i)
+		}
+	}
+}
+
+func TestRecoverSealers(t *testing.T) {
+	data := []byte("committed seal")
+	addresses := make([]common.Address, 2*minParallelSeals)
+	seals := make([][]byte, len(addresses))
+	for i := range seals {
+		key, _ := crypto.GenerateKey()
+		addresses[i] = crypto.PubkeyToAddress(key.PublicKey)
+		var err error
+		if seals[i], err = crypto.Sign(crypto.Keccak256(data), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sealers, err := recoverSealers(data, seals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sealers, addresses) {
+		t.Fatalf("sealers mismatch: have %v, want %v", sealers, addresses)
+	}
+
+	seals[len(seals)-1] = []byte{1, 2, 3}
+	if _, err := recoverSealers(data, seals); err == nil {
+		t.Fatal("invalid seal accepted")
+	}
+}
+
+// The speedup of the parallel verification shows by running the benchmarks
+// with different numbers of CPUs, e.g. -cpu 1,2,4,8.
+func BenchmarkVerifyCommittedSeals(b *testing.B) {
+	for _, size := range []int{4, 32, 128} {
+		b.Run(fmt.Sprintf("committee-%d", size), func(b *testing.B) {
+			chain, headers := newSealedHeaders(b, size, 1)
+			parent := chain.GetHeaderByHash(headers[0].ParentHash)
+			engine := &Backend{
+				config: &config.Config{},
+				logger: log.New("backend", "test", "id", 0),
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := engine.verifyCommittedSeals(headers[0], parent); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyHeaders(b *testing.B) {
+	for _, size := range []int{4, 32, 128} {
+		b.Run(fmt.Sprintf("committee-%d", size), func(b *testing.B) {
+			chain, headers := newSealedHeaders(b, size, 64)
+			engine := &Backend{
+				config: &config.Config{},
+				logger: log.New("backend", "test", "id", 0),
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, results := engine.VerifyHeaders(chain, headers, make([]bool, len(headers)))
+				for range headers {
+					if err := <-results; err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
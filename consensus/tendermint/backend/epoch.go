@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"errors"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/core/types"
+)
+
+// inmemoryEpochs is the number of epoch committees kept in memory.
+const inmemoryEpochs = 16
+
+var (
+	// errInvalidCommitteeHash is returned if a header carries a committee
+	// hash when it should carry the committee or the other way round, or if
+	// the hash is not the one of the committee of its epoch.
+	errInvalidCommitteeHash = errors.New("invalid committee hash")
+	// errUnknownEpoch is returned when the epoch header of a header is not
+	// part of the local blockchain.
+	errUnknownEpoch = errors.New("unknown epoch header")
+)
+
+// Once an epoch length is set, the committee only changes at the epoch headers,
+// whose number is a multiple of the length. The headers in between carry the
+// hash of the committee of their parent instead of the committee itself, which
+// is the committee of the epoch header. The committee of such a header is
+// resolved in memory before it is used, it is not part of the header hash.
+
+// resolveCommittee returns header, or a copy of it holding the committee of its
+// epoch if it only carries the committee hash.
+func (sb *Backend) resolveCommittee(chain consensus.ChainHeaderReader, header *types.Header) (*types.Header, error) {
+	if header == nil || header.CommitteeHash == (common.Hash{}) || len(header.Committee) > 0 {
+		return header, nil
+	}
+	committee, err := sb.epochCommittee(chain, header)
+	if err != nil {
+		return nil, err
+	}
+	return withCommittee(header, committee), nil
+}
+
+// withCommittee returns a copy of header holding the given committee.
+func withCommittee(header *types.Header, committee types.Committee) *types.Header {
+	resolved := types.CopyHeader(header)
+	resolved.Committee = make(types.Committee, len(committee))
+	copy(resolved.Committee, committee)
+	return resolved
+}
+
+// epochCommittee returns the committee whose hash header carries, from the
+// epoch header it was elected at.
+func (sb *Backend) epochCommittee(chain consensus.ChainHeaderReader, header *types.Header) (types.Committee, error) {
+	if sb.epochCommittees != nil {
+		if committee, ok := sb.epochCommittees.Get(header.CommitteeHash); ok {
+			return committee.(types.Committee), nil
+		}
+	}
+	number := sb.config.EpochHeaderNumber(header.Number.Uint64())
+	epoch := chain.GetHeaderByNumber(number)
+	if epoch == nil || epoch.Committee.Hash() != header.CommitteeHash {
+		// The header may not be part of the canonical chain, in which case
+		// its epoch header is found through its ancestors.
+		epoch = header
+		for epoch != nil && epoch.Number.Uint64() > number {
+			epoch = chain.GetHeader(epoch.ParentHash, epoch.Number.Uint64()-1)
+		}
+	}
+	if epoch == nil {
+		return nil, errUnknownEpoch
+	}
+	if epoch.Committee.Hash() != header.CommitteeHash {
+		return nil, errInvalidCommitteeHash
+	}
+	if sb.epochCommittees != nil {
+		sb.epochCommittees.Add(header.CommitteeHash, epoch.Committee)
+	}
+	return epoch.Committee, nil
+}
+
+// setCommittee sets the committee elected for the block of header, or its hash
+// if the block is not an epoch header.
+func (sb *Backend) setCommittee(header *types.Header, committee types.Committee) {
+	if sb.config.IsEpochHeader(header.Number) {
+		header.Committee, header.CommitteeHash = committee, common.Hash{}
+		return
+	}
+	header.Committee, header.CommitteeHash = nil, committee.Hash()
+}
+
+// verifyCommitteeHash checks that header carries the committee if it is an
+// epoch header and the hash of the committee of its parent otherwise, parent
+// being resolved.
+func (sb *Backend) verifyCommitteeHash(header, parent *types.Header) error {
+	if sb.config.IsEpochHeader(header.Number) {
+		if header.CommitteeHash != (common.Hash{}) {
+			return errInvalidCommitteeHash
+		}
+		return nil
+	}
+	if header.CommitteeHash != parent.Committee.Hash() {
+		return errInvalidCommitteeHash
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	tendermintCrypto "github.com/clearmatics/autonity/consensus/tendermint/crypto"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// Within an epoch headers carry the hash of the committee of the epoch header,
+// which is resolved to verify their children.
+func TestVerifyHeadersEpochs(t *testing.T) {
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	newCommittee := func(n int) types.Committee {
+		committee := make(types.Committee, n)
+		for i := range committee {
+			key, _ := crypto.GenerateKey()
+			committee[i] = types.CommitteeMember{
+				Address:     crypto.PubkeyToAddress(key.PublicKey),
+				VotingPower: big.NewInt(1),
+			}
+			keys[committee[i].Address] = key
+		}
+		return committee
+	}
+
+	// makeHeader returns a child of parent carrying the given committee if it
+	// is an epoch header and its hash otherwise, sealed by the members of the
+	// committee of parent.
+	epochLength := uint64(2)
+	makeHeader := func(parent *types.Header, parentCommittee, committee types.Committee) *types.Header {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 1,
+			Difficulty: defaultDifficulty,
+			MixDigest:  types.BFTDigest,
+			UncleHash:  nilUncleHash,
+			Coinbase:   parentCommittee[0].Address,
+		}
+		if header.Number.Uint64()%epochLength == 0 {
+			header.Committee = committee
+		} else {
+			header.CommitteeHash = committee.Hash()
+		}
+		if err := tendermintCrypto.SignHeader(header, keys[header.Coinbase]); err != nil {
+			t.Fatal(err)
+		}
+		seal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+		seals := make([][]byte, len(parentCommittee))
+		for i := range seals {
+			var err error
+			if seals[i], err = crypto.Sign(crypto.Keccak256(seal), keys[parentCommittee[i].Address]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := types.WriteCommittedSeals(header, seals); err != nil {
+			t.Fatal(err)
+		}
+		return header
+	}
+
+	first, second := newCommittee(4), newCommittee(3)
+	genesis := &types.Header{
+		Number:     common.Big0,
+		Difficulty: defaultDifficulty,
+		MixDigest:  types.BFTDigest,
+		UncleHash:  nilUncleHash,
+		Committee:  first,
+	}
+	chain := headerChain{genesis.Hash(): genesis}
+	epochCommittees, _ := lru.New(inmemoryEpochs)
+	engine := &Backend{
+		config:          &config.Config{EpochBlock: common.Big0, EpochLength: epochLength},
+		logger:          log.New("backend", "test", "id", 0),
+		epochCommittees: epochCommittees,
+	}
+
+	verify := func(headers []*types.Header) error {
+		_, results := engine.VerifyHeaders(chain, headers, make([]bool, len(headers)))
+		for range headers {
+			if err := <-results; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The committee elected within the first epoch only takes over at the
+	// second epoch header.
+	h1 := makeHeader(genesis, first, first)
+	h2 := makeHeader(h1, first, second)
+	h3 := makeHeader(h2, second, second)
+	if err := verify([]*types.Header{h1, h2, h3}); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+
+	t.Run("committee resolved from the chain", func(t *testing.T) {
+		chain[h1.Hash()] = h1
+		defer delete(chain, h1.Hash())
+		if err := engine.VerifyHeader(chain, h2, false); err != nil {
+			t.Fatalf("error mismatch: have %v, want nil", err)
+		}
+		resolved, err := engine.resolveCommittee(chain, h1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved.Hash() != h1.Hash() || resolved.Committee.Hash() != first.Hash() {
+			t.Fatalf("resolved committee mismatch: have %v", resolved.Committee)
+		}
+	})
+
+	t.Run("committee changed within an epoch", func(t *testing.T) {
+		header := makeHeader(genesis, first, second)
+		if err := verify([]*types.Header{header}); err != errInvalidCommitteeHash {
+			t.Errorf("error mismatch: have %v, want %v", err, errInvalidCommitteeHash)
+		}
+	})
+
+	t.Run("epoch header without committee", func(t *testing.T) {
+		header := makeHeader(h1, first, second)
+		header.Committee, header.CommitteeHash = nil, second.Hash()
+		if err := verify([]*types.Header{h1, header}); err != errInvalidCommitteeHash {
+			t.Errorf("error mismatch: have %v, want %v", err, errInvalidCommitteeHash)
+		}
+	})
+}
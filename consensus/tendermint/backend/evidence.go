@@ -0,0 +1,101 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clearmatics/autonity/common"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+var (
+	// errDecodeEvidenceFailed is returned when an evidence message cannot be decoded
+	errDecodeEvidenceFailed = errors.New("fail to decode tendermint evidence")
+)
+
+// ReportEvidence implements tendermint.Backend.ReportEvidence
+func (sb *Backend) ReportEvidence(_ context.Context, evidence *tendermintCore.Evidence) {
+	payload, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		sb.logger.Error("Failed to encode evidence", "err", err)
+		return
+	}
+	sb.storeAndGossipEvidence(evidence.Hash(), uint64(evidence.Height), payload)
+}
+
+// handleEvidence verifies an evidence received from a peer and relays it if
+// we did not know about it yet.
+func (sb *Backend) handleEvidence(addr common.Address, payload []byte) error {
+	evidence := new(tendermintCore.Evidence)
+	if err := rlp.DecodeBytes(payload, evidence); err != nil {
+		return errDecodeEvidenceFailed
+	}
+	hash := evidence.Hash()
+	height := uint64(evidence.Height)
+	sb.markPeerMessage(addr, hash)
+
+	if rawdb.HasConsensusEvidence(sb.db, height, hash) {
+		return nil
+	}
+	if height == 0 {
+		return errDecodeEvidenceFailed
+	}
+	parent, err := sb.resolveCommittee(sb.blockchain, sb.blockchain.GetHeaderByNumber(height-1))
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		// We can't tell who was in the committee at this height yet, the
+		// evidence will reach us again from another peer once we are synced.
+		sb.logger.Debug("Ignoring evidence of future height", "height", height, "from", addr)
+		return nil
+	}
+	if err := evidence.Verify(parent); err != nil {
+		return err
+	}
+
+	sb.logger.Warn("Received misbehaviour evidence", "offender", evidence.Offender, "height", height, "round", evidence.Round, "from", addr)
+	sb.storeAndGossipEvidence(hash, height, payload)
+	return nil
+}
+
+func (sb *Backend) storeAndGossipEvidence(hash common.Hash, height uint64, payload []byte) {
+	if rawdb.HasConsensusEvidence(sb.db, height, hash) {
+		return
+	}
+	rawdb.WriteConsensusEvidence(sb.db, height, hash, payload)
+
+	if sb.blockchain == nil {
+		return
+	}
+	head, err := sb.resolveCommittee(sb.blockchain, sb.blockchain.CurrentHeader())
+	if err != nil {
+		sb.logger.Error("Failed to resolve the committee of the current block", "err", err)
+		return
+	}
+	sb.send(head.Committee, tendermintEvidenceMsg, hash, payload)
+}
+
+// Evidence retrieves the misbehaviour evidence recorded at the given height.
+func (sb *Backend) Evidence(height uint64) ([]*tendermintCore.Evidence, error) {
+	return decodeEvidence(rawdb.ReadConsensusEvidence(sb.db, height))
+}
+
+// AllEvidence retrieves all the misbehaviour evidence recorded, sorted by height.
+func (sb *Backend) AllEvidence() ([]*tendermintCore.Evidence, error) {
+	return decodeEvidence(rawdb.ReadAllConsensusEvidence(sb.db))
+}
+
+func decodeEvidence(payloads [][]byte) ([]*tendermintCore.Evidence, error) {
+	evidence := make([]*tendermintCore.Evidence, 0, len(payloads))
+	for _, payload := range payloads {
+		e := new(tendermintCore.Evidence)
+		if err := rlp.DecodeBytes(payload, e); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, e)
+	}
+	return evidence, nil
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/consensus/tendermint/bft"
+	"github.com/clearmatics/autonity/core/types"
+)
+
+// errGenesisNotSealed is returned when the signers of the genesis block are
+// requested, no committee decided it.
+var errGenesisNotSealed = errors.New("genesis block is not sealed")
+
+// BlockSigners returns the committee members which sealed the block of header
+// with their voting power, along with the quorum of the committee of its
+// parent which decided it.
+func (sb *Backend) BlockSigners(chain consensus.ChainHeaderReader, header *types.Header) (*types.BlockSigners, error) {
+	parent, err := sb.decidingHeader(chain, header)
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := sb.Author(header)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := sb.indexedSealSigners(header, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	powers := make(map[common.Address]*big.Int, len(parent.Committee))
+	for _, member := range parent.Committee {
+		powers[member.Address] = member.VotingPower
+	}
+	block := &types.BlockSigners{
+		Number:   header.Number.Uint64(),
+		Hash:     header.Hash(),
+		Round:    header.Round,
+		Proposer: proposer,
+		Signers:  make([]types.BlockSigner, 0, len(signers)),
+		Quorum:   bft.Quorum(parent.Committee.TotalVotingPower()),
+	}
+	for _, signer := range signers {
+		power, ok := powers[signer]
+		if !ok {
+			// The seals are verified on import, this is a corrupted header.
+			return nil, errUnauthorized
+		}
+		block.Signers = append(block.Signers, types.BlockSigner{Address: signer, VotingPower: new(big.Int).Set(power)})
+		block.VotingPower += power.Uint64()
+	}
+	return block, nil
+}
+
+// Quorum returns the voting power the committee which decided the block of
+// header needed to seal it.
+func (sb *Backend) Quorum(chain consensus.ChainHeaderReader, header *types.Header) (uint64, error) {
+	parent, err := sb.decidingHeader(chain, header)
+	if err != nil {
+		return 0, err
+	}
+	return bft.Quorum(parent.Committee.TotalVotingPower()), nil
+}
+
+// decidingHeader returns the parent of header with the committee which decided
+// its block resolved.
+func (sb *Backend) decidingHeader(chain consensus.ChainHeaderReader, header *types.Header) (*types.Header, error) {
+	if header.IsGenesis() {
+		return nil, errGenesisNotSealed
+	}
+	parent, err := sb.resolveCommittee(chain, chain.GetHeader(header.ParentHash, header.Number.Uint64()-1))
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errUnknownBlock
+	}
+	return parent, nil
+}
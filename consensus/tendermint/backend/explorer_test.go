@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+)
+
+func TestBlockSigners(t *testing.T) {
+	chain, headers := newSealedHeaders(t, 4, 3)
+	for _, header := range headers {
+		chain[header.Hash()] = header
+	}
+	committee := headers[0].Committee
+	// The first member missed the last block.
+	head := headers[2]
+	head.CommittedSeals = head.CommittedSeals[1:]
+
+	engine := newLivenessBackend(&config.Config{})
+	signers, err := engine.BlockSigners(chain, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signers.Number != 3 || signers.Hash != head.Hash() || signers.Proposer != head.Coinbase {
+		t.Fatalf("block mismatch: have %d %v proposed by %v", signers.Number, signers.Hash, signers.Proposer)
+	}
+	if len(signers.Signers) != 3 || signers.VotingPower != 3 || signers.Quorum != 3 {
+		t.Fatalf("signers mismatch: have %d signers of power %d, quorum %d, want 3, 3 and 3", len(signers.Signers), signers.VotingPower, signers.Quorum)
+	}
+	for i, signer := range signers.Signers {
+		if signer.Address != committee[i+1].Address || signer.VotingPower.Uint64() != 1 {
+			t.Errorf("signer %d mismatch: have %v with power %v", i, signer.Address, signer.VotingPower)
+		}
+	}
+
+	quorum, err := engine.Quorum(chain, head)
+	if err != nil || quorum != 3 {
+		t.Fatalf("quorum mismatch: have %d (%v), want 3", quorum, err)
+	}
+	genesis := chain.GetHeaderByNumber(0)
+	if _, err := engine.BlockSigners(chain, genesis); err != errGenesisNotSealed {
+		t.Fatalf("error mismatch: have %v, want %v", err, errGenesisNotSealed)
+	}
+}
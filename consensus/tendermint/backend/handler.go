@@ -30,8 +30,9 @@ import (
 )
 
 const (
-	tendermintMsg     = 0x11
-	tendermintSyncMsg = 0x12
+	tendermintMsg         = 0x11
+	tendermintSyncMsg     = 0x12
+	tendermintEvidenceMsg = 0x13
 )
 
 type UnhandledMsg struct {
@@ -46,7 +47,7 @@ var (
 
 // Protocol implements consensus.Handler.Protocol
 func (sb *Backend) Protocol() (protocolName string, extraMsgCodes uint64) {
-	return "tendermint", 2 //nolint
+	return "tendermint", 3 //nolint
 }
 
 func (sb *Backend) HandleUnhandledMsgs(ctx context.Context) {
@@ -68,15 +69,23 @@ func (sb *Backend) HandleUnhandledMsgs(ctx context.Context) {
 
 // HandleMsg implements consensus.Handler.HandleMsg
 func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
-	if msg.Code != tendermintMsg && msg.Code != tendermintSyncMsg {
+	if msg.Code != tendermintMsg && msg.Code != tendermintSyncMsg && msg.Code != tendermintEvidenceMsg {
 		return false, nil
 	}
 
+	// Returning an error disconnects the peer.
+	if sb.peerScores.abusive(addr, now()) {
+		return true, errAbusivePeer
+	}
+
 	sb.coreMu.Lock()
 	defer sb.coreMu.Unlock()
 
 	switch msg.Code {
 	case tendermintMsg:
+		if sb.isSentry() {
+			return true, sb.relayMessage(addr, msg)
+		}
 		if !sb.coreStarted {
 			buffer := new(bytes.Buffer)
 			if _, err := io.Copy(buffer, msg.Payload); err != nil {
@@ -94,17 +103,7 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 		}
 
 		hash := types.RLPHash(data)
-
-		// Mark peer's message
-		ms, ok := sb.recentMessages.Get(addr)
-		var m *lru.ARCCache
-		if ok {
-			m, _ = ms.(*lru.ARCCache)
-		} else {
-			m, _ = lru.NewARC(inmemoryMessages)
-			sb.recentMessages.Add(addr, m)
-		}
-		m.Add(hash, true)
+		sb.markPeerMessage(addr, hash)
 
 		// Mark self known message
 		if _, ok := sb.knownMessages.Get(hash); ok {
@@ -114,14 +113,27 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 
 		sb.postEvent(events.MessageEvent{
 			Payload: data,
+			Sender:  addr,
 		})
 	case tendermintSyncMsg:
+		if sb.isSentry() {
+			sb.relaySyncRequest(addr)
+		}
 		if !sb.coreStarted {
 			sb.logger.Info("Sync message received but core not running")
 			return true, nil // we return nil as we don't want to shutdown the connection if core is stopped
 		}
 		sb.logger.Info("Received sync message", "from", addr)
 		sb.postEvent(events.SyncEvent{Addr: addr})
+	case tendermintEvidenceMsg:
+		// Evidence is verified against the chain, it doesn't need the core to be running.
+		var data []byte
+		if err := msg.Decode(&data); err != nil {
+			return true, errDecodeFailed
+		}
+		if err := sb.handleEvidence(addr, data); err != nil {
+			return true, err
+		}
 	default:
 		return false, nil
 	}
@@ -129,6 +141,19 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 	return true, nil
 }
 
+// markPeerMessage records that the peer knows about the message with the given hash.
+func (sb *Backend) markPeerMessage(addr common.Address, hash common.Hash) {
+	ms, ok := sb.recentMessages.Get(addr)
+	var m *lru.ARCCache
+	if ok {
+		m, _ = ms.(*lru.ARCCache)
+	} else {
+		m, _ = lru.NewARC(inmemoryMessages)
+		sb.recentMessages.Add(addr, m)
+	}
+	m.Add(hash, true)
+}
+
 // SetBroadcaster implements consensus.Handler.SetBroadcaster
 func (sb *Backend) SetBroadcaster(broadcaster consensus.Broadcaster) {
 	sb.broadcaster = broadcaster
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/clearmatics/autonity/common"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core/rawdb"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/log"
@@ -121,11 +123,58 @@ func TestProtocol(t *testing.T) {
 	if name != "tendermint" {
 		t.Fatalf("expected 'tendermint', got %v", name)
 	}
-	if code != 2 {
-		t.Fatalf("expected 2, got %v", code)
+	if code != 3 {
+		t.Fatalf("expected 3, got %v", code)
 	}
 }
 
+func TestEvidenceMessage(t *testing.T) {
+	addr := common.BytesToAddress([]byte("address"))
+	newBackend := func() *Backend {
+		recentMessages, _ := lru.NewARC(inmemoryPeers)
+		return &Backend{
+			logger:         log.New("backend", "test", "id", 0),
+			db:             rawdb.NewMemoryDatabase(),
+			recentMessages: recentMessages,
+		}
+	}
+
+	t.Run("undecodable evidence, error returned", func(t *testing.T) {
+		b := newBackend()
+		msg := makeMsg(tendermintEvidenceMsg, []byte("not an evidence"))
+		if res, err := b.HandleMsg(addr, msg); !res || err != errDecodeEvidenceFailed {
+			t.Fatalf("expected %v, got %v", errDecodeEvidenceFailed, err)
+		}
+	})
+
+	t.Run("known evidence, ignored", func(t *testing.T) {
+		b := newBackend()
+		evidence := &tendermintCore.Evidence{Offender: addr, Height: 5, First: []byte{1}, Second: []byte{2}}
+		payload, err := rlp.EncodeToBytes(evidence)
+		if err != nil {
+			t.Fatalf("expected <nil>, got %v", err)
+		}
+		rawdb.WriteConsensusEvidence(b.db, 5, evidence.Hash(), payload)
+
+		if res, err := b.HandleMsg(addr, makeMsg(tendermintEvidenceMsg, payload)); !res || err != nil {
+			t.Fatalf("HandleMsg unexpected return")
+		}
+		if ms, ok := b.recentMessages.Get(addr); !ok {
+			t.Fatalf("the cache of messages for this peer cannot be nil")
+		} else if _, ok := ms.(*lru.ARCCache).Get(evidence.Hash()); !ok {
+			t.Fatalf("the evidence should be known by the peer")
+		}
+
+		stored, err := b.Evidence(5)
+		if err != nil {
+			t.Fatalf("expected <nil>, got %v", err)
+		}
+		if len(stored) != 1 || stored[0].Hash() != evidence.Hash() {
+			t.Fatalf("expected the stored evidence, got %v", stored)
+		}
+	})
+}
+
 func TestNewChainHead(t *testing.T) {
 	t.Run("engine not started, error returned", func(t *testing.T) {
 		b := &Backend{}
@@ -0,0 +1,208 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/metrics"
+)
+
+const (
+	// defaultUptimeWindow is the number of blocks over which the uptime of the
+	// committee members is computed when no window is given, and reported in
+	// the metrics.
+	defaultUptimeWindow = 100
+	// maxUptimeWindow is the largest number of blocks over which the uptime
+	// can be computed.
+	maxUptimeWindow = 10000
+	// inmemorySigners is the number of blocks whose signers are kept indexed.
+	inmemorySigners = maxUptimeWindow
+	// livenessMissedMetric is the name of the gauges of the number of blocks
+	// each committee member missed over the default window.
+	livenessMissedMetric = "tendermint/liveness/missed"
+)
+
+// errInvalidUptimeWindow is returned when the uptime is requested over no
+// block or too many of them.
+var errInvalidUptimeWindow = errors.New("invalid uptime window")
+
+// ValidatorUptime is the liveness of a committee member over a window of
+// blocks.
+type ValidatorUptime struct {
+	Address common.Address `json:"address"`
+	Blocks  uint64         `json:"blocks"` // Blocks of the window it was a committee member of
+	Missed  uint64         `json:"missed"` // Blocks of the window it did not seal
+}
+
+// Uptime is the liveness of the committee members over the blocks From to To.
+type Uptime struct {
+	From       uint64            `json:"from"`
+	To         uint64            `json:"to"`
+	Validators []ValidatorUptime `json:"validators"`
+}
+
+// Uptime computes the liveness of the committee members over the window of
+// blocks ending at head, from the committed seals recorded in the local chain.
+// As a block only needs a quorum of seals, a member whose seal reached this
+// node after the quorum was gathered is counted as having missed it.
+func (sb *Backend) Uptime(chain consensus.ChainHeaderReader, head *types.Header, window uint64) (*Uptime, error) {
+	if window == 0 || window > maxUptimeWindow {
+		return nil, errInvalidUptimeWindow
+	}
+	uptime := &Uptime{From: head.Number.Uint64(), To: head.Number.Uint64()}
+	indexes := make(map[common.Address]int)
+	header := head
+	for n := uint64(0); n < window && !header.IsGenesis(); n++ {
+		parent, err := sb.resolveCommittee(chain, chain.GetHeader(header.ParentHash, header.Number.Uint64()-1))
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, errUnknownBlock
+		}
+		signers, err := sb.indexedSealSigners(header, parent)
+		if err != nil {
+			return nil, err
+		}
+		missed := make(map[common.Address]bool)
+		for _, address := range absentees(parent.Committee, signers) {
+			missed[address] = true
+		}
+		for _, member := range parent.Committee {
+			i, ok := indexes[member.Address]
+			if !ok {
+				i = len(uptime.Validators)
+				indexes[member.Address] = i
+				uptime.Validators = append(uptime.Validators, ValidatorUptime{Address: member.Address})
+			}
+			uptime.Validators[i].Blocks++
+			if missed[member.Address] {
+				uptime.Validators[i].Missed++
+			}
+		}
+		uptime.From = header.Number.Uint64()
+		header = parent
+	}
+	sort.Slice(uptime.Validators, func(i, j int) bool {
+		return bytes.Compare(uptime.Validators[i].Address[:], uptime.Validators[j].Address[:]) < 0
+	})
+	return uptime, nil
+}
+
+// indexedSealSigners returns the committee members of parent which sealed
+// header, from the index of the recent blocks if possible.
+func (sb *Backend) indexedSealSigners(header, parent *types.Header) ([]common.Address, error) {
+	hash := header.Hash()
+	if signers, ok := sb.sealSignersIndex.Get(hash); ok {
+		return signers.([]common.Address), nil
+	}
+	signers, err := sb.sealSigners(header, parent)
+	if err != nil {
+		return nil, err
+	}
+	sb.sealSignersIndex.Add(hash, signers)
+	return signers, nil
+}
+
+// sealSigners returns the committee members of parent which sealed header. The
+// seals are expected to have been verified already.
+func (sb *Backend) sealSigners(header, parent *types.Header) ([]common.Address, error) {
+	if sb.isAggregatedSeal(header.Number, parent) {
+		indexes, err := types.SealSignerIndexes(len(parent.Committee), header.SealSigners)
+		if err != nil {
+			return nil, err
+		}
+		signers := make([]common.Address, len(indexes))
+		for i, index := range indexes {
+			signers[i] = parent.Committee[index].Address
+		}
+		return signers, nil
+	}
+	headerSeal := tendermintCore.PrepareCommittedSeal(header.Hash(), int64(header.Round), header.Number)
+	return recoverSealers(headerSeal, header.CommittedSeals)
+}
+
+// pastAbsentees returns the committee members which did not seal the parent of
+// header according to the committed seals of the parent header carries. Unlike
+// the seals of the parent itself, which differ from node to node, they are part
+// of the block and can be reported to the Autonity contract.
+func (sb *Backend) pastAbsentees(chain consensus.ChainHeaderReader, header *types.Header) ([]common.Address, error) {
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	grandparent, err := sb.resolveCommittee(chain, chain.GetHeader(parent.ParentHash, parent.Number.Uint64()-1))
+	if err != nil {
+		return nil, err
+	}
+	if grandparent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	past, err := sb.withPastCommittedSeals(header, parent, grandparent)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := sb.sealSigners(past, grandparent)
+	if err != nil {
+		return nil, err
+	}
+	return absentees(grandparent.Committee, signers), nil
+}
+
+// absentees returns the committee members which are not signers, in the order
+// of the committee.
+func absentees(committee types.Committee, signers []common.Address) []common.Address {
+	sealed := make(map[common.Address]bool, len(signers))
+	for _, signer := range signers {
+		sealed[signer] = true
+	}
+	var missed []common.Address
+	for _, member := range committee {
+		if !sealed[member.Address] {
+			missed = append(missed, member.Address)
+		}
+	}
+	return missed
+}
+
+// livenessMetricsLoop reports the number of blocks each committee member missed
+// over the default window ending at every new head of the chain, labelled by
+// the address of the member.
+func (sb *Backend) livenessMetricsLoop(bc *core.BlockChain) {
+	heads := make(chan core.ChainHeadEvent, 10)
+	sub := bc.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	missed := metrics.GetOrRegisterGaugeFloat64Vec(livenessMissedMetric, nil, "address")
+	members := make(map[common.Address]bool)
+	for {
+		select {
+		case head := <-heads:
+			uptime, err := sb.Uptime(bc, head.Block.Header(), defaultUptimeWindow)
+			if err != nil {
+				sb.logger.Debug("Could not compute the uptime of the committee", "err", err)
+				continue
+			}
+			committee := make(map[common.Address]bool, len(uptime.Validators))
+			for _, validator := range uptime.Validators {
+				committee[validator.Address] = true
+				missed.With(validator.Address.Hex()).Update(float64(validator.Missed))
+			}
+			// The members which left the committee have nothing more to report.
+			for address := range members {
+				if !committee[address] {
+					missed.Delete(address.Hex())
+				}
+			}
+			members = committee
+		case <-sub.Err():
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package backend
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+func newLivenessBackend(config *config.Config) *Backend {
+	sealSignersIndex, _ := lru.New(inmemorySigners)
+	return &Backend{
+		config:           config,
+		logger:           log.New("backend", "test", "id", 0),
+		sealSignersIndex: sealSignersIndex,
+	}
+}
+
+func TestUptime(t *testing.T) {
+	chain, headers := newSealedHeaders(t, 4, 10)
+	for _, header := range headers {
+		chain[header.Hash()] = header
+	}
+	committee := headers[0].Committee
+	// The last member misses block 5 and the first one block 9, the seals
+	// are not part of the hash.
+	headers[4].CommittedSeals = headers[4].CommittedSeals[:3]
+	headers[8].CommittedSeals = headers[8].CommittedSeals[1:]
+
+	engine := newLivenessBackend(&config.Config{})
+	head := headers[len(headers)-1]
+
+	uptime, err := engine.Uptime(chain, head, defaultUptimeWindow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uptime.From != 1 || uptime.To != 10 {
+		t.Fatalf("window mismatch: have %d-%d, want 1-10", uptime.From, uptime.To)
+	}
+	missed := map[common.Address]uint64{committee[0].Address: 1, committee[3].Address: 1}
+	if len(uptime.Validators) != len(committee) {
+		t.Fatalf("validators mismatch: have %d, want %d", len(uptime.Validators), len(committee))
+	}
+	for _, validator := range uptime.Validators {
+		if validator.Blocks != 10 || validator.Missed != missed[validator.Address] {
+			t.Errorf("uptime mismatch of %v: have %d/%d, want %d/10", validator.Address, validator.Missed, validator.Blocks, missed[validator.Address])
+		}
+	}
+
+	uptime, err = engine.Uptime(chain, head, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uptime.From != 8 {
+		t.Fatalf("window start mismatch: have %d, want 8", uptime.From)
+	}
+	for _, validator := range uptime.Validators {
+		if validator.Address == committee[0].Address && validator.Missed != 1 {
+			t.Errorf("missed blocks mismatch: have %d, want 1", validator.Missed)
+		}
+		if validator.Address == committee[3].Address && validator.Missed != 0 {
+			t.Errorf("missed blocks mismatch: have %d, want 0", validator.Missed)
+		}
+	}
+
+	if _, err := engine.Uptime(chain, head, maxUptimeWindow+1); err != errInvalidUptimeWindow {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidUptimeWindow)
+	}
+}
+
+func TestPastCommittedSeals(t *testing.T) {
+	chain, headers := newSealedHeaders(t, 4, 3)
+	for _, header := range headers[:2] {
+		chain[header.Hash()] = header
+	}
+	grandparent, parent, header := headers[0], headers[1], headers[2]
+	committee := grandparent.Committee
+
+	engine := newLivenessBackend(&config.Config{DowntimeBlock: big.NewInt(3)})
+	header.PastCommittedSeals = parent.CommittedSeals[1:]
+	if err := engine.verifyPastCommittedSeals(header, parent, grandparent); err != nil {
+		t.Fatal(err)
+	}
+	absentees, err := engine.pastAbsentees(chain, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []common.Address{committee[0].Address}; !reflect.DeepEqual(absentees, want) {
+		t.Fatalf("absentees mismatch: have %v, want %v", absentees, want)
+	}
+
+	// The past committed seals must hold a quorum.
+	header.PastCommittedSeals = parent.CommittedSeals[2:]
+	if err := engine.verifyPastCommittedSeals(header, parent, grandparent); err != errInvalidPastCommittedSeals {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidPastCommittedSeals)
+	}
+	header.PastCommittedSeals = nil
+	if err := engine.verifyPastCommittedSeals(header, parent, grandparent); err != errInvalidPastCommittedSeals {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidPastCommittedSeals)
+	}
+
+	// They must not be carried before the fork.
+	engine.config = &config.Config{DowntimeBlock: big.NewInt(4)}
+	header.PastCommittedSeals = parent.CommittedSeals
+	if err := engine.verifyPastCommittedSeals(header, parent, grandparent); err != errInvalidPastCommittedSeals {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidPastCommittedSeals)
+	}
+	header.PastCommittedSeals = nil
+	if err := engine.verifyPastCommittedSeals(header, parent, grandparent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAbsentees(t *testing.T) {
+	committee := types.Committee{
+		{Address: common.HexToAddress("0x01"), VotingPower: common.Big1},
+		{Address: common.HexToAddress("0x02"), VotingPower: common.Big1},
+		{Address: common.HexToAddress("0x03"), VotingPower: common.Big1},
+	}
+	missed := absentees(committee, []common.Address{committee[1].Address})
+	if want := []common.Address{committee[0].Address, committee[2].Address}; !reflect.DeepEqual(missed, want) {
+		t.Fatalf("absentees mismatch: have %v, want %v", missed, want)
+	}
+	if missed := absentees(committee, []common.Address{committee[2].Address, committee[0].Address, committee[1].Address}); missed != nil {
+		t.Fatalf("absentees mismatch: have %v, want none", missed)
+	}
+}
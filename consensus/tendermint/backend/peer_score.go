@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/metrics"
+)
+
+const (
+	// maxPeerPenalty is the penalty beyond which the consensus messages of a
+	// peer are refused, which disconnects it.
+	maxPeerPenalty = 200
+	// peerPenaltyDecay is the penalty a peer is forgiven every second.
+	peerPenaltyDecay = 2
+)
+
+var (
+	// errAbusivePeer is returned for the messages of a peer whose penalty
+	// exceeds maxPeerPenalty.
+	errAbusivePeer = errors.New("abusive consensus peer")
+
+	abusivePeerMeter = metrics.NewRegisteredMeter("tendermint/peers/abusive", nil)
+)
+
+type peerScore struct {
+	penalty float64
+	updated time.Time
+}
+
+// peerScores keeps track of the penalties the core reports for the peers
+// sending abusive consensus messages. The penalties decay over time so that
+// a peer is only disconnected for a sustained abuse.
+type peerScores struct {
+	mu     sync.Mutex
+	scores map[common.Address]*peerScore
+}
+
+// penalize adds penalty to the score of address and returns its total
+// penalty.
+func (s *peerScores) penalize(address common.Address, penalty int, now time.Time) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scores == nil {
+		s.scores = make(map[common.Address]*peerScore)
+	}
+	score := s.decay(address, now)
+	if score == nil {
+		score = &peerScore{updated: now}
+		s.scores[address] = score
+	}
+	score.penalty += float64(penalty)
+	return score.penalty
+}
+
+// abusive returns whether the penalty of address exceeds maxPeerPenalty.
+func (s *peerScores) abusive(address common.Address, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	score := s.decay(address, now)
+	return score != nil && score.penalty > maxPeerPenalty
+}
+
+// decay forgives the penalty of address accrued since its last update, the
+// score is forgotten once the penalty is entirely forgiven.
+func (s *peerScores) decay(address common.Address, now time.Time) *peerScore {
+	score, ok := s.scores[address]
+	if !ok {
+		return nil
+	}
+	score.penalty -= now.Sub(score.updated).Seconds() * peerPenaltyDecay
+	score.updated = now
+	if score.penalty <= 0 {
+		delete(s.scores, address)
+		return nil
+	}
+	return score
+}
+
+// PenalizePeer implements tendermint.Backend.PenalizePeer. The sentries of
+// the node and the validators it is a sentry of are never penalized, as they
+// relay the messages of other members they can't tell abusive beforehand.
+func (sb *Backend) PenalizePeer(address common.Address, penalty int) {
+	if _, ok := sb.sentries[address]; ok {
+		return
+	}
+	if _, ok := sb.sentryValidators[address]; ok {
+		return
+	}
+	before := sb.peerScores.abusive(address, now())
+	if total := sb.peerScores.penalize(address, penalty, now()); total > maxPeerPenalty && !before {
+		sb.logger.Warn("Disconnecting abusive consensus peer", "peer", address, "penalty", total)
+		abusivePeerMeter.Mark(1)
+	}
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+)
+
+func TestPeerScores(t *testing.T) {
+	var (
+		scores peerScores
+		peer   = common.HexToAddress("0x01")
+		start  = time.Unix(1000, 0)
+	)
+	if scores.abusive(peer, start) {
+		t.Fatal("unknown peer is abusive")
+	}
+	if total := scores.penalize(peer, maxPeerPenalty, start); total != maxPeerPenalty {
+		t.Fatalf("penalty mismatch: have %v, want %v", total, maxPeerPenalty)
+	}
+	if scores.abusive(peer, start) {
+		t.Fatal("peer at the maximum penalty is abusive")
+	}
+	scores.penalize(peer, 1, start)
+	if !scores.abusive(peer, start) {
+		t.Fatal("peer beyond the maximum penalty is not abusive")
+	}
+	if scores.abusive(common.HexToAddress("0x02"), start) {
+		t.Fatal("penalty of another peer applied")
+	}
+
+	// The penalty is forgiven over time.
+	if scores.abusive(peer, start.Add(time.Second)) {
+		t.Fatal("penalty not forgiven")
+	}
+	scores.abusive(peer, start.Add(time.Hour))
+	if len(scores.scores) != 0 {
+		t.Fatal("forgiven peer still scored")
+	}
+}
@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"errors"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	tendermintConfig "github.com/clearmatics/autonity/consensus/tendermint/config"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	tendermintCrypto "github.com/clearmatics/autonity/consensus/tendermint/crypto"
+	"github.com/clearmatics/autonity/consensus/tendermint/events"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/p2p"
+)
+
+// invalidRelayPenalty is the penalty of a peer for every consensus message a
+// sentry node refuses to relay.
+const invalidRelayPenalty = 10
+
+// In sentry mode a validator does not expose itself to the other committee
+// members: it only connects to its sentry nodes, which relay the consensus
+// messages between it and the rest of the network. A sentry node relays every
+// message of a committee member it did not know of to all its peers, so that
+// the messages of a validator behind sentries reach the committee and the
+// other way round.
+
+// sentryAddresses returns the addresses of the nodes of the given enode URLs,
+// which were validated with the config.
+func sentryAddresses(urls []string, logger log.Logger) map[common.Address]struct{} {
+	nodes, err := tendermintConfig.ParseEnodes(urls)
+	if err != nil {
+		logger.Error("Invalid sentry enode", "err", err)
+		return nil
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	addresses := make(map[common.Address]struct{}, len(nodes))
+	for _, node := range nodes {
+		addresses[crypto.PubkeyToAddress(*node.Pubkey())] = struct{}{}
+	}
+	return addresses
+}
+
+// isSentry returns whether the node relays the consensus messages of
+// validators behind it.
+func (sb *Backend) isSentry() bool {
+	return len(sb.sentryValidators) > 0
+}
+
+// gossipPeers returns the connected peers a consensus message for the given
+// committee is sent to: the sentries of a validator behind sentries, all the
+// peers of a sentry node and the other committee members otherwise.
+func (sb *Backend) gossipPeers(committee types.Committee) map[common.Address]consensus.Peer {
+	if len(sb.sentries) > 0 {
+		return sb.broadcaster.FindPeers(sb.sentries)
+	}
+	if sb.isSentry() {
+		return sb.broadcaster.Peers()
+	}
+	targets := make(map[common.Address]struct{})
+	for _, val := range committee {
+		if val.Address != sb.Address() {
+			targets[val.Address] = struct{}{}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return sb.broadcaster.FindPeers(targets)
+}
+
+// relayMessage relays a consensus message received by a sentry node to its
+// peers which don't know it yet. A sentry node isn't necessarily synced, so
+// the message is only checked to be signed by a member of the committee of the
+// last block. The peer sending a message which isn't is only penalized if the
+// message is malformed or its signature isn't the one of its sender. A message
+// is relayed once only.
+func (sb *Backend) relayMessage(addr common.Address, msg p2p.Msg) error {
+	var data []byte
+	if err := msg.Decode(&data); err != nil {
+		return errDecodeFailed
+	}
+
+	hash := types.RLPHash(data)
+	sb.markPeerMessage(addr, hash)
+	if _, ok := sb.knownMessages.Get(hash); ok {
+		return nil
+	}
+	if sb.currentBlock == nil {
+		// Without a chain there is no committee to check the message against.
+		return nil
+	}
+	if err := sb.verifyRelayedMessage(data); err != nil {
+		sb.logger.Debug("Dropping invalid consensus message", "from", addr, "err", err)
+		// The signer of a message can be a member of a committee the node
+		// hasn't synced yet, which an honest peer relays as well.
+		if !errors.Is(err, tendermintCrypto.ErrUnauthorizedAddress) {
+			sb.PenalizePeer(addr, invalidRelayPenalty)
+		}
+		return nil
+	}
+	sb.knownMessages.Add(hash, true)
+	sb.send(nil, tendermintMsg, hash, data)
+
+	if sb.coreStarted {
+		sb.postEvent(events.MessageEvent{
+			Payload: data,
+			Sender:  addr,
+		})
+	}
+	return nil
+}
+
+// verifyRelayedMessage checks that payload is a consensus message signed by a
+// member of the committee of the last block.
+func (sb *Backend) verifyRelayedMessage(payload []byte) error {
+	msg := new(tendermintCore.Message)
+	if err := msg.FromPayload(payload); err != nil {
+		return errDecodeFailed
+	}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		return errDecodeFailed
+	}
+	lastBlock, _ := sb.LastCommittedProposal()
+	signer, err := tendermintCrypto.CheckValidatorSignature(lastBlock.Header(), data, msg.Signature)
+	if err != nil {
+		return err
+	}
+	if signer != msg.Address {
+		return ErrUnauthorizedAddress
+	}
+	return nil
+}
+
+// relaySyncRequest relays the synchronization request of a protected
+// validator to the network and the ones from the network to the protected
+// validators. The messages they are answered with are relayed back like any
+// other consensus message.
+func (sb *Backend) relaySyncRequest(addr common.Address) {
+	if sb.broadcaster == nil {
+		return
+	}
+	var ps map[common.Address]consensus.Peer
+	if _, ok := sb.sentryValidators[addr]; ok {
+		ps = sb.broadcaster.Peers()
+	} else {
+		ps = sb.broadcaster.FindPeers(sb.sentryValidators)
+	}
+	for peer, p := range ps {
+		if peer != addr {
+			go p.Send(tendermintSyncMsg, []byte{}) //nolint
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package backend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+func newSentryBackend(t *testing.T, sentries, validators map[common.Address]struct{}) *Backend {
+	recentMessages, err := lru.NewARC(inmemoryPeers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	knownMessages, err := lru.NewARC(inmemoryMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Backend{
+		logger:           log.New("backend", "test", "id", 0),
+		recentMessages:   recentMessages,
+		knownMessages:    knownMessages,
+		sentries:         sentries,
+		sentryValidators: validators,
+	}
+}
+
+// newSignedVote returns the payload of a prevote for the given round signed by
+// key.
+func newSignedVote(t *testing.T, key *ecdsa.PrivateKey, round int64) []byte {
+	return newSignedVoteFrom(t, key, crypto.PubkeyToAddress(key.PublicKey), round)
+}
+
+// newSignedVoteFrom returns a vote of the given sender signed with key.
+func newSignedVoteFrom(t *testing.T, key *ecdsa.PrivateKey, sender common.Address, round int64) []byte {
+	vote, err := tendermintCore.Encode(&tendermintCore.Vote{Round: round, Height: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &tendermintCore.Message{Code: 1, Msg: vote, Address: sender}
+	data, err := msg.PayloadNoSig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+// newSentPeers returns mocked peers which report their address on the returned
+// channel for every message sent to them.
+func newSentPeers(ctrl *gomock.Controller, addresses ...common.Address) (map[common.Address]consensus.Peer, chan common.Address) {
+	sent := make(chan common.Address, 10)
+	peers := make(map[common.Address]consensus.Peer)
+	for _, address := range addresses {
+		address := address
+		peer := consensus.NewMockPeer(ctrl)
+		peer.EXPECT().Send(gomock.Any(), gomock.Any()).Do(func(_, _ interface{}) {
+			sent <- address
+		}).AnyTimes()
+		peers[address] = peer
+	}
+	return peers, sent
+}
+
+// receivers returns the peers n messages were sent to, and fails if more were.
+func receivers(t *testing.T, sent chan common.Address, n int) map[common.Address]bool {
+	to := make(map[common.Address]bool)
+	for i := 0; i < n; i++ {
+		select {
+		case address := <-sent:
+			to[address] = true
+		case <-time.After(time.Second):
+			t.Fatalf("sent messages mismatch: have %d, want %d", i, n)
+		}
+	}
+	select {
+	case address := <-sent:
+		t.Fatalf("unexpected message sent to %v", address)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return to
+}
+
+func TestSentryGossip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	header := newTestHeader(4)
+	sentry := common.HexToAddress("0x01")
+	sentries := map[common.Address]struct{}{sentry: {}}
+	peers, sent := newSentPeers(ctrl, sentry)
+
+	// The committee members are not looked up, the sentries relay to them.
+	broadcaster := consensus.NewMockBroadcaster(ctrl)
+	broadcaster.EXPECT().FindPeers(sentries).Return(peers).Times(1)
+
+	b := newSentryBackend(t, sentries, nil)
+	b.SetBroadcaster(broadcaster)
+	payload, err := rlp.EncodeToBytes([]byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Gossip(context.Background(), header.Committee, payload)
+	if to := receivers(t, sent, 1); !to[sentry] {
+		t.Fatalf("message not sent to the sentry")
+	}
+}
+
+func TestSentryRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		validator = common.HexToAddress("0x01")
+		member    = common.HexToAddress("0x02")
+		sentry    = common.HexToAddress("0x03")
+	)
+	validators := map[common.Address]struct{}{validator: {}}
+	peers, sent := newSentPeers(ctrl, validator, member, sentry)
+
+	broadcaster := consensus.NewMockBroadcaster(ctrl)
+	broadcaster.EXPECT().Peers().Return(peers).AnyTimes()
+	broadcaster.EXPECT().FindPeers(validators).Return(map[common.Address]consensus.Peer{validator: peers[validator]}).AnyTimes()
+
+	memberKey, _ := crypto.GenerateKey()
+	outsiderKey, _ := crypto.GenerateKey()
+	lastBlock := types.NewBlockWithHeader(&types.Header{
+		Number: common.Big0,
+		Committee: types.Committee{{
+			Address:     crypto.PubkeyToAddress(memberKey.PublicKey),
+			VotingPower: common.Big1,
+		}},
+	})
+
+	b := newSentryBackend(t, nil, validators)
+	b.currentBlock = func() *types.Block { return lastBlock }
+	b.SetBroadcaster(broadcaster)
+
+	t.Run("consensus messages are relayed once to the other peers", func(t *testing.T) {
+		vote := newSignedVote(t, memberKey, 0)
+		if _, err := b.HandleMsg(member, makeMsg(tendermintMsg, vote)); err != nil {
+			t.Fatal(err)
+		}
+		if to := receivers(t, sent, 2); !to[validator] || !to[sentry] {
+			t.Fatalf("receivers mismatch: have %v, want the validator and the sentry", to)
+		}
+		if _, err := b.HandleMsg(sentry, makeMsg(tendermintMsg, vote)); err != nil {
+			t.Fatal(err)
+		}
+		receivers(t, sent, 0)
+	})
+
+	t.Run("invalid consensus messages are dropped and their peer penalized", func(t *testing.T) {
+		forged := newSignedVoteFrom(t, memberKey, crypto.PubkeyToAddress(outsiderKey.PublicKey), 1)
+		for _, payload := range [][]byte{[]byte("vote"), forged} {
+			if _, err := b.HandleMsg(member, makeMsg(tendermintMsg, payload)); err != nil {
+				t.Fatal(err)
+			}
+			receivers(t, sent, 0)
+		}
+		if have, want := b.peerScores.penalize(member, 0, now()), 2.0*invalidRelayPenalty; have > want || have < want-1 {
+			t.Fatalf("penalty mismatch: have %v, want %v", have, want)
+		}
+	})
+
+	t.Run("consensus messages of unknown members are dropped without penalty", func(t *testing.T) {
+		unknown := newSignedVote(t, outsiderKey, 2)
+		if _, err := b.HandleMsg(sentry, makeMsg(tendermintMsg, unknown)); err != nil {
+			t.Fatal(err)
+		}
+		receivers(t, sent, 0)
+		if b.peerScores.penalize(sentry, 0, now()) != 0 {
+			t.Fatal("peer relaying a message of an unknown member penalized")
+		}
+	})
+
+	t.Run("the validators behind the sentry are never penalized", func(t *testing.T) {
+		b.PenalizePeer(validator, maxPeerPenalty+1)
+		if b.peerScores.abusive(validator, now()) {
+			t.Fatal("validator behind the sentry penalized")
+		}
+	})
+
+	t.Run("sync requests of the validators are relayed to the network", func(t *testing.T) {
+		if _, err := b.HandleMsg(validator, makeMsg(tendermintSyncMsg, []byte{})); err != nil {
+			t.Fatal(err)
+		}
+		if to := receivers(t, sent, 2); !to[member] || !to[sentry] {
+			t.Fatalf("receivers mismatch: have %v, want the member and the sentry", to)
+		}
+	})
+
+	t.Run("sync requests of the network are relayed to the validators", func(t *testing.T) {
+		if _, err := b.HandleMsg(member, makeMsg(tendermintSyncMsg, []byte{})); err != nil {
+			t.Fatal(err)
+		}
+		if to := receivers(t, sent, 1); !to[validator] {
+			t.Fatalf("receivers mismatch: have %v, want the validator", to)
+		}
+	})
+}
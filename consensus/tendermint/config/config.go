@@ -16,26 +16,327 @@
 
 package config
 
-import "flag"
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/clearmatics/autonity/p2p/enode"
+)
 
 var blockPeriod = flag.Uint64("blockperiod", 0, "The minimum time between blocks in seconds")
 
+// errSentryOfSentries is returned when a node is configured both as a sentry
+// and as a validator behind sentries.
+var errSentryOfSentries = errors.New("a tendermint sentry node cannot have sentries")
+
+// errEpochSettings is returned when only one of the epoch block and the epoch
+// length is set.
+var errEpochSettings = errors.New("tendermint epoch-block and epoch-length must be set together")
+
+// errEpochProposerPolicy is returned when epochs are set along with a proposer
+// policy which does not elect the proposer from the epoch committee.
+var errEpochProposerPolicy = errors.New("tendermint epochs require the round-robin or weighted-round-robin proposer policy")
+
 type ProposerPolicy uint64
 
 const (
 	RoundRobin ProposerPolicy = iota
 	WeightedRandomSampling
+	WeightedRoundRobin
+)
+
+// proposerPolicies are the proposer policies by the name under which they can
+// be selected in the genesis.
+var proposerPolicies = map[string]ProposerPolicy{
+	"round-robin":              RoundRobin,
+	"weighted-random-sampling": WeightedRandomSampling,
+	"weighted-round-robin":     WeightedRoundRobin,
+}
+
+// ProposerPolicyByName returns the proposer policy of the given name.
+func ProposerPolicyByName(name string) (ProposerPolicy, error) {
+	policy, ok := proposerPolicies[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown tendermint proposer policy %q", name)
+	}
+	return policy, nil
+}
+
+// UnmarshalJSON parses a proposer policy from its name, or from its number as
+// genesis files used to set it.
+func (p *ProposerPolicy) UnmarshalJSON(input []byte) error {
+	var name string
+	if err := json.Unmarshal(input, &name); err != nil {
+		return json.Unmarshal(input, (*uint64)(p))
+	}
+	policy, err := ProposerPolicyByName(name)
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+func (p ProposerPolicy) String() string {
+	for name, policy := range proposerPolicies {
+		if policy == p {
+			return name
+		}
+	}
+	return fmt.Sprintf("ProposerPolicy(%d)", uint64(p))
+}
+
+const (
+	// DefaultInitialTimeout is the timeout of every step at round 0 when not configured, in milliseconds.
+	DefaultInitialTimeout uint64 = 500
+	// DefaultTimeoutDelta is the increase of every step timeout at each round when not configured, in milliseconds.
+	DefaultTimeoutDelta uint64 = 200
+	// MaxTimeout is the highest value allowed for a timeout or its increase, in milliseconds.
+	MaxTimeout uint64 = 60000
+	// DefaultTraceFileSize is the size above which the consensus trace file is rotated when not configured, in megabytes.
+	DefaultTraceFileSize uint64 = 100
 )
 
 type Config struct {
 	BlockPeriod    uint64         `toml:",omitempty" json:"block-period"` // Default minimum difference between two consecutive block's timestamps in second
-	ProposerPolicy ProposerPolicy `toml:",omitempty" json:"policy"`       // The policy for proposer selection
+	ProposerPolicy ProposerPolicy `toml:",omitempty" json:"policy"`       // The policy for proposer selection, by name or number in the genesis
+
+	// Step timeouts in milliseconds, the timeout of a step at round r is its
+	// initial value plus r times its delta. Nil means the default value, so
+	// that a delta can explicitly be set to zero.
+	ProposeTimeout        *uint64 `toml:",omitempty" json:"propose-timeout,omitempty"`
+	ProposeTimeoutDelta   *uint64 `toml:",omitempty" json:"propose-timeout-delta,omitempty"`
+	PrevoteTimeout        *uint64 `toml:",omitempty" json:"prevote-timeout,omitempty"`
+	PrevoteTimeoutDelta   *uint64 `toml:",omitempty" json:"prevote-timeout-delta,omitempty"`
+	PrecommitTimeout      *uint64 `toml:",omitempty" json:"precommit-timeout,omitempty"`
+	PrecommitTimeoutDelta *uint64 `toml:",omitempty" json:"precommit-timeout-delta,omitempty"`
+
+	// AggregatedSealBlock is the first block whose committed seals are
+	// aggregated into a single BLS signature, nil means never. The seals of a
+	// block are only aggregated if every member of its committee has a BLS
+	// key.
+	AggregatedSealBlock *big.Int `toml:",omitempty" json:"aggregated-seal-block,omitempty"`
+
+	// DowntimeBlock is the first block whose header carries the committed
+	// seals of its parent, from which the committee members having missed
+	// the parent are reported to the Autonity contract, nil means never.
+	DowntimeBlock *big.Int `toml:",omitempty" json:"downtime-block,omitempty"`
+
+	// EpochBlock is the first epoch header, from which the committee only
+	// changes every EpochLength blocks, nil means never. Before it the
+	// committee can change at every block.
+	EpochBlock *big.Int `toml:",omitempty" json:"epoch-block,omitempty"`
+
+	// EpochLength is the number of blocks the committee stays the same for
+	// from EpochBlock on. The committee is only carried by the epoch headers,
+	// every EpochLength blocks from EpochBlock, the headers in between carry
+	// its hash.
+	EpochLength uint64 `toml:",omitempty" json:"epoch-length,omitempty"`
+
+	// TraceFile is the file the consensus inputs and decisions of the node
+	// are recorded to for offline replay, empty means no recording. Unlike
+	// the settings above it is local to the node.
+	TraceFile string `toml:",omitempty" json:"-"`
+	// TraceFileSize is the size in megabytes above which the trace file is
+	// rotated, zero means the default size.
+	TraceFileSize uint64 `toml:",omitempty" json:"-"`
+
+	// Sentries are the enode URLs of the sentry nodes a validator reaches
+	// the network through, it exchanges consensus messages with them only.
+	Sentries []string `toml:",omitempty" json:"-"`
+	// SentryValidators are the enode URLs of the validators a sentry node
+	// protects, setting them makes the node relay the consensus messages
+	// between them and the rest of the network.
+	SentryValidators []string `toml:",omitempty" json:"-"`
+
+	// Signer is the path of the local socket of the signing service holding
+	// the validator keys. The node key signs the consensus messages and
+	// blocks when it is empty. The service must hold the node key as well,
+	// as the committee members are known to their peers by their enode, so
+	// that it only keeps the signing, and the record of the values signed,
+	// apart from the node.
+	Signer string `toml:",omitempty" json:"-"`
+	// SafetyMargin is the number of blocks the node must see committed past
+	// the last height it signed for before it signs again after a restart,
+	// so that a second instance of the validator or one restored from a
+	// backup doesn't sign conflicting messages. Zero means no wait.
+	SafetyMargin uint64 `toml:",omitempty" json:"-"`
+}
+
+// Timeouts are the durations the consensus waits at each step before giving
+// up on the round.
+type Timeouts struct {
+	Propose        time.Duration
+	ProposeDelta   time.Duration
+	Prevote        time.Duration
+	PrevoteDelta   time.Duration
+	Precommit      time.Duration
+	PrecommitDelta time.Duration
 }
 
 func (c *Config) String() string {
 	return "tendermint"
 }
 
+// Timeouts returns the step timeouts of the config, the ones which are not set
+// take their default value.
+func (c *Config) Timeouts() Timeouts {
+	timeout := func(ms *uint64, def uint64) time.Duration {
+		if ms == nil {
+			return time.Duration(def) * time.Millisecond
+		}
+		return time.Duration(*ms) * time.Millisecond
+	}
+	return Timeouts{
+		Propose:        timeout(c.ProposeTimeout, DefaultInitialTimeout),
+		ProposeDelta:   timeout(c.ProposeTimeoutDelta, DefaultTimeoutDelta),
+		Prevote:        timeout(c.PrevoteTimeout, DefaultInitialTimeout),
+		PrevoteDelta:   timeout(c.PrevoteTimeoutDelta, DefaultTimeoutDelta),
+		Precommit:      timeout(c.PrecommitTimeout, DefaultInitialTimeout),
+		PrecommitDelta: timeout(c.PrecommitTimeoutDelta, DefaultTimeoutDelta),
+	}
+}
+
+// OverrideTimeouts replaces the timeouts of c with the ones set in other, zero
+// values included.
+func (c *Config) OverrideTimeouts(other *Config) {
+	override := func(dst **uint64, src *uint64) {
+		if src != nil {
+			v := *src
+			*dst = &v
+		}
+	}
+	override(&c.ProposeTimeout, other.ProposeTimeout)
+	override(&c.ProposeTimeoutDelta, other.ProposeTimeoutDelta)
+	override(&c.PrevoteTimeout, other.PrevoteTimeout)
+	override(&c.PrevoteTimeoutDelta, other.PrevoteTimeoutDelta)
+	override(&c.PrecommitTimeout, other.PrecommitTimeout)
+	override(&c.PrecommitTimeoutDelta, other.PrecommitTimeoutDelta)
+}
+
+// IsAggregatedSeal returns whether the committed seals of the given block are
+// aggregated into a single BLS signature, provided every member of its
+// committee has a BLS key.
+func (c *Config) IsAggregatedSeal(number *big.Int) bool {
+	return c.AggregatedSealBlock != nil && c.AggregatedSealBlock.Cmp(number) <= 0
+}
+
+// IsDowntime returns whether the header of the given block carries the
+// committed seals of its parent. The first block has none to carry as its
+// parent is the genesis.
+func (c *Config) IsDowntime(number *big.Int) bool {
+	return c.DowntimeBlock != nil && c.DowntimeBlock.Cmp(number) <= 0 && number.Cmp(big.NewInt(1)) > 0
+}
+
+// isEpoch returns whether the committee of the given block only changes at
+// epoch headers.
+func (c *Config) isEpoch(number *big.Int) bool {
+	return c.EpochBlock != nil && c.EpochLength > 0 && c.EpochBlock.Cmp(number) <= 0
+}
+
+// IsEpochHeader returns whether the header of the given block carries the
+// committee rather than its hash.
+func (c *Config) IsEpochHeader(number *big.Int) bool {
+	if !c.isEpoch(number) {
+		return true
+	}
+	return new(big.Int).Mod(new(big.Int).Sub(number, c.EpochBlock), new(big.Int).SetUint64(c.EpochLength)).Sign() == 0
+}
+
+// EpochHeaderNumber returns the number of the epoch header of the given block,
+// whose committee the block carries the hash of.
+func (c *Config) EpochHeaderNumber(number uint64) uint64 {
+	if !c.isEpoch(new(big.Int).SetUint64(number)) {
+		return number
+	}
+	return number - (number-c.EpochBlock.Uint64())%c.EpochLength
+}
+
+// Validate checks that the timeouts of the config are within bounds, that its
+// proposer policy exists and that its sentry enodes are valid.
+func (c *Config) Validate() error {
+	if _, err := ProposerPolicyByName(c.ProposerPolicy.String()); err != nil {
+		return err
+	}
+	if err := c.validateEpoch(); err != nil {
+		return err
+	}
+	for _, t := range []struct {
+		name    string
+		value   *uint64
+		initial bool
+	}{
+		{"propose-timeout", c.ProposeTimeout, true},
+		{"propose-timeout-delta", c.ProposeTimeoutDelta, false},
+		{"prevote-timeout", c.PrevoteTimeout, true},
+		{"prevote-timeout-delta", c.PrevoteTimeoutDelta, false},
+		{"precommit-timeout", c.PrecommitTimeout, true},
+		{"precommit-timeout-delta", c.PrecommitTimeoutDelta, false},
+	} {
+		switch {
+		case t.value == nil:
+		case *t.value > MaxTimeout:
+			return fmt.Errorf("invalid tendermint %s: %dms is above the maximum of %dms", t.name, *t.value, MaxTimeout)
+		case *t.value == 0 && t.initial:
+			// A step would time out before any message could be received.
+			return fmt.Errorf("invalid tendermint %s: must be above zero", t.name)
+		}
+	}
+	if len(c.Sentries) > 0 && len(c.SentryValidators) > 0 {
+		return errSentryOfSentries
+	}
+	if _, err := ParseEnodes(c.Sentries); err != nil {
+		return fmt.Errorf("invalid tendermint sentry: %v", err)
+	}
+	if _, err := ParseEnodes(c.SentryValidators); err != nil {
+		return fmt.Errorf("invalid tendermint sentry validator: %v", err)
+	}
+	return nil
+}
+
+// validateEpoch checks that the settings of the network are compatible with
+// a committee changing at epoch boundaries only.
+func (c *Config) validateEpoch() error {
+	if (c.EpochBlock == nil) != (c.EpochLength == 0) {
+		return errEpochSettings
+	}
+	if c.EpochBlock == nil {
+		return nil
+	}
+	// The proposer elected by the Autonity contract is drawn from the
+	// committee the deployed contracts compute at every block, which may
+	// have changed within the epoch.
+	if c.ProposerPolicy == WeightedRandomSampling {
+		return errEpochProposerPolicy
+	}
+	// The first aggregated seal is verified against the BLS keys of the
+	// committee of its parent, which must be an epoch header to carry them.
+	if c.AggregatedSealBlock != nil && c.AggregatedSealBlock.Sign() > 0 {
+		parent := new(big.Int).Sub(c.AggregatedSealBlock, big.NewInt(1))
+		if !c.IsEpochHeader(parent) {
+			return fmt.Errorf("invalid tendermint aggregated-seal-block: %v does not follow an epoch header", c.AggregatedSealBlock)
+		}
+	}
+	return nil
+}
+
+// ParseEnodes parses a list of enode URLs.
+func ParseEnodes(urls []string) ([]*enode.Node, error) {
+	nodes := make([]*enode.Node, 0, len(urls))
+	for _, url := range urls {
+		node, err := enode.Parse(enode.ValidSchemes, url)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		BlockPeriod:    *blockPeriod,
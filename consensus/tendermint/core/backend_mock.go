@@ -108,6 +108,20 @@ func (mr *MockBackendMockRecorder) Commit(proposalBlock, round, seals interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockBackend)(nil).Commit), proposalBlock, round, seals)
 }
 
+// CommitAggregated mocks base method
+func (m *MockBackend) CommitAggregated(proposalBlock *types.Block, round int64, seal, signers []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitAggregated", proposalBlock, round, seal, signers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitAggregated indicates an expected call of CommitAggregated
+func (mr *MockBackendMockRecorder) CommitAggregated(proposalBlock, round, seal, signers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitAggregated", reflect.TypeOf((*MockBackend)(nil).CommitAggregated), proposalBlock, round, seal, signers)
+}
+
 // GetContractABI mocks base method
 func (m *MockBackend) GetContractABI() string {
 	m.ctrl.T.Helper()
@@ -189,6 +203,18 @@ func (mr *MockBackendMockRecorder) LastCommittedProposal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCommittedProposal", reflect.TypeOf((*MockBackend)(nil).LastCommittedProposal))
 }
 
+// PenalizePeer mocks base method
+func (m *MockBackend) PenalizePeer(address common.Address, penalty int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PenalizePeer", address, penalty)
+}
+
+// PenalizePeer indicates an expected call of PenalizePeer
+func (mr *MockBackendMockRecorder) PenalizePeer(address, penalty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PenalizePeer", reflect.TypeOf((*MockBackend)(nil).PenalizePeer), address, penalty)
+}
+
 // Post mocks base method
 func (m *MockBackend) Post(ev interface{}) {
 	m.ctrl.T.Helper()
@@ -201,6 +227,18 @@ func (mr *MockBackendMockRecorder) Post(ev interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockBackend)(nil).Post), ev)
 }
 
+// ReportEvidence mocks base method
+func (m *MockBackend) ReportEvidence(ctx context.Context, evidence *Evidence) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportEvidence", ctx, evidence)
+}
+
+// ReportEvidence indicates an expected call of ReportEvidence
+func (mr *MockBackendMockRecorder) ReportEvidence(ctx, evidence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportEvidence", reflect.TypeOf((*MockBackend)(nil).ReportEvidence), ctx, evidence)
+}
+
 // SetProposedBlockHash mocks base method
 func (m *MockBackend) SetProposedBlockHash(hash common.Hash) {
 	m.ctrl.T.Helper()
@@ -228,6 +266,21 @@ func (mr *MockBackendMockRecorder) Sign(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockBackend)(nil).Sign), arg0)
 }
 
+// SignBLS mocks base method
+func (m *MockBackend) SignBLS(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignBLS", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignBLS indicates an expected call of SignBLS
+func (mr *MockBackendMockRecorder) SignBLS(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignBLS", reflect.TypeOf((*MockBackend)(nil).SignBLS), arg0)
+}
+
 // Subscribe mocks base method
 func (m *MockBackend) Subscribe(types ...interface{}) *event.TypeMuxSubscription {
 	m.ctrl.T.Helper()
@@ -17,20 +17,33 @@
 package core
 
 import (
-	"github.com/clearmatics/autonity/common"
 	"math/big"
-)
 
-const MaxSizeBacklogUnchecked = 1000
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/metrics"
+)
 
-var (
-	// msgPriority is defined for calculating processing priority to speedup consensus
-	// msgProposal > msgPrecommit > msgPrevote
-	msgPriority = map[uint64]int{
-		msgProposal:  1,
-		msgPrecommit: 2,
-		msgPrevote:   3,
-	}
+const (
+	// MaxSizeBacklogUnchecked bounds the number of future height messages kept
+	// in the untrusted backlog.
+	MaxSizeBacklogUnchecked = 1000
+	// maxUncheckedBacklogPerHeight bounds the number of messages of a same
+	// future height kept in the untrusted backlog, so that the next heights
+	// always have room left.
+	maxUncheckedBacklogPerHeight = MaxSizeBacklogUnchecked / 2
+	// maxUncheckedBacklogPerSender bounds the number of future height messages
+	// of a same sender kept in the untrusted backlog. As the messages are not
+	// verified yet the sender is only the claimed one, which is why exceeding
+	// this quota is not penalized.
+	maxUncheckedBacklogPerSender = 100
+	// maxBacklogPerSender bounds the number of future round and future step
+	// messages of a same committee member kept in the backlog, a member
+	// following the protocol never gets that far ahead of us.
+	maxBacklogPerSender = 100
+	// backlogPenalty is the penalty reported to the backend, for the peer
+	// which relayed it, for every message of a committee member received
+	// beyond its quota.
+	backlogPenalty = 1
 )
 
 type backlogEvent struct {
@@ -46,7 +59,7 @@ type backlogUncheckedEvent struct {
 // return errOldHeightMessage if the message view is smaller than curRoundMessages view
 // return errFutureStepMessage if we are at the same view but at the propose step and it's a voting message.
 func (c *core) checkMessage(round int64, height *big.Int, step Step) error {
-	if height == nil || round < 0 || round > MaxRound {
+	if height == nil || round < 0 {
 		return errInvalidMessage
 	}
 
@@ -65,6 +78,9 @@ func (c *core) checkMessage(round int64, height *big.Int, step Step) error {
 	return nil
 }
 
+// storeBacklog stores a future round or future step message of the committee
+// member src. Once src reaches its quota, the message the furthest ahead is
+// dropped and the peer which relayed the message is penalized.
 func (c *core) storeBacklog(msg *Message, src common.Address) {
 	logger := c.logger.New("from", src, "step", c.step)
 
@@ -73,12 +89,29 @@ func (c *core) storeBacklog(msg *Message, src common.Address) {
 		return
 	}
 
+	backlog := c.backlogs[src]
+	if len(backlog) >= maxBacklogPerSender {
+		logger.Debug("Backlog quota exceeded", "quota", maxBacklogPerSender)
+		if msg.relay != (common.Address{}) {
+			c.backend.PenalizePeer(msg.relay, backlogPenalty)
+		}
+		i := furthestMessage(backlog)
+		if !isFurther(backlog[i], msg) {
+			c.dropBacklog(msg, backlogDroppedMeter)
+			return
+		}
+		c.dropBacklog(backlog[i], backlogDroppedMeter)
+		backlog = append(backlog[:i], backlog[i+1:]...)
+	}
+
 	logger.Debug("Store future message")
-	c.backlogs[src] = append(c.backlogs[src], msg)
+	c.backlogs[src] = append(backlog, msg)
 }
 
 // storeUncheckedBacklog push to a special backlog future height consensus messages
 // this is done in a way that prevents memory exhaustion in the case of a malicious peer.
+// The messages are kept within a quota per height, per sender and overall,
+// the message the furthest ahead being discarded first.
 func (c *core) storeUncheckedBacklog(msg *Message) {
 	// future height messages of a gap wider than one block should not occur frequently as block sync should happen
 	// Todo : implement a double ended priority queue (DEPQ)
@@ -87,31 +120,101 @@ func (c *core) storeUncheckedBacklog(msg *Message) {
 	if errHeight != nil {
 		panic("error parsing height")
 	}
+	height := msgHeight.Uint64()
+
+	if backlog := c.backlogUnchecked[height]; len(backlog) >= maxUncheckedBacklogPerHeight {
+		i := furthestMessage(backlog)
+		if !isFurther(backlog[i], msg) {
+			c.dropBacklog(msg, backlogUncheckedDroppedMeter)
+			return
+		}
+		c.removeUncheckedBacklog(height, i)
+	}
+
+	var (
+		sent           int
+		furthestHeight uint64
+		furthestIndex  int
+		furthestSent   *Message
+	)
+	for h, backlog := range c.backlogUnchecked {
+		for i, m := range backlog {
+			if m.Address != msg.Address {
+				continue
+			}
+			sent++
+			if furthestSent == nil || isFurther(m, furthestSent) {
+				furthestHeight, furthestIndex, furthestSent = h, i, m
+			}
+		}
+	}
+	if sent >= maxUncheckedBacklogPerSender {
+		if !isFurther(furthestSent, msg) {
+			c.dropBacklog(msg, backlogUncheckedDroppedMeter)
+			return
+		}
+		c.removeUncheckedBacklog(furthestHeight, furthestIndex)
+	}
 
-	c.backlogUnchecked[msgHeight.Uint64()] = append(c.backlogUnchecked[msgHeight.Uint64()], msg)
+	c.backlogUnchecked[height] = append(c.backlogUnchecked[height], msg)
 	c.backlogUncheckedLen++
 	// We discard the furthest ahead messages in priority.
-	if c.backlogUncheckedLen == MaxSizeBacklogUnchecked+1 {
-		maxHeight := msgHeight.Uint64()
+	if c.backlogUncheckedLen > MaxSizeBacklogUnchecked {
+		maxHeight := height
 		for k := range c.backlogUnchecked {
 			if k > maxHeight && len(c.backlogUnchecked[k]) > 0 {
 				maxHeight = k
 			}
 		}
+		c.removeUncheckedBacklog(maxHeight, furthestMessage(c.backlogUnchecked[maxHeight]))
+	}
+}
 
-		// Forget in the local cache that we ever received this message.
-		// It's needed for it to be able to be re-received and processed later, after a consensus sync, if needed.
-		c.backend.RemoveMessageFromLocalCache(c.backlogUnchecked[maxHeight][len(c.backlogUnchecked[maxHeight])-1].Payload())
+// removeUncheckedBacklog drops the message i of the given height from the
+// untrusted backlog.
+func (c *core) removeUncheckedBacklog(height uint64, i int) {
+	backlog := c.backlogUnchecked[height]
+	c.dropBacklog(backlog[i], backlogUncheckedDroppedMeter)
 
-		// Remove it from the backlog buffer.
-		c.backlogUnchecked[maxHeight] = c.backlogUnchecked[maxHeight][:len(c.backlogUnchecked[maxHeight])-1]
-		c.backlogUncheckedLen--
+	// Remove it from the backlog buffer.
+	c.backlogUnchecked[height] = append(backlog[:i], backlog[i+1:]...)
+	c.backlogUncheckedLen--
 
-		if len(c.backlogUnchecked[maxHeight]) == 0 {
-			delete(c.backlogUnchecked, maxHeight)
+	if len(c.backlogUnchecked[height]) == 0 {
+		delete(c.backlogUnchecked, height)
+	}
+}
+
+// dropBacklog discards a message which is not kept in the backlogs.
+func (c *core) dropBacklog(msg *Message, meter metrics.Meter) {
+	// Forget in the local cache that we ever received this message.
+	// It's needed for it to be able to be re-received and processed later, after a consensus sync, if needed.
+	c.backend.RemoveMessageFromLocalCache(msg.Payload())
+	meter.Mark(1)
+}
+
+// furthestMessage returns the index of the message of msgs the furthest ahead,
+// the last one among equals.
+func furthestMessage(msgs []*Message) int {
+	furthest := 0
+	for i := range msgs {
+		if !isFurther(msgs[furthest], msgs[i]) {
+			furthest = i
 		}
 	}
+	return furthest
+}
 
+// isFurther returns whether a is further ahead than b, by height then round.
+func isFurther(a, b *Message) bool {
+	aHeight, _ := a.Height()
+	bHeight, _ := b.Height()
+	if cmp := aHeight.Cmp(bHeight); cmp != 0 {
+		return cmp > 0
+	}
+	aRound, _ := a.Round()
+	bRound, _ := b.Round()
+	return aRound > bRound
 }
 
 func (c *core) processBacklog() {
@@ -169,6 +272,7 @@ func (c *core) processBacklog() {
 			}
 		}
 		if height <= c.height.Uint64() {
+			c.backlogUncheckedLen -= len(c.backlogUnchecked[height])
 			delete(c.backlogUnchecked, height)
 		}
 	}
@@ -666,14 +666,15 @@ func TestStoreUncheckedBacklog(t *testing.T) {
 
 		for i := int64(0); i < MaxSizeBacklogUnchecked; i++ {
 			nilRoundVote := &Vote{
-				Round:  i % 10,
-				Height: big.NewInt(i / (1 + i%10)),
+				Round:  i,
+				Height: big.NewInt(5 + i%4),
 			}
 			payload, err := rlp.EncodeToBytes(nilRoundVote)
 			require.NoError(t, err)
 			msg := &Message{
 				Code:       msgPrevote,
 				Msg:        payload,
+				Address:    common.BigToAddress(big.NewInt(i % 20)),
 				decodedMsg: nilRoundVote,
 			}
 			c.storeUncheckedBacklog(msg)
@@ -741,6 +742,7 @@ func TestStoreUncheckedBacklog(t *testing.T) {
 			msg := &Message{
 				Code:       msgPrevote,
 				Msg:        payload,
+				Address:    common.BigToAddress(big.NewInt(i)),
 				decodedMsg: nilRoundVote,
 			}
 			c.storeUncheckedBacklog(msg)
@@ -769,3 +771,154 @@ func TestStoreUncheckedBacklog(t *testing.T) {
 		}
 	})
 }
+
+func newBacklogVote(t *testing.T, round int64, height int64, sender common.Address) *Message {
+	vote := &Vote{
+		Round:  round,
+		Height: big.NewInt(height),
+	}
+	payload, err := rlp.EncodeToBytes(vote)
+	require.NoError(t, err)
+	return &Message{
+		Code:       msgPrevote,
+		Msg:        payload,
+		Address:    sender,
+		decodedMsg: vote,
+	}
+}
+
+func TestBacklogQuotas(t *testing.T) {
+	sender := common.HexToAddress("0x0987654321")
+
+	t.Run("relay of a sender beyond its quota is penalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		backendMock := NewMockBackend(ctrl)
+		c := &core{
+			logger:   log.New("backend", "test", "id", 0),
+			backend:  backendMock,
+			address:  common.HexToAddress("0x1234567890"),
+			backlogs: make(map[common.Address][]*Message),
+			step:     propose,
+			height:   big.NewInt(4),
+		}
+
+		for r := int64(1); r <= maxBacklogPerSender; r++ {
+			c.storeBacklog(newBacklogVote(t, r, 4, sender), sender)
+		}
+		require.Len(t, c.backlogs[sender], maxBacklogPerSender)
+
+		// A nearer message evicts the furthest ahead one.
+		relay := common.HexToAddress("0x5555")
+		backendMock.EXPECT().PenalizePeer(relay, backlogPenalty).Times(2)
+		evicted := c.backlogs[sender][maxBacklogPerSender-1]
+		backendMock.EXPECT().RemoveMessageFromLocalCache(evicted.Payload())
+		near := newBacklogVote(t, 1, 4, sender)
+		near.relay = relay
+		c.storeBacklog(near, sender)
+		require.Len(t, c.backlogs[sender], maxBacklogPerSender)
+		require.Contains(t, c.backlogs[sender], near)
+		require.NotContains(t, c.backlogs[sender], evicted)
+
+		// A further message is dropped.
+		far := newBacklogVote(t, 2*maxBacklogPerSender, 4, sender)
+		far.relay = relay
+		backendMock.EXPECT().RemoveMessageFromLocalCache(far.Payload())
+		c.storeBacklog(far, sender)
+		require.Len(t, c.backlogs[sender], maxBacklogPerSender)
+		require.NotContains(t, c.backlogs[sender], far)
+	})
+
+	t.Run("unchecked messages are bounded per sender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		backendMock := NewMockBackend(ctrl)
+		c := &core{
+			logger:           log.New("backend", "test", "id", 0),
+			backend:          backendMock,
+			address:          common.HexToAddress("0x1234567890"),
+			backlogUnchecked: make(map[uint64][]*Message),
+			height:           big.NewInt(4),
+		}
+
+		for i := int64(0); i < maxUncheckedBacklogPerSender; i++ {
+			c.storeUncheckedBacklog(newBacklogVote(t, i, 5+i%2, sender))
+		}
+		require.Equal(t, maxUncheckedBacklogPerSender, c.backlogUncheckedLen)
+
+		// The unchecked messages are not penalized, the sender is not verified.
+		backendMock.EXPECT().PenalizePeer(gomock.Any(), gomock.Any()).Times(0)
+		evicted := c.backlogUnchecked[6][len(c.backlogUnchecked[6])-1]
+		backendMock.EXPECT().RemoveMessageFromLocalCache(evicted.Payload())
+		near := newBacklogVote(t, 0, 5, sender)
+		c.storeUncheckedBacklog(near)
+		require.Equal(t, maxUncheckedBacklogPerSender, c.backlogUncheckedLen)
+		require.Contains(t, c.backlogUnchecked[5], near)
+		require.NotContains(t, c.backlogUnchecked[6], evicted)
+
+		far := newBacklogVote(t, 0, 7, sender)
+		backendMock.EXPECT().RemoveMessageFromLocalCache(far.Payload())
+		c.storeUncheckedBacklog(far)
+		require.Equal(t, maxUncheckedBacklogPerSender, c.backlogUncheckedLen)
+		require.Empty(t, c.backlogUnchecked[7])
+
+		// Other senders have their own quota.
+		c.storeUncheckedBacklog(newBacklogVote(t, 0, 7, common.HexToAddress("0x01")))
+		require.Len(t, c.backlogUnchecked[7], 1)
+	})
+
+	t.Run("unchecked messages are bounded per height", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		backendMock := NewMockBackend(ctrl)
+		c := &core{
+			logger:           log.New("backend", "test", "id", 0),
+			backend:          backendMock,
+			address:          common.HexToAddress("0x1234567890"),
+			backlogUnchecked: make(map[uint64][]*Message),
+			height:           big.NewInt(4),
+		}
+
+		for i := int64(0); i < maxUncheckedBacklogPerHeight; i++ {
+			c.storeUncheckedBacklog(newBacklogVote(t, i%10, 5, common.BigToAddress(big.NewInt(i))))
+		}
+		require.Len(t, c.backlogUnchecked[5], maxUncheckedBacklogPerHeight)
+
+		far := newBacklogVote(t, 10, 5, sender)
+		backendMock.EXPECT().RemoveMessageFromLocalCache(far.Payload())
+		c.storeUncheckedBacklog(far)
+		require.Len(t, c.backlogUnchecked[5], maxUncheckedBacklogPerHeight)
+		require.NotContains(t, c.backlogUnchecked[5], far)
+
+		// The next height is not affected by the quota of this one.
+		c.storeUncheckedBacklog(newBacklogVote(t, 0, 6, sender))
+		require.Len(t, c.backlogUnchecked[6], 1)
+	})
+	t.Run("processed unchecked messages are no longer counted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		backendMock := NewMockBackend(ctrl)
+		backendMock.EXPECT().Post(gomock.Any()).AnyTimes()
+		c := &core{
+			logger:           log.New("backend", "test", "id", 0),
+			backend:          backendMock,
+			address:          common.HexToAddress("0x1234567890"),
+			backlogs:         make(map[common.Address][]*Message),
+			backlogUnchecked: make(map[uint64][]*Message),
+			height:           big.NewInt(4),
+		}
+
+		c.storeUncheckedBacklog(newBacklogVote(t, 0, 5, sender))
+		c.storeUncheckedBacklog(newBacklogVote(t, 0, 6, sender))
+		require.Equal(t, 2, c.backlogUncheckedLen)
+
+		c.setHeight(big.NewInt(5))
+		c.processBacklog()
+		require.Equal(t, 1, c.backlogUncheckedLen)
+		require.Empty(t, c.backlogUnchecked[5])
+	})
+}
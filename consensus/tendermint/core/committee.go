@@ -2,17 +2,22 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/consensus/tendermint/bft"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	ethcore "github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/state"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/log"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 type committee interface {
@@ -30,6 +35,31 @@ type committee interface {
 	F() uint64
 }
 
+// newCommittee builds the committee of the height following the last block
+// mined, electing its proposers with the given policy.
+func newCommittee(c *core, policy config.ProposerPolicy, lastBlock *types.Block) (committee, error) {
+	switch policy {
+	case config.RoundRobin:
+		var lastProposer common.Address
+		if !lastBlock.Header().IsGenesis() {
+			var err error
+			lastProposer, err = types.Ecrecover(lastBlock.Header())
+			if err != nil {
+				return nil, fmt.Errorf("unable to recover proposer address from header %q: %v", lastBlock.Header(), err)
+			}
+		}
+		return newRoundRobinSet(lastBlock.Header().Committee, lastProposer)
+	case config.WeightedRandomSampling:
+		return newWeightedRandomSamplingCommittee(lastBlock, c.autonityContract, c.backend.BlockChain()), nil
+	case config.WeightedRoundRobin:
+		return newWeightedRoundRobinCommittee(lastBlock.Header().Committee, lastBlock.NumberU64()+1)
+	case tracedProposerPolicy:
+		return newTracedCommittee(c, lastBlock)
+	default:
+		return nil, fmt.Errorf("unrecognised proposer policy %q", policy)
+	}
+}
+
 type roundRobinCommittee struct {
 	members           types.Committee
 	lastBlockProposer common.Address
@@ -209,6 +239,166 @@ func (w *weightedRandomSamplingCommittee) F() uint64 {
 	return bft.F(w.previousHeader.TotalVotingPower())
 }
 
+// proposerPriorities caches the proposer priorities of the recent committees
+// at the last height they were computed for, so that the next height only
+// takes one more step.
+var proposerPriorities, _ = lru.New(16)
+
+// priorities are the proposer priorities of the members of a committee after
+// the given number of steps.
+type priorities struct {
+	step   uint64
+	values []*big.Int
+}
+
+// weightedRoundRobinCommittee elects the proposers with the proposer priority
+// algorithm of Tendermint: at every step each member gains its voting power in
+// priority, the member with the highest priority proposes and loses the total
+// voting power. The priorities start null when the committee is elected and
+// take a step at every height and at every round of a height, the rounds of a
+// height following the same steps as the next heights. Each member proposes
+// in proportion to its exact voting power, the priorities coming back to null
+// once every member proposed as many times as its voting power divided by the
+// greatest common divisor of the powers.
+type weightedRoundRobinCommittee struct {
+	members    types.Committee
+	totalPower uint64
+	weights    []*big.Int // voting powers of the members driving their priorities
+	total      *big.Int   // sum of the weights
+	priorities []*big.Int // priorities of the members at the height
+	proposers  map[int64]types.CommitteeMember
+	mu         sync.Mutex
+}
+
+func newWeightedRoundRobinCommittee(members types.Committee, height uint64) (*weightedRoundRobinCommittee, error) {
+	if len(members) == 0 {
+		return nil, ErrEmptyCommitteeSet
+	}
+	members = copyMembers(members)
+	sort.Sort(members)
+
+	committee := &weightedRoundRobinCommittee{
+		members:   members,
+		weights:   make([]*big.Int, len(members)),
+		total:     new(big.Int),
+		proposers: make(map[int64]types.CommitteeMember),
+	}
+	gcd := new(big.Int)
+	for i, m := range members {
+		committee.totalPower += m.VotingPower.Uint64()
+		committee.weights[i] = m.VotingPower
+		committee.total.Add(committee.total, m.VotingPower)
+		gcd.GCD(nil, nil, gcd, m.VotingPower)
+	}
+	if committee.total.Sign() == 0 {
+		// Only members without voting power, they propose in turn.
+		for i := range committee.weights {
+			committee.weights[i] = big.NewInt(1)
+		}
+		committee.total.SetInt64(int64(len(members)))
+		gcd.SetInt64(1)
+	}
+	// The priorities are periodic, which spares the steps of the cycles
+	// already completed.
+	step := height
+	if period := new(big.Int).Div(committee.total, gcd); period.IsUint64() {
+		step %= period.Uint64()
+	}
+
+	key := committeeKey(members)
+	start := &priorities{values: make([]*big.Int, len(members))}
+	for i := range start.values {
+		start.values[i] = new(big.Int)
+	}
+	if cached, ok := proposerPriorities.Get(key); ok && cached.(*priorities).step <= step {
+		start = cached.(*priorities)
+	}
+	values := copyPriorities(start.values)
+	for s := start.step; s < step; s++ {
+		incrementPriorities(values, committee.weights, committee.total)
+	}
+	proposerPriorities.Add(key, &priorities{step: step, values: values})
+	committee.priorities = values
+	return committee, nil
+}
+
+// committeeKey identifies a committee by its members and their voting power.
+func committeeKey(members types.Committee) string {
+	var key strings.Builder
+	for _, m := range members {
+		key.Write(m.Address.Bytes())
+		key.WriteString(m.VotingPower.String())
+		key.WriteByte(0)
+	}
+	return key.String()
+}
+
+// incrementPriorities takes a step of the proposer priorities and returns the
+// index of the member proposing at this step. Ties are broken in favour of
+// the lowest address.
+func incrementPriorities(values, weights []*big.Int, total *big.Int) int {
+	proposer := 0
+	for i, w := range weights {
+		values[i].Add(values[i], w)
+		if values[i].Cmp(values[proposer]) > 0 {
+			proposer = i
+		}
+	}
+	values[proposer].Sub(values[proposer], total)
+	return proposer
+}
+
+func copyPriorities(values []*big.Int) []*big.Int {
+	valuesCopy := make([]*big.Int, len(values))
+	for i, v := range values {
+		valuesCopy[i] = new(big.Int).Set(v)
+	}
+	return valuesCopy
+}
+
+func (set *weightedRoundRobinCommittee) Committee() types.Committee {
+	return copyMembers(set.members)
+}
+
+func (set *weightedRoundRobinCommittee) GetByIndex(i int) (types.CommitteeMember, error) {
+	if i < 0 || i >= len(set.members) {
+		return types.CommitteeMember{}, consensus.ErrCommitteeMemberNotFound
+	}
+	return set.members[i], nil
+}
+
+func (set *weightedRoundRobinCommittee) GetByAddress(addr common.Address) (int, types.CommitteeMember, error) {
+	for i, member := range set.members {
+		if addr == member.Address {
+			return i, member, nil
+		}
+	}
+	return -1, types.CommitteeMember{}, consensus.ErrCommitteeMemberNotFound
+}
+
+func (set *weightedRoundRobinCommittee) GetProposer(round int64) types.CommitteeMember {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	if proposer, ok := set.proposers[round]; ok {
+		return proposer
+	}
+	values := copyPriorities(set.priorities)
+	var proposer int
+	for r := int64(0); r <= round; r++ {
+		proposer = incrementPriorities(values, set.weights, set.total)
+	}
+	set.proposers[round] = set.members[proposer]
+	return set.members[proposer]
+}
+
+func (set *weightedRoundRobinCommittee) Quorum() uint64 {
+	return bft.Quorum(set.totalPower)
+}
+
+func (set *weightedRoundRobinCommittee) F() uint64 {
+	return bft.F(set.totalPower)
+}
+
 var ErrEmptyCommitteeSet = errors.New("committee set can't be empty")
 
 func copyMembers(members types.Committee) types.Committee {
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/crypto"
 	"github.com/stretchr/testify/assert"
@@ -297,6 +299,139 @@ func TestSet_QandF(t *testing.T) {
 
 }
 
+func TestWeightedRoundRobinCommittee(t *testing.T) {
+	committee := types.Committee{
+		{Address: common.HexToAddress("0x04"), VotingPower: big.NewInt(4)},
+		{Address: common.HexToAddress("0x02"), VotingPower: big.NewInt(2)},
+		{Address: common.HexToAddress("0x03"), VotingPower: big.NewInt(3)},
+		{Address: common.HexToAddress("0x01"), VotingPower: big.NewInt(1)},
+	}
+	// The proposers of the first heights by index of the sorted committee.
+	want := []int{3, 2, 1, 3, 0, 2, 3, 1, 2, 3}
+
+	set, err := newWeightedRoundRobinCommittee(committee, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), set.totalPower)
+
+	proposed := make(map[common.Address]int64)
+	for height := uint64(0); height < 10; height++ {
+		set, err := newWeightedRoundRobinCommittee(committee, height)
+		require.NoError(t, err)
+		proposer := set.GetProposer(0)
+		proposed[proposer.Address]++
+		require.Equal(t, set.members[want[height]], proposer)
+		// The next rounds of a height follow the steps of the next heights.
+		for round := int64(1); round < 15; round++ {
+			next, err := newWeightedRoundRobinCommittee(committee, height+uint64(round))
+			require.NoError(t, err)
+			require.Equal(t, next.GetProposer(0), set.GetProposer(round))
+		}
+	}
+	// Over a cycle, every member proposes as many times as its voting power.
+	for _, member := range committee {
+		require.Equal(t, member.VotingPower.Int64(), proposed[member.Address])
+	}
+
+	_, err = newWeightedRoundRobinCommittee(nil, 1)
+	assertError(t, ErrEmptyCommitteeSet, err)
+}
+
+// All the nodes must elect the same proposers whatever the order they got the
+// committee in and whatever they have in cache.
+func TestWeightedRoundRobinDeterminism(t *testing.T) {
+	committee := createTestCommitteeMembers(t, 20, 1000)
+	for i := range committee {
+		committee[i].VotingPower = big.NewInt(genRandUint64(1, 100))
+	}
+
+	const nodes = 4
+	proposers := make([][]common.Address, nodes)
+	for n := range proposers {
+		proposerPriorities.Purge()
+		members := copyMembers(committee)
+		rand.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+		for height := uint64(1); height <= 500; height++ {
+			set, err := newWeightedRoundRobinCommittee(members, height)
+			require.NoError(t, err)
+			for round := int64(0); round < 3; round++ {
+				proposers[n] = append(proposers[n], set.GetProposer(round).Address)
+			}
+		}
+	}
+	for n := 1; n < nodes; n++ {
+		require.Equal(t, proposers[0], proposers[n], "node %d elected other proposers", n)
+	}
+
+	// A node computing the priorities of a height from scratch, e.g. after a
+	// restart, elects the same proposer as the nodes which followed the chain.
+	proposerPriorities.Purge()
+	set, err := newWeightedRoundRobinCommittee(committee, 500)
+	require.NoError(t, err)
+	require.Equal(t, proposers[0][len(proposers[0])-3], set.GetProposer(0).Address)
+}
+
+// The members propose in proportion to their exact voting power, however
+// uneven the stakes.
+func TestWeightedRoundRobinFrequencies(t *testing.T) {
+	power := func(s string) *big.Int {
+		p, _ := new(big.Int).SetString(s, 10)
+		return p
+	}
+	for _, powers := range [][]*big.Int{
+		{big.NewInt(1), big.NewInt(10), big.NewInt(100), big.NewInt(1000)},
+		{big.NewInt(7), big.NewInt(13), big.NewInt(1), big.NewInt(29), big.NewInt(50)},
+		{power("1000000000000000001"), power("3000000000000000000"), big.NewInt(1)},
+		{power("1000000000000000000"), power("1000000000000000000"), power("1")},
+	} {
+		committee := make(types.Committee, len(powers))
+		total := new(big.Int)
+		for i, p := range powers {
+			committee[i] = types.CommitteeMember{Address: common.BigToAddress(big.NewInt(int64(i + 1))), VotingPower: p}
+			total.Add(total, p)
+		}
+		proposerPriorities.Purge()
+
+		const heights = 20000
+		proposed := make(map[common.Address]int64)
+		for height := uint64(0); height < heights; height++ {
+			set, err := newWeightedRoundRobinCommittee(committee, height)
+			require.NoError(t, err)
+			proposed[set.GetProposer(0).Address]++
+		}
+		for _, member := range committee {
+			expected := new(big.Int).Mul(member.VotingPower, big.NewInt(heights))
+			expected.Div(expected, total)
+			diff := proposed[member.Address] - expected.Int64()
+			require.True(t, diff >= -1 && diff <= 1, "power %v proposed %d times, want %v", member.VotingPower, proposed[member.Address], expected)
+		}
+	}
+
+	// Members without voting power propose in turn.
+	committee := types.Committee{{Address: common.HexToAddress("0x01"), VotingPower: new(big.Int)}, {Address: common.HexToAddress("0x02"), VotingPower: new(big.Int)}}
+	for height := uint64(0); height < 4; height++ {
+		set, err := newWeightedRoundRobinCommittee(committee, height)
+		require.NoError(t, err)
+		require.Equal(t, committee[height%2].Address, set.GetProposer(0).Address)
+	}
+}
+
+func TestProposerPolicies(t *testing.T) {
+	for _, name := range []string{"round-robin", "weighted-random-sampling", "weighted-round-robin"} {
+		policy, err := config.ProposerPolicyByName(name)
+		require.NoError(t, err)
+		require.Equal(t, name, policy.String())
+	}
+	_, err := config.ProposerPolicyByName("lottery")
+	require.Error(t, err)
+
+	var cfg config.Config
+	require.NoError(t, json.Unmarshal([]byte(`{"policy": "weighted-round-robin"}`), &cfg))
+	require.Equal(t, config.WeightedRoundRobin, cfg.ProposerPolicy)
+	require.NoError(t, json.Unmarshal([]byte(`{"policy": 1}`), &cfg))
+	require.Equal(t, config.WeightedRandomSampling, cfg.ProposerPolicy)
+	require.Error(t, json.Unmarshal([]byte(`{"policy": "lottery"}`), &cfg))
+}
+
 func assertNilError(t *testing.T, got error) {
 	t.Helper()
 	if got != nil {
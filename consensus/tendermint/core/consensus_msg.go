@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"io"
+	"math"
 	"math/big"
 
 	"github.com/clearmatics/autonity/common"
@@ -110,7 +111,7 @@ func (p *Proposal) DecodeRLP(s *rlp.Stream) error {
 		validRound = int64(proposal.ValidRound)
 	}
 
-	if !(validRound <= MaxRound && proposal.Round <= MaxRound) {
+	if proposal.Round > math.MaxInt64 || proposal.ValidRound > math.MaxInt64 {
 		return errors.New("bad proposal with invalid rounds")
 	}
 
@@ -156,10 +157,10 @@ func (sub *Vote) DecodeRLP(s *rlp.Stream) error {
 	if err := s.Decode(&vote); err != nil {
 		return err
 	}
-	sub.Round = int64(vote.Round)
-	if sub.Round > MaxRound {
+	if vote.Round > math.MaxInt64 {
 		return errInvalidMessage
 	}
+	sub.Round = int64(vote.Round)
 	sub.Height = vote.Height
 	sub.ProposedBlockHash = vote.ProposedBlockHash
 	return nil
@@ -28,8 +28,11 @@ import (
 
 	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto/bls"
+	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/log"
 )
@@ -71,19 +74,21 @@ var (
 	errMovedToNewRound = errors.New("timer expired and new round started")
 )
 
-const (
-	MaxRound = 99 // consequence of backlog priority
-)
-
-// New creates an Tendermint consensus core
-func New(backend Backend, config *config.Config) *core {
+// New creates an Tendermint consensus core, the write-ahead log is persisted in db.
+func New(backend Backend, config *config.Config, db ethdb.Database) *core {
 	addr := backend.Address()
 	logger := log.New("addr", addr.String())
 	messagesMap := newMessagesMap()
 	roundMessage := messagesMap.getOrCreate(0)
+	trace := newTracer(config, addr, logger)
+	if trace != nil {
+		backend = &tracingBackend{Backend: backend, trace: trace}
+	}
 	return &core{
 		proposerPolicy:        config.ProposerPolicy,
 		blockPeriod:           config.BlockPeriod,
+		timeouts:              config.Timeouts(),
+		aggregatedSealBlock:   config.AggregatedSealBlock,
 		address:               addr,
 		logger:                logger,
 		backend:               backend,
@@ -101,15 +106,22 @@ func New(backend Backend, config *config.Config) *core {
 		proposeTimeout:        newTimeout(propose, logger),
 		prevoteTimeout:        newTimeout(prevote, logger),
 		precommitTimeout:      newTimeout(precommit, logger),
+		wal:                   newWAL(db, logger),
+		guard:                 newSignGuard(db, config.SafetyMargin, logger),
+		trace:                 trace,
 	}
 }
 
 type core struct {
 	proposerPolicy config.ProposerPolicy
 	blockPeriod    uint64
+	timeouts       config.Timeouts
 	address        common.Address
 	logger         log.Logger
 
+	// first height whose committed seals are aggregated BLS signatures.
+	aggregatedSealBlock *big.Int
+
 	backend Backend
 	cancel  context.CancelFunc
 
@@ -161,6 +173,10 @@ type core struct {
 	futureRoundChange map[int64]map[common.Address]uint64
 
 	autonityContract *autonity.Contract
+
+	wal   *wal
+	guard *signGuard
+	trace *tracer
 }
 
 func (c *core) GetCurrentHeightMessages() []*Message {
@@ -180,6 +196,13 @@ func (c *core) finalizeMessage(msg *Message) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	step, err := MessageStep(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.guard.check(step); err != nil {
+		return nil, err
+	}
 	msg.Signature, err = c.backend.Sign(data)
 	if err != nil {
 		return nil, err
@@ -192,11 +215,18 @@ func (c *core) broadcast(ctx context.Context, msg *Message) {
 	logger := c.logger.New("step", c.step)
 
 	payload, err := c.finalizeMessage(msg)
+	if errors.Is(err, errSafetyMargin) {
+		logger.Debug("Not signing yet", "msg", msg, "err", err)
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to finalize message", "msg", msg, "err", err)
 		return
 	}
 
+	// The message must hit the write-ahead log before anyone can see it.
+	c.wal.writeMessage(c.Round(), payload)
+
 	// Broadcast payload
 	logger.Debug("broadcasting", "msg", msg.String())
 	if err = c.backend.Broadcast(ctx, c.committeeSet().Committee(), payload); err != nil {
@@ -234,6 +264,18 @@ func (c *core) commit(round int64, messages *roundMessages) {
 
 	c.logger.Info("commit a block", "hash", proposal.ProposalBlock.Header().Hash())
 
+	if c.isAggregatedSeal(proposal.ProposalBlock.Number()) {
+		seal, signers, err := c.aggregateCommittedSeals(messages.CommitedSeals(proposal.ProposalBlock.Hash()))
+		if err != nil {
+			c.logger.Error("failed to aggregate the committed seals", "err", err)
+			return
+		}
+		if err := c.backend.CommitAggregated(proposal.ProposalBlock, round, seal, signers); err != nil {
+			c.logger.Error("failed to commit a block", "err", err)
+		}
+		return
+	}
+
 	committedSeals := make([][]byte, 0)
 	for _, v := range messages.CommitedSeals(proposal.ProposalBlock.Hash()) {
 		seal := make([]byte, types.BFTExtraSeal)
@@ -247,6 +289,46 @@ func (c *core) commit(round int64, messages *roundMessages) {
 	}
 }
 
+// isAggregatedSeal returns whether the committed seals of the given height are
+// BLS signatures to be aggregated. They are not as long as a member of the
+// committee has no BLS key, it could not seal blocks otherwise.
+func (c *core) isAggregatedSeal(height *big.Int) bool {
+	return c.aggregatedSealBlock != nil && c.aggregatedSealBlock.Cmp(height) <= 0 &&
+		c.committeeSet().Committee().HasBLSKeys()
+}
+
+// aggregateCommittedSeals aggregates the BLS committed seals of the given
+// messages, and returns the bitmap of their senders in the committee.
+func (c *core) aggregateCommittedSeals(messages []Message) ([]byte, []byte, error) {
+	members := c.committeeSet().Committee()
+	indexes := make(map[common.Address]int, len(members))
+	for i, member := range members {
+		indexes[member.Address] = i
+	}
+
+	var (
+		seals   []*bls.Signature
+		signers []int
+	)
+	for _, m := range messages {
+		index, ok := indexes[m.Address]
+		if !ok {
+			return nil, nil, consensus.ErrCommitteeMemberNotFound
+		}
+		seal, err := bls.SignatureFromBytes(m.CommittedSeal)
+		if err != nil {
+			return nil, nil, err
+		}
+		seals = append(seals, seal)
+		signers = append(signers, index)
+	}
+	aggregate, err := bls.AggregateSignatures(seals)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aggregate.Bytes(), types.SealSignersBitmap(len(members), signers), nil
+}
+
 // Metric collecton of round change and height change.
 func (c *core) measureHeightRoundMetrics(round int64) {
 	if round == 0 {
@@ -264,6 +346,9 @@ func (c *core) startRound(ctx context.Context, round int64) {
 	c.measureHeightRoundMetrics(round)
 	// Set initial FSM state
 	c.setInitialState(round)
+	if round > 0 {
+		c.wal.writeRound(round)
+	}
 	// c.setStep(propose) will process the pending unmined blocks sent by the backed.Seal() and set c.lastestPendingRequest
 	c.setStep(propose)
 	c.logger.Debug("Starting new Round", "Height", c.Height(), "Round", round)
@@ -303,30 +388,14 @@ func (c *core) setInitialState(r int64) {
 		c.setHeight(new(big.Int).Add(lastBlockMined.Number(), common.Big1))
 
 		lastHeader := lastBlockMined.Header()
-		var committeeSet committee
-		var err error
-		var lastProposer common.Address
-		switch c.proposerPolicy {
-		case config.RoundRobin:
-			if !lastHeader.IsGenesis() {
-				var err error
-				lastProposer, err = types.Ecrecover(lastHeader)
-				if err != nil {
-					panic(fmt.Sprintf("unable to recover proposer address from header %q: %v", lastHeader, err))
-				}
-			}
-			committeeSet, err = newRoundRobinSet(lastHeader.Committee, lastProposer)
-			if err != nil {
-				panic(fmt.Sprintf("failed to construct committee %v", err))
-			}
-		case config.WeightedRandomSampling:
-			committeeSet = newWeightedRandomSamplingCommittee(lastBlockMined, c.autonityContract, c.backend.BlockChain())
-		default:
-			panic(fmt.Sprintf("unrecognised proposer policy %q", c.proposerPolicy))
+		committeeSet, err := newCommittee(c, c.proposerPolicy, lastBlockMined)
+		if err != nil {
+			panic(fmt.Sprintf("failed to construct committee %v", err))
 		}
 
 		c.lastHeader = lastHeader
 		c.setCommitteeSet(committeeSet)
+		c.wal.setHeight(c.Height())
 		c.lockedRound = -1
 		c.lockedValue = nil
 		c.validRound = -1
@@ -344,6 +413,9 @@ func (c *core) setInitialState(r int64) {
 	c.sentPrecommit = false
 	c.setValidRoundAndValue = false
 	c.setRound(r)
+	if c.trace != nil {
+		c.trace.proposer(c.Height(), r, c.committeeSet().GetProposer(r).Address)
+	}
 }
 
 func (c *core) acceptVote(roundMsgs *roundMessages, step Step, hash common.Hash, msg Message) {
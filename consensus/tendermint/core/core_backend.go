@@ -26,6 +26,11 @@ type Backend interface {
 	// The delivered proposal will be put into blockchain.
 	Commit(proposalBlock *types.Block, round int64, seals [][]byte) error
 
+	// CommitAggregated delivers an approved proposal along with the aggregate
+	// of its BLS committed seals and the bitmap of their signers in the
+	// committee. The delivered proposal will be put into blockchain.
+	CommitAggregated(proposalBlock *types.Block, round int64, seal []byte, signers []byte) error
+
 	GetContractABI() string
 
 	// Gossip sends a message to all validators (exclude self)
@@ -38,14 +43,25 @@ type Backend interface {
 	// LastCommittedProposal retrieves latest committed proposal and the address of proposer
 	LastCommittedProposal() (*types.Block, common.Address)
 
+	// PenalizePeer lowers the score of a committee member which sent abusive
+	// messages, the backend disconnects the peers whose score gets too low.
+	PenalizePeer(address common.Address, penalty int)
+
 	Post(ev interface{})
 
+	// ReportEvidence persists the evidence of a committee member misbehaviour
+	// and gossips it to the network.
+	ReportEvidence(ctx context.Context, evidence *Evidence)
+
 	// Setter for proposed block hash
 	SetProposedBlockHash(hash common.Hash)
 
 	// Sign signs input data with the backend's private key
 	Sign([]byte) ([]byte, error)
 
+	// SignBLS signs input data with the backend's BLS key
+	SignBLS([]byte) ([]byte, error)
+
 	Subscribe(types ...interface{}) *event.TypeMuxSubscription
 
 	SyncPeer(address common.Address)
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/common/hexutil"
+	"github.com/clearmatics/autonity/consensus/tendermint/crypto"
+	"github.com/clearmatics/autonity/core/types"
+)
+
+var (
+	// errEquivocation is returned when a committee member sent two different
+	// messages of the same kind for the same height and round.
+	errEquivocation = errors.New("equivocation detected")
+	// errInvalidEvidence is returned when an evidence does not prove any misbehaviour.
+	errInvalidEvidence = errors.New("invalid evidence")
+)
+
+// Evidence is a self-contained proof that a committee member signed two
+// different messages of the same kind for the same height and round: two
+// proposals for different blocks, or two prevotes or precommits for
+// different values. Both messages are kept as they were signed, so anyone
+// knowing the committee of the height can verify it.
+type Evidence struct {
+	Offender common.Address `json:"offender"`
+	Height   hexutil.Uint64 `json:"height"`
+	Round    hexutil.Uint64 `json:"round"`
+	Code     hexutil.Uint64 `json:"code"`
+	First    hexutil.Bytes  `json:"first"`
+	Second   hexutil.Bytes  `json:"second"`
+}
+
+// NewEvidence builds the evidence of the two given conflicting messages. The
+// payloads are sorted so that the same pair always gives the same evidence.
+func NewEvidence(a, b *Message) (*Evidence, error) {
+	height, err := a.Height()
+	if err != nil {
+		return nil, err
+	}
+	round, err := a.Round()
+	if err != nil {
+		return nil, err
+	}
+	first, second := a.Payload(), b.Payload()
+	if bytes.Compare(first, second) > 0 {
+		first, second = second, first
+	}
+	return &Evidence{
+		Offender: a.Address,
+		Height:   hexutil.Uint64(height.Uint64()),
+		Round:    hexutil.Uint64(round),
+		Code:     hexutil.Uint64(a.Code),
+		First:    common.CopyBytes(first),
+		Second:   common.CopyBytes(second),
+	}, nil
+}
+
+// Hash returns the hash identifying the evidence.
+func (e *Evidence) Hash() common.Hash {
+	return types.RLPHash(e)
+}
+
+// Verify checks that the evidence proves a misbehaviour of a member of the
+// committee elected by parent, which must be the header preceding the height
+// of the evidence.
+func (e *Evidence) Verify(parent *types.Header) error {
+	if parent.Number.Uint64()+1 != uint64(e.Height) {
+		return fmt.Errorf("%w: height %d does not follow parent %v", errInvalidEvidence, e.Height, parent.Number)
+	}
+	var values [2]common.Hash
+	for i, payload := range [][]byte{e.First, e.Second} {
+		msg := new(Message)
+		if err := msg.FromPayload(payload); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidEvidence, err)
+		}
+		height, _ := msg.Height()
+		round, _ := msg.Round()
+		if msg.Code != uint64(e.Code) || msg.Address != e.Offender || height.Uint64() != uint64(e.Height) || round != int64(e.Round) {
+			return fmt.Errorf("%w: message %d does not match the evidence", errInvalidEvidence, i)
+		}
+		if _, err := msg.Validate(crypto.CheckValidatorSignature, parent); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidEvidence, err)
+		}
+		value, err := msgValue(msg)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidEvidence, err)
+		}
+		values[i] = value
+	}
+	if values[0] == values[1] {
+		return fmt.Errorf("%w: messages are not conflicting", errInvalidEvidence)
+	}
+	return nil
+}
+
+// msgValue returns the block hash a message proposes or votes for.
+func msgValue(msg *Message) (common.Hash, error) {
+	switch msg.Code {
+	case msgProposal:
+		var proposal Proposal
+		if err := msg.Decode(&proposal); err != nil {
+			return common.Hash{}, errFailedDecodeProposal
+		}
+		if proposal.ProposalBlock == nil {
+			return common.Hash{}, errFailedDecodeProposal
+		}
+		return proposal.ProposalBlock.Hash(), nil
+	case msgPrevote, msgPrecommit:
+		var vote Vote
+		if err := msg.Decode(&vote); err != nil {
+			return common.Hash{}, errFailedDecodeVote
+		}
+		return vote.ProposedBlockHash, nil
+	default:
+		return common.Hash{}, errInvalidMessage
+	}
+}
+
+// checkEquivocation looks for a message of the sender of msg conflicting with
+// it at the same round. If there is one, the evidence is handed to the backend
+// and errEquivocation is returned so that msg is neither processed nor gossiped.
+func (c *core) checkEquivocation(ctx context.Context, msg *Message) error {
+	// Decoding caches the content of the message, failures are left to the
+	// message handlers to report.
+	var err error
+	switch msg.Code {
+	case msgProposal:
+		err = msg.Decode(new(Proposal))
+	case msgPrevote, msgPrecommit:
+		err = msg.Decode(new(Vote))
+	default:
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+	round, _ := msg.Round()
+	roundMsgs := c.messages.get(round)
+	if roundMsgs == nil {
+		return nil
+	}
+
+	var previous *Message
+	switch msg.Code {
+	case msgProposal:
+		if p := roundMsgs.ProposalMessage(); p != nil && p.Address == msg.Address {
+			previous = p
+		}
+	case msgPrevote:
+		previous = roundMsgs.prevotes.GetMessage(msg.Address)
+	case msgPrecommit:
+		previous = roundMsgs.precommits.GetMessage(msg.Address)
+	}
+	if previous == nil {
+		return nil
+	}
+	previousValue, err := msgValue(previous)
+	if err != nil {
+		return nil
+	}
+	value, err := msgValue(msg)
+	if err != nil || value == previousValue {
+		return nil
+	}
+
+	evidence, err := NewEvidence(previous, msg)
+	if err != nil {
+		return err
+	}
+	c.logger.Warn("Equivocation detected", "offender", msg.Address, "height", evidence.Height, "round", round, "code", msg.Code, "evidence", evidence.Hash())
+	c.backend.ReportEvidence(ctx, evidence)
+	return errEquivocation
+}
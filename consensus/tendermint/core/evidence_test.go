@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+func TestEquivocation(t *testing.T) {
+	const committeeSize = 4
+	prevHeight := big.NewInt(10)
+	height := big.NewInt(11)
+
+	committeeSet, keys := prepareCommittee(t, committeeSize)
+	members := committeeSet.Committee()
+	prevBlock := generateBlock(prevHeight)
+	setCommitteeAndSealOnBlock(t, prevBlock, committeeSet, keys, committeeSize-1)
+	offender := members[1]
+
+	// newCore returns a core of members[2] at the step s of round 0, the
+	// evidence it reports are sent to the returned channel.
+	newCore := func(t *testing.T, ctrl *gomock.Controller, s Step) (*core, chan *Evidence) {
+		reported := make(chan *Evidence, 1)
+		backendMock := NewMockBackend(ctrl)
+		backendMock.EXPECT().Address().Return(members[2].Address)
+		backendMock.EXPECT().LastCommittedProposal().Return(prevBlock, members[committeeSize-1].Address).AnyTimes()
+		backendMock.EXPECT().ReportEvidence(gomock.Any(), gomock.Any()).Do(func(_ context.Context, e *Evidence) {
+			reported <- e
+		}).AnyTimes()
+		c := New(backendMock, config.RoundRobinConfig(), nil)
+		c.setInitialState(0)
+		c.setStep(s)
+		return c, reported
+	}
+
+	// received returns msg as it would be decoded from the network.
+	received := func(t *testing.T, msg *Message) *Message {
+		decoded := new(Message)
+		require.NoError(t, decoded.FromPayload(msg.Payload()))
+		return decoded
+	}
+
+	vote := func(t *testing.T, code uint64, hash common.Hash) *Message {
+		msg, _, _ := prepareVote(t, code, 0, height, hash, offender.Address, keys[offender.Address])
+		return received(t, msg)
+	}
+
+	for name, code := range map[string]uint64{"prevotes": msgPrevote, "precommits": msgPrecommit} {
+		s := prevote
+		if code == msgPrecommit {
+			s = precommit
+		}
+
+		t.Run("conflicting "+name+" are reported", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, reported := newCore(t, ctrl, s)
+
+			first, second := vote(t, code, generateBlock(height).Hash()), vote(t, code, common.Hash{})
+			require.NoError(t, c.handleCheckedMsg(context.Background(), first))
+			assert.Equal(t, errEquivocation, c.handleCheckedMsg(context.Background(), second))
+
+			require.Len(t, reported, 1)
+			evidence := <-reported
+			assert.Equal(t, offender.Address, evidence.Offender)
+			assert.Equal(t, height.Uint64(), uint64(evidence.Height))
+			assert.Equal(t, code, uint64(evidence.Code))
+			assert.NoError(t, evidence.Verify(prevBlock.Header()))
+
+			// The same pair of messages must always give the same evidence.
+			swapped, err := NewEvidence(second, first)
+			require.NoError(t, err)
+			assert.Equal(t, evidence.Hash(), swapped.Hash())
+		})
+
+		t.Run("duplicated "+name+" are not reported", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, reported := newCore(t, ctrl, s)
+
+			hash := generateBlock(height).Hash()
+			require.NoError(t, c.handleCheckedMsg(context.Background(), vote(t, code, hash)))
+			assert.NoError(t, c.checkEquivocation(context.Background(), vote(t, code, hash)))
+			assert.Empty(t, reported)
+		})
+	}
+
+	t.Run("conflicting proposals are reported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		c, reported := newCore(t, ctrl, propose)
+		proposer := members[0]
+		require.Equal(t, proposer.Address, c.committeeSet().GetProposer(0).Address)
+
+		first, _, _ := prepareProposal(t, 0, height, -1, generateBlock(height), proposer.Address, keys[proposer.Address])
+		first = received(t, first)
+		var proposal Proposal
+		require.NoError(t, first.Decode(&proposal))
+		c.curRoundMessages.SetProposal(&proposal, first, true)
+
+		second, _, _ := prepareProposal(t, 0, height, -1, generateBlock(height), proposer.Address, keys[proposer.Address])
+		assert.Equal(t, errEquivocation, c.handleCheckedMsg(context.Background(), received(t, second)))
+
+		require.Len(t, reported, 1)
+		evidence := <-reported
+		assert.Equal(t, proposer.Address, evidence.Offender)
+		assert.Equal(t, msgProposal, uint64(evidence.Code))
+		assert.NoError(t, evidence.Verify(prevBlock.Header()))
+	})
+
+	t.Run("invalid evidence is rejected", func(t *testing.T) {
+		first, second := vote(t, msgPrevote, generateBlock(height).Hash()), vote(t, msgPrevote, common.Hash{})
+		evidence, err := NewEvidence(first, second)
+		require.NoError(t, err)
+		require.NoError(t, evidence.Verify(prevBlock.Header()))
+
+		// Evidence must survive its encoding.
+		enc, err := rlp.EncodeToBytes(evidence)
+		require.NoError(t, err)
+		decoded := new(Evidence)
+		require.NoError(t, rlp.DecodeBytes(enc, decoded))
+		assert.NoError(t, decoded.Verify(prevBlock.Header()))
+
+		same, err := NewEvidence(first, first)
+		require.NoError(t, err)
+		assert.Error(t, same.Verify(prevBlock.Header()))
+
+		framed := *evidence
+		framed.Offender = members[0].Address
+		assert.Error(t, framed.Verify(prevBlock.Header()))
+
+		// A message signed by someone else than its sender.
+		forged, _, _ := prepareVote(t, msgPrevote, 0, height, common.Hash{}, offender.Address, keys[members[0].Address])
+		forgedEvidence, err := NewEvidence(first, received(t, forged))
+		require.NoError(t, err)
+		assert.Error(t, forgedEvidence.Verify(prevBlock.Header()))
+
+		assert.Error(t, evidence.Verify(generateBlock(height).Header()))
+	})
+}
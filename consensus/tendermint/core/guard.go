@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+var (
+	// errDoubleSign is returned when asked to sign a value for the step a
+	// different value was last signed for.
+	errDoubleSign = errors.New("refusing to double sign")
+	// errSignedAhead is returned when asked to sign for a step before the
+	// last one signed for.
+	errSignedAhead = errors.New("refusing to sign for a step before the last signed")
+	// errSafetyMargin is returned when asked to sign before the safety margin
+	// past the last signed height was seen committed.
+	errSafetyMargin = errors.New("waiting for the safety margin past the last signed height")
+)
+
+// storedStep is the persisted form of a SignedStep, rlp not supporting signed
+// integers.
+type storedStep struct {
+	Height uint64
+	Round  uint64
+	Step   uint64
+	Value  common.Hash
+}
+
+// signGuard persists the last step this node signed for, before it signs it,
+// and refuses to sign for a step before it or a different value for it. Unlike
+// the write-ahead log, it survives the heights and the guard of a restored
+// node can only be behind, which the safety margin makes up for: after a
+// restart nothing is signed until that many blocks are committed past the
+// last signed height. A nil guard is valid and allows everything.
+type signGuard struct {
+	db     ethdb.Database
+	last   *SignedStep
+	join   uint64 // first height the node may sign for again
+	logger log.Logger
+}
+
+func newSignGuard(db ethdb.Database, margin uint64, logger log.Logger) *signGuard {
+	if db == nil {
+		return nil
+	}
+	g := &signGuard{db: db, logger: logger}
+	if enc := rawdb.ReadConsensusLastSigned(db); enc != nil {
+		var stored storedStep
+		if err := rlp.DecodeBytes(enc, &stored); err != nil {
+			// Better not sign at all than sign twice, the operator must
+			// look into it.
+			logger.Error("Invalid last signed consensus step, signing nothing", "err", err)
+			g.join = ^uint64(0)
+			return g
+		}
+		g.last = &SignedStep{Height: stored.Height, Round: int64(stored.Round), Step: stored.Step, Value: stored.Value}
+		if margin > 0 {
+			g.join = stored.Height + margin + 1
+			logger.Info("Waiting for blocks past the last signed height before signing", "last", stored.Height, "margin", margin, "join", g.join)
+		}
+	}
+	return g
+}
+
+// check records step as the last signed one, unless signing it could
+// conflict with what was signed before.
+func (g *signGuard) check(step *SignedStep) error {
+	if g == nil {
+		return nil
+	}
+	if step.Height < g.join {
+		return fmt.Errorf("%w: height %d, joining at %d", errSafetyMargin, step.Height, g.join)
+	}
+	if last := g.last; last != nil {
+		switch compareSteps(step, last) {
+		case -1:
+			return fmt.Errorf("%w: height %d round %d step %d, signed height %d round %d step %d",
+				errSignedAhead, step.Height, step.Round, step.Step, last.Height, last.Round, last.Step)
+		case 0:
+			if step.Value != last.Value {
+				g.logger.Warn("Refused to double sign", "height", step.Height, "round", step.Round, "step", step.Step, "signed", last.Value, "value", step.Value)
+				return fmt.Errorf("%w: height %d round %d step %d", errDoubleSign, step.Height, step.Round, step.Step)
+			}
+			return nil
+		}
+	}
+	enc, err := rlp.EncodeToBytes(&storedStep{Height: step.Height, Round: uint64(step.Round), Step: step.Step, Value: step.Value})
+	if err != nil {
+		return err
+	}
+	rawdb.WriteConsensusLastSigned(g.db, enc)
+	g.last = step
+	return nil
+}
+
+// compareSteps orders the steps by height, round and step.
+func compareSteps(a, b *SignedStep) int {
+	switch {
+	case a.Height != b.Height:
+		return compareUint64(a.Height, b.Height)
+	case a.Round != b.Round:
+		return compareUint64(uint64(a.Round), uint64(b.Round))
+	default:
+		return compareUint64(a.Step, b.Step)
+	}
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignGuard(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	guard := newSignGuard(db, 0, log.New())
+	value, other := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	require.NoError(t, guard.check(&SignedStep{Height: 10, Round: 1, Step: msgPrevote, Value: value}))
+	// Signing the same value again is harmless.
+	require.NoError(t, guard.check(&SignedStep{Height: 10, Round: 1, Step: msgPrevote, Value: value}))
+
+	err := guard.check(&SignedStep{Height: 10, Round: 1, Step: msgPrevote, Value: other})
+	require.True(t, errors.Is(err, errDoubleSign))
+	err = guard.check(&SignedStep{Height: 10, Round: 0, Step: msgPrecommit, Value: other})
+	require.True(t, errors.Is(err, errSignedAhead))
+	err = guard.check(&SignedStep{Height: 9, Round: 3, Step: msgPrecommit, Value: other})
+	require.True(t, errors.Is(err, errSignedAhead))
+
+	require.NoError(t, guard.check(&SignedStep{Height: 10, Round: 1, Step: msgPrecommit, Value: other}))
+
+	// The last signed step survives a restart.
+	guard = newSignGuard(db, 0, log.New())
+	err = guard.check(&SignedStep{Height: 10, Round: 1, Step: msgPrecommit, Value: value})
+	require.True(t, errors.Is(err, errDoubleSign))
+	require.NoError(t, guard.check(&SignedStep{Height: 10, Round: 2, Step: msgProposal, Value: value}))
+}
+
+func TestSignGuardSafetyMargin(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	require.NoError(t, newSignGuard(db, 5, log.New()).check(&SignedStep{Height: 10, Step: msgPrevote}))
+
+	guard := newSignGuard(db, 5, log.New())
+	for h := uint64(10); h <= 15; h++ {
+		err := guard.check(&SignedStep{Height: h, Step: msgPrevote})
+		require.True(t, errors.Is(err, errSafetyMargin), "height %d", h)
+	}
+	require.NoError(t, guard.check(&SignedStep{Height: 16, Step: msgPrevote}))
+}
+
+func TestFinalizeMessageGuarded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := common.HexToAddress("0x01")
+	backendMock := NewMockBackend(ctrl)
+	backendMock.EXPECT().Sign(gomock.Any()).Return([]byte{0x1}, nil)
+	c := &core{
+		backend: backendMock,
+		guard:   newSignGuard(rawdb.NewMemoryDatabase(), 0, log.New()),
+	}
+	vote := func(hash common.Hash) *Message {
+		encoded, err := Encode(&Vote{Round: 0, Height: common.Big1, ProposedBlockHash: hash})
+		require.NoError(t, err)
+		return &Message{Code: msgPrevote, Msg: encoded, Address: member}
+	}
+
+	_, err := c.finalizeMessage(vote(common.HexToHash("0x01")))
+	require.NoError(t, err)
+	_, err = c.finalizeMessage(vote(common.HexToHash("0x02")))
+	require.True(t, errors.Is(err, errDoubleSign))
+}
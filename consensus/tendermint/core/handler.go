@@ -64,6 +64,8 @@ func (c *core) Stop() {
 	<-c.stopped
 	<-c.stopped
 	<-c.stopped
+
+	c.trace.close()
 }
 
 func (c *core) subscribeEvents() {
@@ -115,8 +117,12 @@ eventLoop:
 }
 
 func (c *core) mainEventLoop(ctx context.Context) {
-	// Start a new round from last height + 1
-	c.startRound(ctx, 0)
+	// Start a new round from last height + 1, unless we stopped in the middle
+	// of it, in which case we resume from the write-ahead log.
+	c.trace.start()
+	if !c.replayWAL(ctx) {
+		c.startRound(ctx, 0)
+	}
 
 	go c.syncLoop(ctx)
 
@@ -130,11 +136,13 @@ eventLoop:
 			// A real ev arrived, process interesting content
 			switch e := ev.Data.(type) {
 			case events.MessageEvent:
+				c.trace.message(traceMessage, e.Payload)
 				msg := new(Message)
 				if err := msg.FromPayload(e.Payload); err != nil {
 					c.logger.Error("consensus message invalid payload", "err", err)
 					continue
 				}
+				msg.relay = e.Sender
 				if err := c.handleMsg(ctx, msg); err != nil {
 					c.logger.Debug("MessageEvent payload failed", "err", err)
 					continue
@@ -143,6 +151,7 @@ eventLoop:
 			case backlogEvent:
 				// No need to check signature for internal messages
 				c.logger.Debug("started handling backlogEvent")
+				c.trace.message(traceBacklog, e.msg.Payload())
 				if err := c.handleCheckedMsg(ctx, e.msg); err != nil {
 					c.logger.Debug("backlogEvent message handling failed", "err", err)
 					continue
@@ -151,6 +160,7 @@ eventLoop:
 
 			case backlogUncheckedEvent:
 				c.logger.Debug("started handling backlogUncheckedEvent")
+				c.trace.message(traceBacklogUnchecked, e.msg.Payload())
 				if err := c.handleMsg(ctx, e.msg); err != nil {
 					c.logger.Debug("backlogUncheckedEvent message failed", "err", err)
 					continue
@@ -165,6 +175,7 @@ eventLoop:
 				break eventLoop
 			}
 			if timeoutE, ok := ev.Data.(TimeoutEvent); ok {
+				c.trace.timeout(timeoutE)
 				switch timeoutE.step {
 				case msgProposal:
 					c.handleTimeoutPropose(ctx, timeoutE)
@@ -180,6 +191,7 @@ eventLoop:
 			}
 			switch ev.Data.(type) {
 			case events.CommitEvent:
+				c.trace.commitEvent()
 				c.handleCommit(ctx)
 			}
 		case <-ctx.Done():
@@ -309,6 +321,10 @@ func (c *core) handleCheckedMsg(ctx context.Context, msg *Message) error {
 		return err
 	}
 
+	if err := c.checkEquivocation(ctx, msg); err != nil {
+		return err
+	}
+
 	switch msg.Code {
 	case msgProposal:
 		logger.Debug("tendermint.MessageEvent: PROPOSAL")
@@ -47,8 +47,9 @@ type Message struct {
 	CommittedSeal []byte
 
 	power      uint64
-	decodedMsg ConsensusMsg // cached decoded Msg
-	payload    []byte       // rlp encoded Message
+	decodedMsg ConsensusMsg   // cached decoded Msg
+	payload    []byte         // rlp encoded Message
+	relay      common.Address // peer the message was received from, if any
 }
 
 // ==============================================
@@ -42,8 +42,8 @@ func (ms *messageSet) AddVote(blockHash common.Hash, msg Message) {
 	defer ms.messagesMu.Unlock()
 
 	// Check first if we already received a message from this pal.
+	// Conflicting votes are caught by checkEquivocation before reaching here.
 	if _, ok := ms.messages[msg.Address]; ok {
-		// TODO : double signing fault ! Accountability
 		return
 	}
 
@@ -70,6 +70,13 @@ func (ms *messageSet) GetMessages() []*Message {
 	return result
 }
 
+// GetMessage returns the vote received from the given address, or nil.
+func (ms *messageSet) GetMessage(addr common.Address) *Message {
+	ms.messagesMu.RLock()
+	defer ms.messagesMu.RUnlock()
+	return ms.messages[addr]
+}
+
 func (ms *messageSet) VotePower(h common.Hash) uint64 {
 	ms.messagesMu.RLock()
 	defer ms.messagesMu.RUnlock()
@@ -26,4 +26,7 @@ var (
 	tendermintProposeTimer      = metrics.NewRegisteredTimer("tendermint/timer/propose", nil)
 	tendermintPrevoteTimer      = metrics.NewRegisteredTimer("tendermint/timer/prevote", nil)
 	tendermintPrecommitTimer    = metrics.NewRegisteredTimer("tendermint/timer/precommit", nil)
+
+	backlogDroppedMeter          = metrics.NewRegisteredMeter("tendermint/backlog/dropped", nil)
+	backlogUncheckedDroppedMeter = metrics.NewRegisteredMeter("tendermint/backlog/unchecked/dropped", nil)
 )
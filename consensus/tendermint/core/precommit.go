@@ -23,6 +23,7 @@ import (
 
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto/bls"
 )
 
 func (c *core) sendPrecommit(ctx context.Context, isNil bool) {
@@ -60,9 +61,22 @@ func (c *core) sendPrecommit(ctx context.Context, isNil bool) {
 
 	// Create committed seal
 	seal := PrepareCommittedSeal(precommit.ProposedBlockHash, c.Round(), c.Height())
-	msg.CommittedSeal, err = c.backend.Sign(seal)
+	step, err := CommittedSealStep(seal)
+	if err == nil {
+		err = c.guard.check(step)
+	}
 	if err != nil {
-		c.logger.Error("core.sendPrecommit error while signing committed seal", "err", err)
+		// The precommit itself is refused the same way when broadcast.
+		logger.Debug("Not signing committed seal", "err", err)
+	} else {
+		if c.isAggregatedSeal(c.Height()) {
+			msg.CommittedSeal, err = c.backend.SignBLS(seal)
+		} else {
+			msg.CommittedSeal, err = c.backend.Sign(seal)
+		}
+		if err != nil {
+			c.logger.Error("core.sendPrecommit error while signing committed seal", "err", err)
+		}
 	}
 
 	c.sentPrecommit = true
@@ -137,6 +151,10 @@ func (c *core) handlePrecommit(ctx context.Context, msg *Message) error {
 func (c *core) verifyCommittedSeal(addressMsg common.Address, committedSealMsg []byte, proposedBlockHash common.Hash, round int64, height *big.Int) error {
 	committedSeal := PrepareCommittedSeal(proposedBlockHash, round, height)
 
+	if c.isAggregatedSeal(height) {
+		return c.verifyBLSCommittedSeal(addressMsg, committedSealMsg, committedSeal)
+	}
+
 	sealerAddress, err := types.GetSignatureAddress(committedSeal, committedSealMsg)
 	if err != nil {
 		c.logger.Error("Failed to get signer address", "err", err)
@@ -153,6 +171,32 @@ func (c *core) verifyCommittedSeal(addressMsg common.Address, committedSealMsg [
 	return nil
 }
 
+// verifyBLSCommittedSeal checks that the BLS committed seal was signed by the
+// sender with the BLS key it has in the committee.
+func (c *core) verifyBLSCommittedSeal(addressMsg common.Address, committedSealMsg []byte, committedSeal []byte) error {
+	_, member, err := c.committeeSet().GetByAddress(addressMsg)
+	if err != nil {
+		return err
+	}
+	if len(member.BLSKey) == 0 {
+		c.logger.Error("committee member without BLS key", "address", addressMsg)
+		return errInvalidSenderOfCommittedSeal
+	}
+	key, err := bls.PublicKeyFromBytes(member.BLSKey)
+	if err != nil {
+		return err
+	}
+	seal, err := bls.SignatureFromBytes(committedSealMsg)
+	if err != nil {
+		return err
+	}
+	if !key.Verify(committedSeal, seal) {
+		c.logger.Error("verify precommit BLS seal error", "address", addressMsg)
+		return errInvalidSenderOfCommittedSeal
+	}
+	return nil
+}
+
 func (c *core) handleCommit(ctx context.Context) {
 	c.logger.Debug("Received a final committed proposal", "step", c.step)
 	lastBlock, _ := c.backend.LastCommittedProposal()
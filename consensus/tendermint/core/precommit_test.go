@@ -1,7 +1,9 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"errors"
 	"math/big"
 	"reflect"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
 	"github.com/clearmatics/autonity/crypto/secp256k1"
 	"github.com/stretchr/testify/require"
 
@@ -457,6 +460,74 @@ func TestVerifyPrecommitCommittedSeal(t *testing.T) {
 	})
 }
 
+func TestVerifyPrecommitBLSCommittedSeal(t *testing.T) {
+	blsKeys := make([]*bls.SecretKey, 2)
+	members := make(types.Committee, 2)
+	for i := range members {
+		key, err := bls.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		blsKeys[i] = key
+		members[i] = types.CommitteeMember{
+			Address:     common.BytesToAddress([]byte{byte(i + 1)}),
+			VotingPower: big.NewInt(1),
+			BLSKey:      key.PublicKey().Bytes(),
+		}
+	}
+	committeeSet, err := newRoundRobinSet(members, members[0].Address)
+	if err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	c := &core{
+		logger:              log.New("backend", "test", "id", 0),
+		committee:           committeeSet,
+		aggregatedSealBlock: big.NewInt(10),
+	}
+	proposalHash := common.HexToHash("0x0123456789")
+	seal := PrepareCommittedSeal(proposalHash, 1, big.NewInt(13))
+
+	t.Run("valid BLS seal given, no error returned", func(t *testing.T) {
+		sig := blsKeys[0].Sign(seal).Bytes()
+		if err := c.verifyCommittedSeal(members[0].Address, sig, proposalHash, 1, big.NewInt(13)); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("BLS seal of another member given, error returned", func(t *testing.T) {
+		sig := blsKeys[1].Sign(seal).Bytes()
+		err := c.verifyCommittedSeal(members[0].Address, sig, proposalHash, 1, big.NewInt(13))
+		if err != errInvalidSenderOfCommittedSeal {
+			t.Fatalf("Expected %v, got %v", errInvalidSenderOfCommittedSeal, err)
+		}
+	})
+
+	t.Run("BLS seals aggregated in committee order", func(t *testing.T) {
+		messages := []Message{
+			{Address: members[1].Address, CommittedSeal: blsKeys[1].Sign(seal).Bytes()},
+			{Address: members[0].Address, CommittedSeal: blsKeys[0].Sign(seal).Bytes()},
+		}
+		aggregated, signers, err := c.aggregateCommittedSeals(messages)
+		if err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if !bytes.Equal(signers, []byte{0x03}) {
+			t.Fatalf("Expected signers 0x03, got %x", signers)
+		}
+		sig, err := bls.SignatureFromBytes(aggregated)
+		if err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		key, err := bls.AggregatePublicKeys([]*bls.PublicKey{blsKeys[0].PublicKey(), blsKeys[1].PublicKey()})
+		if err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if !key.Verify(seal, sig) {
+			t.Fatal("Expected a valid aggregated seal")
+		}
+	})
+}
+
 func TestHandleCommit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -114,11 +114,13 @@ func (c *core) handlePrevote(ctx context.Context, msg *Message) error {
 			if c.step == prevote {
 				c.lockedValue = c.curRoundMessages.Proposal().ProposalBlock
 				c.lockedRound = c.Round()
+				c.wal.writeLock(c.lockedRound, c.lockedValue)
 				c.sendPrecommit(ctx, false)
 				c.setStep(precommit)
 			}
 			c.validValue = c.curRoundMessages.Proposal().ProposalBlock
 			c.validRound = c.Round()
+			c.wal.writeValid(c.validRound, c.validValue)
 			c.setValidRoundAndValue = true
 			// Line 44 in Algorithm 1 of The latest gossip on BFT consensus
 		} else if c.step == prevote && c.curRoundMessages.PrevotesPower(common.Hash{}) >= c.committeeSet().Quorum() {
@@ -68,10 +68,10 @@ func (c *core) handleProposal(ctx context.Context, msg *Message) error {
 
 			roundMsgs := c.messages.getOrCreate(proposal.Round)
 
-			// if we already have a proposal then it must be different than the current one
-			// it can't happen unless someone's byzantine.
+			// if we already have a proposal then it is the same as the current one,
+			// a different one would have been caught by checkEquivocation.
 			if roundMsgs.proposal != nil {
-				return err // do not gossip, TODO: accountability
+				return err // do not gossip
 			}
 
 			if !c.isProposerMsg(proposal.Round, msg.Address) {
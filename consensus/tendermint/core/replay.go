@@ -0,0 +1,509 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	ethcore "github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto/bls"
+	"github.com/clearmatics/autonity/event"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+const (
+	// tracedProposerPolicy selects the proposers recorded in the trace, the
+	// proposer policy of the node may need the chain state to elect them.
+	tracedProposerPolicy = config.ProposerPolicy(math.MaxUint64)
+	// replayStepTimeout bounds the time an input is replayed for, the state
+	// machine waits for a block to propose when the trace has none.
+	replayStepTimeout = time.Second
+)
+
+var errNoTraceNode = errors.New("consensus trace has no node description")
+
+// ReplayStep is the outcome of the replay of one input of a consensus trace.
+type ReplayStep struct {
+	Time    time.Time
+	Input   string // the input fed to the state machine
+	Skipped bool   // whether the input came before the first height of the trace
+
+	// the state machine state once the input is handled
+	Height      *big.Int
+	Round       int64
+	Step        string
+	LockedRound int64
+	ValidRound  int64
+
+	Recorded []string // the decisions the node took on the input
+	Replayed []string // the decisions the replayed state machine took
+}
+
+// Diverged returns whether the replayed state machine took other decisions
+// than the node which recorded the trace.
+func (s *ReplayStep) Diverged() bool {
+	return !s.Skipped && !reflect.DeepEqual(s.Recorded, s.Replayed)
+}
+
+// Replayer feeds the inputs of a consensus trace, one at a time, into a state
+// machine wired to a backend answering from the trace, and compares its
+// decisions with the recorded ones.
+type Replayer struct {
+	stream  *rlp.Stream
+	next    *traceEntry
+	core    *core
+	backend *replayBackend
+	started bool
+}
+
+// NewReplayer creates a replayer of the consensus trace read from r.
+func NewReplayer(r io.Reader) *Replayer {
+	return &Replayer{
+		stream: rlp.NewStream(r, 0),
+		backend: &replayBackend{
+			mux:       event.NewTypeMuxSilent(log.Root()),
+			proposers: make(map[uint64]map[int64]common.Address),
+		},
+	}
+}
+
+// Next replays the next input of the trace, it returns io.EOF once the trace
+// is entirely replayed.
+func (r *Replayer) Next() (*ReplayStep, error) {
+	input, outputs, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if r.core == nil {
+		return nil, errNoTraceNode
+	}
+	step := &ReplayStep{Time: time.Unix(0, int64(input.Time)), Input: describeInput(input)}
+	if err := r.backend.load(r.core, outputs, step); err != nil {
+		return nil, err
+	}
+	r.backend.decisions = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), replayStepTimeout)
+	defer cancel()
+	c := r.core
+	switch input.Kind {
+	case traceStart:
+		head := r.backend.peekHead()
+		if head == nil {
+			return nil, fmt.Errorf("consensus trace start without a head block")
+		}
+		height := new(big.Int).Add(head.Number, common.Big1)
+		// A node restarted in the middle of a height resumed it from its
+		// write-ahead log.
+		if entries := r.backend.resumed; entries == nil || !c.resumeWAL(ctx, height, entries) {
+			c.setHeight(height)
+			c.startRound(ctx, 0)
+		}
+		r.started = true
+	case traceCommitEvent:
+		if !r.started {
+			head := r.backend.peekHead()
+			if head == nil {
+				step.Skipped = true
+				break
+			}
+			// The trace starts with a rotated file, the previous height is
+			// over and the state machine resumes at the next one.
+			c.setHeight(new(big.Int).Set(head.Number))
+			r.started = true
+		}
+		c.handleCommit(ctx)
+	case traceMessage, traceBacklog, traceBacklogUnchecked:
+		if !r.started {
+			step.Skipped = true
+			break
+		}
+		msg := new(Message)
+		if err := msg.FromPayload(input.Data); err != nil {
+			break
+		}
+		// The backlog messages were validated when received, they are
+		// validated again to restore their power.
+		_ = c.handleMsg(ctx, msg)
+	case traceTimeout:
+		if !r.started {
+			step.Skipped = true
+			break
+		}
+		var timeout traceTimeoutData
+		if err := rlp.DecodeBytes(input.Data, &timeout); err != nil {
+			return nil, err
+		}
+		e := TimeoutEvent{roundWhenCalled: int64(timeout.Round), heightWhenCalled: timeout.Height, step: timeout.Step}
+		switch timeout.Step {
+		case msgProposal:
+			c.handleTimeoutPropose(ctx, e)
+		case msgPrevote:
+			c.handleTimeoutPrevote(ctx, e)
+		case msgPrecommit:
+			c.handleTimeoutPrecommit(ctx, e)
+		}
+	}
+
+	step.Replayed = r.backend.decisions
+	if r.started {
+		step.Height = c.Height()
+		step.Round = c.Round()
+		step.Step = c.step.String()
+		step.LockedRound = c.lockedRound
+		step.ValidRound = c.validRound
+	}
+	return step, nil
+}
+
+// read returns the next input of the trace and the entries recorded while it
+// was handled.
+func (r *Replayer) read() (*traceEntry, []*traceEntry, error) {
+	var (
+		input   *traceEntry
+		outputs []*traceEntry
+	)
+	for {
+		entry := r.next
+		r.next = nil
+		if entry == nil {
+			entry = new(traceEntry)
+			if err := r.stream.Decode(entry); err == io.EOF && input != nil {
+				return input, outputs, nil
+			} else if err != nil {
+				return nil, nil, err
+			}
+		}
+		switch {
+		case entry.Kind == traceNode:
+			if err := r.setNode(entry); err != nil {
+				return nil, nil, err
+			}
+		case entry.Kind < traceHead && input == nil:
+			input = entry
+		case entry.Kind < traceHead:
+			r.next = entry
+			return input, outputs, nil
+		default:
+			// The decisions recorded before the first input of a rotated
+			// file follow from an input of the previous one.
+			if input != nil {
+				outputs = append(outputs, entry)
+			}
+		}
+	}
+}
+
+// setNode creates the state machine of the node described by entry, the
+// trace of a same node can be described several times.
+func (r *Replayer) setNode(entry *traceEntry) error {
+	var node traceNodeData
+	if err := rlp.DecodeBytes(entry.Data, &node); err != nil {
+		return err
+	}
+	if r.core != nil {
+		return nil
+	}
+	cfg := &config.Config{ProposerPolicy: tracedProposerPolicy}
+	if node.AggregatedSeal {
+		cfg.AggregatedSealBlock = new(big.Int).SetUint64(node.AggregatedSealBlock)
+	}
+	r.backend.address = node.Address
+	r.backend.policy = config.ProposerPolicy(node.ProposerPolicy)
+	r.core = New(r.backend, cfg, nil)
+	return nil
+}
+
+// describeInput returns a human readable description of a trace input.
+func describeInput(entry *traceEntry) string {
+	switch entry.Kind {
+	case traceStart:
+		return "start"
+	case traceMessage:
+		return "message " + describeMessage(entry.Data, true)
+	case traceBacklog:
+		return "backlog message " + describeMessage(entry.Data, true)
+	case traceBacklogUnchecked:
+		return "unchecked backlog message " + describeMessage(entry.Data, true)
+	case traceTimeout:
+		var timeout traceTimeoutData
+		if err := rlp.DecodeBytes(entry.Data, &timeout); err != nil {
+			return "invalid timeout"
+		}
+		return fmt.Sprintf("timeout %s height=%v round=%d", Step(timeout.Step), timeout.Height, timeout.Round)
+	case traceCommitEvent:
+		return "block committed"
+	}
+	return fmt.Sprintf("unknown input %d", entry.Kind)
+}
+
+// describeMessage returns a human readable description of a message payload.
+func describeMessage(payload []byte, sender bool) string {
+	msg := new(Message)
+	if err := msg.FromPayload(payload); err != nil {
+		return "invalid payload"
+	}
+	var description string
+	switch msg.Code {
+	case msgProposal:
+		var proposal Proposal
+		if err := msg.Decode(&proposal); err != nil {
+			return "invalid proposal"
+		}
+		description = fmt.Sprintf("proposal height=%v round=%d validRound=%d block=%v", proposal.Height, proposal.Round, proposal.ValidRound, proposal.ProposalBlock.Hash())
+	default:
+		var vote Vote
+		if err := msg.Decode(&vote); err != nil {
+			return "invalid vote"
+		}
+		description = fmt.Sprintf("%s height=%v round=%d block=%v", Step(msg.Code), vote.Height, vote.Round, vote.ProposedBlockHash)
+	}
+	if sender {
+		description += " from " + msg.Address.String()
+	}
+	return description
+}
+
+func describeCommit(hash common.Hash, round int64) string {
+	return fmt.Sprintf("commit block=%v round=%d", hash, round)
+}
+
+// tracedCommittee elects the proposers recorded in the trace, the other
+// rounds fall back to the proposer policy of the node, or to round robin if
+// it needs the chain state.
+type tracedCommittee struct {
+	committee
+	height    uint64
+	proposers map[uint64]map[int64]common.Address
+}
+
+func newTracedCommittee(c *core, lastBlock *types.Block) (committee, error) {
+	backend, ok := c.backend.(*replayBackend)
+	if !ok {
+		return nil, errors.New("traced proposers outside of a replay")
+	}
+	policy := backend.policy
+	if policy == config.WeightedRandomSampling {
+		policy = config.RoundRobin
+	}
+	base, err := newCommittee(c, policy, lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedCommittee{committee: base, height: lastBlock.NumberU64() + 1, proposers: backend.proposers}, nil
+}
+
+func (t *tracedCommittee) GetProposer(round int64) types.CommitteeMember {
+	if proposer, ok := t.proposers[t.height][round]; ok {
+		if _, member, err := t.GetByAddress(proposer); err == nil {
+			return member
+		}
+	}
+	return t.committee.GetProposer(round)
+}
+
+// replayBackend answers the state machine from the trace and collects its
+// decisions.
+type replayBackend struct {
+	address   common.Address
+	policy    config.ProposerPolicy
+	mux       *event.TypeMuxSilent
+	heads     []*types.Header
+	verified  []traceVerifyData
+	resumed   [][]byte // the write-ahead log entries the node resumed from
+	proposers map[uint64]map[int64]common.Address
+	decisions []string
+}
+
+// load prepares the answers to the state machine for the outputs of an input
+// and sets the recorded decisions of step.
+func (b *replayBackend) load(c *core, outputs []*traceEntry, step *ReplayStep) error {
+	b.verified, b.resumed = nil, nil
+	var heads []*types.Header
+	for _, entry := range outputs {
+		switch entry.Kind {
+		case traceHead:
+			head := new(types.Header)
+			if err := rlp.DecodeBytes(entry.Data, head); err != nil {
+				return err
+			}
+			heads = append(heads, head)
+		case traceCommittee:
+			var members []traceMember
+			if err := rlp.DecodeBytes(entry.Data, &members); err != nil {
+				return err
+			}
+			if len(heads) == 0 {
+				continue
+			}
+			committee := make(types.Committee, len(members))
+			for i, member := range members {
+				committee[i] = types.CommitteeMember{Address: member.Address, VotingPower: member.VotingPower, BLSKey: member.BLSKey}
+			}
+			heads[len(heads)-1].Committee = committee
+		case traceProposer:
+			var proposer traceProposerData
+			if err := rlp.DecodeBytes(entry.Data, &proposer); err != nil {
+				return err
+			}
+			height := proposer.Height.Uint64()
+			if b.proposers[height] == nil {
+				b.proposers[height] = make(map[int64]common.Address)
+			}
+			b.proposers[height][int64(proposer.Round)] = proposer.Proposer
+		case traceResume:
+			if err := rlp.DecodeBytes(entry.Data, &b.resumed); err != nil {
+				return err
+			}
+		case traceVerify:
+			var verified traceVerifyData
+			if err := rlp.DecodeBytes(entry.Data, &verified); err != nil {
+				return err
+			}
+			b.verified = append(b.verified, verified)
+		case traceBroadcast:
+			step.Recorded = append(step.Recorded, describeMessage(entry.Data, false))
+			// The blocks the node proposed are handed over again.
+			msg := new(Message)
+			var proposal Proposal
+			if msg.FromPayload(entry.Data) == nil && msg.Code == msgProposal && msg.Decode(&proposal) == nil {
+				c.pendingUnminedBlocksMu.Lock()
+				c.pendingUnminedBlocks[proposal.Height.Uint64()] = proposal.ProposalBlock
+				c.pendingUnminedBlocksMu.Unlock()
+			}
+		case traceCommit:
+			var commit traceCommitData
+			if err := rlp.DecodeBytes(entry.Data, &commit); err != nil {
+				return err
+			}
+			step.Recorded = append(step.Recorded, describeCommit(commit.Hash, int64(commit.Round)))
+		}
+	}
+	if len(heads) > 0 {
+		b.heads = heads
+	}
+	return nil
+}
+
+func (b *replayBackend) peekHead() *types.Header {
+	if len(b.heads) == 0 {
+		return nil
+	}
+	return b.heads[0]
+}
+
+func (b *replayBackend) Address() common.Address {
+	return b.address
+}
+
+func (b *replayBackend) AddSeal(block *types.Block) (*types.Block, error) {
+	return block, nil
+}
+
+func (b *replayBackend) AskSync(header *types.Header) {}
+
+func (b *replayBackend) Broadcast(ctx context.Context, committee types.Committee, payload []byte) error {
+	b.decisions = append(b.decisions, describeMessage(payload, false))
+	return nil
+}
+
+func (b *replayBackend) Commit(proposalBlock *types.Block, round int64, seals [][]byte) error {
+	b.decisions = append(b.decisions, describeCommit(proposalBlock.Hash(), round))
+	return nil
+}
+
+func (b *replayBackend) CommitAggregated(proposalBlock *types.Block, round int64, seal []byte, signers []byte) error {
+	b.decisions = append(b.decisions, describeCommit(proposalBlock.Hash(), round))
+	return nil
+}
+
+func (b *replayBackend) GetContractABI() string {
+	return ""
+}
+
+func (b *replayBackend) Gossip(ctx context.Context, committee types.Committee, payload []byte) {}
+
+func (b *replayBackend) KnownMsgHash() []common.Hash {
+	return nil
+}
+
+func (b *replayBackend) HandleUnhandledMsgs(ctx context.Context) {}
+
+// LastCommittedProposal returns the heads recorded for the input in turn, the
+// last one once they are all returned.
+func (b *replayBackend) LastCommittedProposal() (*types.Block, common.Address) {
+	head := b.peekHead()
+	if head == nil {
+		head = &types.Header{Number: new(big.Int)}
+	}
+	if len(b.heads) > 1 {
+		b.heads = b.heads[1:]
+	}
+	return types.NewBlockWithHeader(head), common.Address{}
+}
+
+func (b *replayBackend) PenalizePeer(address common.Address, penalty int) {}
+
+// Post drops the events of the state machine, the timeouts and the backlog
+// messages it handled are inputs of the trace.
+func (b *replayBackend) Post(ev interface{}) {}
+
+func (b *replayBackend) ReportEvidence(ctx context.Context, evidence *Evidence) {}
+
+func (b *replayBackend) SetProposedBlockHash(hash common.Hash) {}
+
+func (b *replayBackend) Sign(data []byte) ([]byte, error) {
+	return make([]byte, types.BFTExtraSeal), nil
+}
+
+func (b *replayBackend) SignBLS(data []byte) ([]byte, error) {
+	return make([]byte, bls.SignatureLength), nil
+}
+
+func (b *replayBackend) Subscribe(types ...interface{}) *event.TypeMuxSubscription {
+	return b.mux.Subscribe(types...)
+}
+
+func (b *replayBackend) SyncPeer(address common.Address) {}
+
+// VerifyProposal returns the recorded result of the verification of the
+// proposal, the proposals verified by the node are valid otherwise.
+func (b *replayBackend) VerifyProposal(proposal types.Block) (time.Duration, error) {
+	for i, verified := range b.verified {
+		if verified.Hash != proposal.Hash() {
+			continue
+		}
+		b.verified = append(b.verified[:i], b.verified[i+1:]...)
+		switch verified.Err {
+		case "":
+			return 0, nil
+		case consensus.ErrFutureBlock.Error():
+			return time.Duration(verified.Delay), consensus.ErrFutureBlock
+		default:
+			return 0, errors.New(verified.Err)
+		}
+	}
+	return 0, nil
+}
+
+func (b *replayBackend) WhiteList() []string {
+	return nil
+}
+
+func (b *replayBackend) BlockChain() *ethcore.BlockChain {
+	return nil
+}
+
+func (b *replayBackend) SetBlockchain(bc *ethcore.BlockChain) {}
+
+func (b *replayBackend) RemoveMessageFromLocalCache(payload []byte) {}
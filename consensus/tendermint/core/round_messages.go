@@ -55,6 +55,13 @@ func (s *messagesMap) getOrCreate(round int64) *roundMessages {
 	return state
 }
 
+// get returns the messages of the given round, or nil if none were received.
+func (s *messagesMap) get(round int64) *roundMessages {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.internal[round]
+}
+
 func (s *messagesMap) GetMessages() []*Message {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -153,6 +160,14 @@ func (s *roundMessages) Proposal() *Proposal {
 	return nil
 }
 
+// ProposalMessage returns the message which carried the proposal of the round, or nil.
+func (s *roundMessages) ProposalMessage() *Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.proposalMsg
+}
+
 func (s *roundMessages) isProposalVerified() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
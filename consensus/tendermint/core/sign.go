@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+// errInvalidCommittedSeal is returned when the data of a committed seal is not
+// the one prepared by PrepareCommittedSeal.
+var errInvalidCommittedSeal = errors.New("invalid committed seal data")
+
+// errInvalidSignPayload is returned when the payload of a consensus message to
+// sign is not the canonical encoding of an unsigned proposal or vote.
+var errInvalidSignPayload = errors.New("invalid consensus message payload")
+
+// SignedStep is what a validator commits to by signing a consensus message or
+// a committed seal: the value it proposes or votes for at a step of a round.
+// Signing two different values for the same step is a double sign.
+type SignedStep struct {
+	Height uint64
+	Round  int64
+	Step   uint64
+	Value  common.Hash
+}
+
+// MessageStep returns the step a validator signs for with the payload of a
+// consensus message, without its signature. The payload must encode the
+// message exactly as PayloadNoSig does, so that no two payloads commit to the
+// same step.
+func MessageStep(payload []byte) (*SignedStep, error) {
+	msg := new(Message)
+	if err := msg.FromPayload(payload); err != nil {
+		return nil, err
+	}
+	if len(msg.Signature) != 0 {
+		return nil, errInvalidSignPayload
+	}
+	if enc, err := msg.PayloadNoSig(); err != nil || !bytes.Equal(enc, payload) {
+		return nil, errInvalidSignPayload
+	}
+	if enc, err := rlp.EncodeToBytes(msg.decodedMsg); err != nil || !bytes.Equal(enc, msg.Msg) {
+		return nil, errInvalidSignPayload
+	}
+	height, err := msg.Height()
+	if err != nil {
+		return nil, err
+	}
+	round, err := msg.Round()
+	if err != nil {
+		return nil, err
+	}
+	value, err := msgValue(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedStep{Height: height.Uint64(), Round: round, Step: msg.Code, Value: value}, nil
+}
+
+// CommittedSealStep returns the step a validator signs for with a committed
+// seal, which is the precommit the seal is sent along with. The seal must be
+// the one PrepareCommittedSeal returns for a block past the genesis.
+func CommittedSealStep(seal []byte) (*SignedStep, error) {
+	if len(seal) <= 8+common.HashLength || len(seal) > 16+common.HashLength {
+		return nil, errInvalidCommittedSeal
+	}
+	height := new(big.Int).SetBytes(seal[8 : len(seal)-common.HashLength])
+	step := &SignedStep{
+		Height: height.Uint64(),
+		Round:  int64(binary.LittleEndian.Uint64(seal[:8])),
+		Step:   msgPrecommit,
+		Value:  common.BytesToHash(seal[len(seal)-common.HashLength:]),
+	}
+	if step.Round < 0 || !bytes.Equal(PrepareCommittedSeal(step.Value, step.Round, height), seal) {
+		return nil, errInvalidCommittedSeal
+	}
+	return step, nil
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedSteps(t *testing.T) {
+	committeeSet, keys := prepareCommittee(t, 1)
+	member := committeeSet.Committee()[0].Address
+	height, hash := big.NewInt(300), common.HexToHash("0xbeef")
+
+	_, payload, signed := prepareVote(t, msgPrevote, 2, height, hash, member, keys[member])
+	step, err := MessageStep(payload)
+	require.NoError(t, err)
+	require.Equal(t, &SignedStep{Height: 300, Round: 2, Step: msgPrevote, Value: hash}, step)
+
+	// A payload carrying a signature, or a vote with trailing data, is not
+	// the one the node would ask to sign.
+	_, err = MessageStep(signed)
+	require.Equal(t, errInvalidSignPayload, err)
+	vote, err := Encode(&Vote{Round: 2, Height: height, ProposedBlockHash: hash})
+	require.NoError(t, err)
+	padded := &Message{Code: msgPrevote, Msg: append(vote, 0x80), Address: member}
+	payload, err = padded.PayloadNoSig()
+	require.NoError(t, err)
+	_, err = MessageStep(payload)
+	require.Error(t, err)
+
+	block := generateBlock(height)
+	_, payload, _ = prepareProposal(t, 3, height, -1, block, member, keys[member])
+	step, err = MessageStep(payload)
+	require.NoError(t, err)
+	require.Equal(t, &SignedStep{Height: 300, Round: 3, Step: msgProposal, Value: block.Hash()}, step)
+
+	step, err = CommittedSealStep(PrepareCommittedSeal(hash, 4, height))
+	require.NoError(t, err)
+	require.Equal(t, &SignedStep{Height: 300, Round: 4, Step: msgPrecommit, Value: hash}, step)
+
+	_, err = CommittedSealStep(hash.Bytes())
+	require.Equal(t, errInvalidCommittedSeal, err)
+	_, err = CommittedSealStep(PrepareCommittedSeal(hash, 4, common.Big0))
+	require.Equal(t, errInvalidCommittedSeal, err)
+	_, err = CommittedSealStep(PrepareCommittedSeal(hash, -1, height))
+	require.Equal(t, errInvalidCommittedSeal, err)
+	// The height is encoded without leading zeros.
+	seal := PrepareCommittedSeal(hash, 4, height)
+	paddedSeal := append(append(append([]byte{}, seal[:8]...), 0), seal[8:]...)
+	_, err = CommittedSealStep(paddedSeal)
+	require.Equal(t, errInvalidCommittedSeal, err)
+}
@@ -9,15 +9,6 @@ import (
 	"github.com/clearmatics/autonity/log"
 )
 
-const (
-	initialProposeTimeout   = 500 * time.Millisecond
-	proposeTimeoutDelta     = 200 * time.Millisecond
-	initialPrevoteTimeout   = 500 * time.Millisecond
-	prevoteTimeoutDelta     = 200 * time.Millisecond
-	initialPrecommitTimeout = 500 * time.Millisecond
-	precommitTimeoutDelta   = 200 * time.Millisecond
-)
-
 type TimeoutEvent struct {
 	roundWhenCalled  int64
 	heightWhenCalled *big.Int
@@ -185,15 +176,15 @@ func (c *core) handleTimeoutPrecommit(ctx context.Context, msg TimeoutEvent) {
 /////////////// Calculate Timeout Duration Functions ///////////////
 // The timeout may need to be changed depending on the Step
 func (c *core) timeoutPropose(round int64) time.Duration {
-	return initialProposeTimeout + time.Duration(c.blockPeriod)*time.Second + time.Duration(round)*proposeTimeoutDelta
+	return c.timeouts.Propose + time.Duration(c.blockPeriod)*time.Second + time.Duration(round)*c.timeouts.ProposeDelta
 }
 
 func (c *core) timeoutPrevote(round int64) time.Duration {
-	return initialPrevoteTimeout + time.Duration(round)*prevoteTimeoutDelta
+	return c.timeouts.Prevote + time.Duration(round)*c.timeouts.PrevoteDelta
 }
 
 func (c *core) timeoutPrecommit(round int64) time.Duration {
-	return initialPrecommitTimeout + time.Duration(round)*precommitTimeoutDelta
+	return c.timeouts.Precommit + time.Duration(round)*c.timeouts.PrecommitDelta
 }
 
 func (c *core) logTimeoutEvent(message string, msgType string, timeout TimeoutEvent) {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/log"
 	"github.com/clearmatics/autonity/metrics"
@@ -224,3 +225,74 @@ func TestOnTimeoutPrecommit(t *testing.T) {
 	})
 	engine.onTimeoutPrecommit(2, big.NewInt(4))
 }
+
+func TestTimeoutDurations(t *testing.T) {
+	t.Run("default timeouts", func(t *testing.T) {
+		engine := core{timeouts: config.RoundRobinConfig().Timeouts(), blockPeriod: 1}
+		if d := engine.timeoutPropose(2); d != 500*time.Millisecond+time.Second+2*200*time.Millisecond {
+			t.Fatalf("unexpected propose timeout %v", d)
+		}
+		if d := engine.timeoutPrevote(2); d != 500*time.Millisecond+2*200*time.Millisecond {
+			t.Fatalf("unexpected prevote timeout %v", d)
+		}
+		if d := engine.timeoutPrecommit(2); d != 500*time.Millisecond+2*200*time.Millisecond {
+			t.Fatalf("unexpected precommit timeout %v", d)
+		}
+	})
+
+	t.Run("configured timeouts", func(t *testing.T) {
+		cfg := config.RoundRobinConfig()
+		cfg.ProposeTimeout, cfg.ProposeTimeoutDelta = ms(3000), ms(1000)
+		cfg.PrevoteTimeout, cfg.PrevoteTimeoutDelta = ms(2000), ms(0)
+		cfg.PrecommitTimeout = ms(1500)
+		engine := core{timeouts: cfg.Timeouts()}
+		if d := engine.timeoutPropose(1); d != 4*time.Second {
+			t.Fatalf("unexpected propose timeout %v", d)
+		}
+		// A zero delta keeps the timeout constant.
+		if d := engine.timeoutPrevote(2); d != 2*time.Second {
+			t.Fatalf("unexpected prevote timeout %v", d)
+		}
+		// The precommit delta is not set and keeps its default value.
+		if d := engine.timeoutPrecommit(1); d != 1700*time.Millisecond {
+			t.Fatalf("unexpected precommit timeout %v", d)
+		}
+	})
+
+	t.Run("genesis timeouts override the node ones", func(t *testing.T) {
+		cfg := config.RoundRobinConfig()
+		cfg.PrevoteTimeout, cfg.PrecommitTimeout, cfg.PrecommitTimeoutDelta = ms(1000), ms(1000), ms(100)
+		cfg.OverrideTimeouts(&config.Config{PrevoteTimeout: ms(2000), PrecommitTimeoutDelta: ms(0)})
+		if *cfg.PrevoteTimeout != 2000 || *cfg.PrecommitTimeout != 1000 || *cfg.PrecommitTimeoutDelta != 0 {
+			t.Fatalf("unexpected timeouts %v %v %v", *cfg.PrevoteTimeout, *cfg.PrecommitTimeout, *cfg.PrecommitTimeoutDelta)
+		}
+	})
+
+	t.Run("timeouts above the maximum are rejected", func(t *testing.T) {
+		cfg := config.RoundRobinConfig()
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("expected <nil>, got %v", err)
+		}
+		cfg.PrevoteTimeoutDelta = ms(config.MaxTimeout + 1)
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("zero initial timeouts are rejected", func(t *testing.T) {
+		cfg := config.RoundRobinConfig()
+		cfg.PrevoteTimeoutDelta = ms(0)
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("expected <nil>, got %v", err)
+		}
+		cfg.PrevoteTimeout = ms(0)
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+// ms returns a pointer to a timeout value in milliseconds.
+func ms(v uint64) *uint64 {
+	return &v
+}
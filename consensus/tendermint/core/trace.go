@@ -0,0 +1,286 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+// The inputs of the consensus state machine, in the order the main event loop
+// handled them.
+const (
+	traceNode             uint64 = iota // the node recording the trace, at the start of every file
+	traceStart                          // the start of the consensus engine
+	traceMessage                        // a message received
+	traceBacklog                        // a message taken back from the backlog
+	traceBacklogUnchecked               // a future height message taken back from the untrusted backlog
+	traceTimeout                        // a step timeout expiry
+	traceCommitEvent                    // a block added to the chain
+)
+
+// The decisions of the state machine and the answers of the backend it relied
+// on, recorded after the input they follow from.
+const (
+	traceHead      uint64 = iota + 64 // the header of the last committed block
+	traceProposer                     // the proposer of a new round
+	traceVerify                       // the result of a proposal verification
+	traceBroadcast                    // a message broadcast by this node
+	traceCommit                       // a block decided by this node
+	traceCommittee                    // the committee of a head carrying its hash only
+	traceResume                       // the write-ahead log entries of the height a restarted node resumed
+)
+
+// traceEntry is the persisted form of a single trace record.
+type traceEntry struct {
+	Kind uint64
+	Time uint64 // unix time in nanoseconds
+	Data []byte
+}
+
+type traceNodeData struct {
+	Address             common.Address
+	ProposerPolicy      uint64
+	AggregatedSeal      bool
+	AggregatedSealBlock uint64
+}
+
+type traceTimeoutData struct {
+	Step   uint64
+	Round  uint64
+	Height *big.Int
+}
+
+type traceProposerData struct {
+	Height   *big.Int
+	Round    uint64
+	Proposer common.Address
+}
+
+type traceVerifyData struct {
+	Hash  common.Hash
+	Delay uint64 // in nanoseconds
+	Err   string
+}
+
+type traceCommitData struct {
+	Hash  common.Hash
+	Round uint64
+}
+
+// traceMember is a committee member along with its BLS key, which is not part
+// of the RLP encoding of the member.
+type traceMember struct {
+	Address     common.Address
+	VotingPower *big.Int
+	BLSKey      []byte
+}
+
+// tracer records the inputs of the consensus state machine and the decisions
+// it takes to a file, so that a height which stalled can be replayed offline.
+// The file is rotated once it exceeds the maximum size, the previous one being
+// kept with the ".1" suffix. A nil tracer is valid and records nothing.
+type tracer struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	node    traceNodeData
+	file    *os.File
+	size    int64
+	logger  log.Logger
+}
+
+func newTracer(cfg *config.Config, address common.Address, logger log.Logger) *tracer {
+	if cfg.TraceFile == "" {
+		return nil
+	}
+	size := cfg.TraceFileSize
+	if size == 0 {
+		size = config.DefaultTraceFileSize
+	}
+	t := &tracer{
+		path:    cfg.TraceFile,
+		maxSize: int64(size) * 1024 * 1024,
+		node: traceNodeData{
+			Address:        address,
+			ProposerPolicy: uint64(cfg.ProposerPolicy),
+		},
+		logger: logger,
+	}
+	if cfg.AggregatedSealBlock != nil {
+		t.node.AggregatedSeal = true
+		t.node.AggregatedSealBlock = cfg.AggregatedSealBlock.Uint64()
+	}
+	return t
+}
+
+func (t *tracer) start() {
+	t.record(traceStart, nil)
+}
+
+func (t *tracer) message(kind uint64, payload []byte) {
+	t.record(kind, payload)
+}
+
+func (t *tracer) timeout(e TimeoutEvent) {
+	t.recordRLP(traceTimeout, &traceTimeoutData{Step: e.step, Round: uint64(e.roundWhenCalled), Height: e.heightWhenCalled})
+}
+
+func (t *tracer) commitEvent() {
+	t.record(traceCommitEvent, nil)
+}
+
+func (t *tracer) resume(entries [][]byte) {
+	t.recordRLP(traceResume, entries)
+}
+
+func (t *tracer) proposer(height *big.Int, round int64, proposer common.Address) {
+	t.recordRLP(traceProposer, &traceProposerData{Height: height, Round: uint64(round), Proposer: proposer})
+}
+
+func (t *tracer) recordRLP(kind uint64, val interface{}) {
+	if t == nil {
+		return
+	}
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.logger.Error("Failed to encode consensus trace entry", "err", err)
+		return
+	}
+	t.record(kind, data)
+}
+
+func (t *tracer) record(kind uint64, data []byte) {
+	if t == nil {
+		return
+	}
+	entry, err := rlp.EncodeToBytes(&traceEntry{Kind: kind, Time: uint64(time.Now().UnixNano()), Data: data})
+	if err != nil {
+		t.logger.Error("Failed to encode consensus trace entry", "err", err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.file != nil && t.size+int64(len(entry)) > t.maxSize {
+		t.file.Close()
+		t.file = nil
+		if err := os.Rename(t.path, t.path+".1"); err != nil {
+			t.logger.Error("Failed to rotate consensus trace", "err", err)
+		}
+	}
+	if t.file == nil && !t.open() {
+		return
+	}
+	n, err := t.file.Write(entry)
+	t.size += int64(n)
+	if err != nil {
+		t.logger.Error("Failed to write consensus trace", "err", err)
+	}
+}
+
+// open appends to the trace file the description of the node, so that every
+// file can be replayed on its own.
+func (t *tracer) open() bool {
+	file, err := os.OpenFile(t.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.logger.Error("Failed to open consensus trace", "path", t.path, "err", err)
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		t.logger.Error("Failed to open consensus trace", "path", t.path, "err", err)
+		file.Close()
+		return false
+	}
+	node, _ := rlp.EncodeToBytes(&t.node)
+	entry, _ := rlp.EncodeToBytes(&traceEntry{Kind: traceNode, Time: uint64(time.Now().UnixNano()), Data: node})
+	n, err := file.Write(entry)
+	if err != nil {
+		t.logger.Error("Failed to write consensus trace", "err", err)
+	}
+	t.file, t.size = file, info.Size()+int64(n)
+	return true
+}
+
+func (t *tracer) close() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
+
+// tracingBackend records the answers of the backend the consensus decisions
+// depend on and the decisions themselves.
+type tracingBackend struct {
+	Backend
+	trace *tracer
+}
+
+func (b *tracingBackend) Broadcast(ctx context.Context, committee types.Committee, payload []byte) error {
+	b.trace.record(traceBroadcast, payload)
+	return b.Backend.Broadcast(ctx, committee, payload)
+}
+
+func (b *tracingBackend) Commit(proposalBlock *types.Block, round int64, seals [][]byte) error {
+	b.trace.recordRLP(traceCommit, &traceCommitData{Hash: proposalBlock.Hash(), Round: uint64(round)})
+	return b.Backend.Commit(proposalBlock, round, seals)
+}
+
+func (b *tracingBackend) CommitAggregated(proposalBlock *types.Block, round int64, seal []byte, signers []byte) error {
+	b.trace.recordRLP(traceCommit, &traceCommitData{Hash: proposalBlock.Hash(), Round: uint64(round)})
+	return b.Backend.CommitAggregated(proposalBlock, round, seal, signers)
+}
+
+func (b *tracingBackend) LastCommittedProposal() (*types.Block, common.Address) {
+	block, proposer := b.Backend.LastCommittedProposal()
+	b.trace.recordRLP(traceHead, block.Header())
+	// The resolved committee of a header within an epoch is not encoded.
+	if header := block.Header(); header.CommitteeHash != (common.Hash{}) {
+		members := make([]traceMember, len(header.Committee))
+		for i, member := range header.Committee {
+			members[i] = traceMember{Address: member.Address, VotingPower: member.VotingPower, BLSKey: member.BLSKey}
+		}
+		b.trace.recordRLP(traceCommittee, members)
+	}
+	return block, proposer
+}
+
+func (b *tracingBackend) VerifyProposal(proposal types.Block) (time.Duration, error) {
+	delay, err := b.Backend.VerifyProposal(proposal)
+	result := &traceVerifyData{Hash: proposal.Hash(), Delay: uint64(delay)}
+	if err != nil {
+		result.Err = err.Error()
+	}
+	b.trace.recordRLP(traceVerify, result)
+	return delay, err
+}
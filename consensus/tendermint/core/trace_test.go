@@ -0,0 +1,118 @@
+package core
+
+import (
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceReplay(t *testing.T) {
+	committeeSet, keys := prepareCommittee(t, 4)
+	members := committeeSet.Committee()
+	proposer, node := members[0].Address, members[1].Address
+
+	// The consensus fields of a header are only encoded with the BFT digest.
+	prevBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), MixDigest: types.BFTDigest})
+	setCommitteeAndSealOnBlock(t, prevBlock, committeeSet, keys, 0)
+	height := big.NewInt(11)
+	block := generateBlock(height)
+
+	writeTrace := func(t *testing.T, prevoteHash common.Hash) string {
+		path := filepath.Join(t.TempDir(), "trace")
+		trace := newTracer(&config.Config{TraceFile: path}, node, log.New())
+
+		trace.start()
+		trace.recordRLP(traceHead, prevBlock.Header())
+		trace.proposer(height, 0, proposer)
+
+		_, _, proposal := prepareProposal(t, 0, height, -1, block, proposer, keys[proposer])
+		trace.message(traceMessage, proposal)
+		trace.recordRLP(traceVerify, &traceVerifyData{Hash: block.Hash()})
+		_, _, prevote := prepareVote(t, msgPrevote, 0, height, prevoteHash, node, keys[node])
+		trace.record(traceBroadcast, prevote)
+
+		trace.close()
+		return path
+	}
+
+	replay := func(t *testing.T, path string) []*ReplayStep {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		var steps []*ReplayStep
+		replayer := NewReplayer(file)
+		for {
+			step, err := replayer.Next()
+			if err == io.EOF {
+				return steps
+			}
+			require.NoError(t, err)
+			steps = append(steps, step)
+		}
+	}
+
+	t.Run("the replay takes the recorded decisions", func(t *testing.T) {
+		steps := replay(t, writeTrace(t, block.Hash()))
+		require.Len(t, steps, 2)
+		for _, step := range steps {
+			require.False(t, step.Diverged(), "step %q diverged: replayed %v, recorded %v", step.Input, step.Replayed, step.Recorded)
+		}
+		require.Equal(t, height, steps[1].Height)
+		require.Equal(t, prevote.String(), steps[1].Step)
+		require.Len(t, steps[1].Replayed, 1)
+	})
+
+	t.Run("the replay reports diverging decisions", func(t *testing.T) {
+		steps := replay(t, writeTrace(t, common.Hash{}))
+		require.Len(t, steps, 2)
+		require.False(t, steps[0].Diverged())
+		require.True(t, steps[1].Diverged())
+	})
+
+	t.Run("the replay resumes the height from the recorded WAL", func(t *testing.T) {
+		_, _, vote := prepareVote(t, msgPrevote, 0, height, block.Hash(), node, keys[node])
+		entry, err := rlp.EncodeToBytes(&walEntry{Kind: walMessage, Data: vote})
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "trace")
+		trace := newTracer(&config.Config{TraceFile: path}, node, log.New())
+		trace.start()
+		trace.recordRLP(traceHead, prevBlock.Header())
+		trace.resume([][]byte{entry})
+		trace.record(traceBroadcast, vote)
+		trace.close()
+
+		steps := replay(t, path)
+		require.Len(t, steps, 1)
+		require.False(t, steps[0].Diverged(), "replayed %v, recorded %v", steps[0].Replayed, steps[0].Recorded)
+		require.Equal(t, height, steps[0].Height)
+		require.Equal(t, prevote.String(), steps[0].Step)
+		require.Len(t, steps[0].Replayed, 1)
+	})
+}
+
+func TestTraceRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace")
+	trace := newTracer(&config.Config{TraceFile: path}, common.Address{}, log.New())
+	trace.maxSize = 128
+	for i := 0; i < 10; i++ {
+		trace.record(traceMessage, make([]byte, 16))
+	}
+	trace.close()
+
+	for _, name := range []string{path, path + ".1"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(128))
+	}
+}
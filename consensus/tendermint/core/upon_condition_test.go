@@ -79,7 +79,7 @@ func TestStartRoundVariables(t *testing.T) {
 		backendMock.EXPECT().Address().Return(clientAddress)
 		backendMock.EXPECT().LastCommittedProposal().Return(prevBlock, clientAddress)
 
-		core := New(backendMock, config.RoundRobinConfig())
+		core := New(backendMock, config.RoundRobinConfig(), nil)
 
 		overrideDefaultCoreValues(core)
 		core.startRound(context.Background(), currentRound)
@@ -102,7 +102,7 @@ func TestStartRoundVariables(t *testing.T) {
 		backendMock.EXPECT().Address().Return(clientAddress)
 		backendMock.EXPECT().LastCommittedProposal().Return(prevBlock, clientAddress).MaxTimes(2)
 
-		core := New(backendMock, config.RoundRobinConfig())
+		core := New(backendMock, config.RoundRobinConfig(), nil)
 		overrideDefaultCoreValues(core)
 		core.startRound(context.Background(), currentRound)
 
@@ -166,7 +166,7 @@ func TestStartRound(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		core := New(backendMock, config.RoundRobinConfig())
+		core := New(backendMock, config.RoundRobinConfig(), nil)
 		// We assume that round 0 can only happen when we move to a new height, therefore, height is
 		// incremented by 1 in start round when round = 0, and the committee set is updated. However, in test case where
 		// round is more than 0, then we need to explicitly update the committee set and height.
@@ -205,7 +205,7 @@ func TestStartRound(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		core := New(backendMock, config.DefaultConfig())
+		core := New(backendMock, config.DefaultConfig(), nil)
 		core.committee = committeeSet
 		core.height = proposalHeight
 		core.validRound = validR
@@ -235,7 +235,7 @@ func TestStartRound(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		core := New(backendMock, config.DefaultConfig())
+		core := New(backendMock, config.DefaultConfig(), nil)
 
 		if currentRound > 0 {
 			core.committee = committeeSet
@@ -263,7 +263,7 @@ func TestStartRound(t *testing.T) {
 
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setCommitteeSet(committeeSet)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
@@ -285,7 +285,7 @@ func TestStartRound(t *testing.T) {
 
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setCommitteeSet(committeeSet)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
@@ -316,7 +316,7 @@ func TestNewProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -353,7 +353,7 @@ func TestNewProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		// if lockedRround = - 1 then lockedValue = nil
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
@@ -387,7 +387,7 @@ func TestNewProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -425,7 +425,7 @@ func TestNewProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -476,7 +476,7 @@ func TestOldProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -517,7 +517,7 @@ func TestOldProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -559,7 +559,7 @@ func TestOldProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(propose)
@@ -670,7 +670,7 @@ func TestOldProposal(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setCommitteeSet(committeeSet)
 		// construct round state with: old round's quorum-1 prevote for v on valid round.
 		c.messages.getOrCreate(proposalValidRound).AddPrevote(proposal.ProposalBlock.Hash(), Message{Code: msgPrevote, power: c.committeeSet().Quorum() - 1})
@@ -734,7 +734,7 @@ func TestPrevoteTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(prevote)
@@ -770,7 +770,7 @@ func TestPrevoteTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(prevote)
@@ -810,7 +810,7 @@ func TestPrevoteTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(prevote)
@@ -838,7 +838,7 @@ func TestPrevoteTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(prevote)
@@ -879,7 +879,7 @@ func TestQuorumPrevote(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(currentStep)
@@ -930,7 +930,7 @@ func TestQuorumPrevote(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(currentStep)
@@ -1000,7 +1000,7 @@ func TestQuorumPrevoteNil(t *testing.T) {
 	backendMock := NewMockBackend(ctrl)
 	backendMock.EXPECT().Address().Return(clientAddr)
 
-	c := New(backendMock, config.DefaultConfig())
+	c := New(backendMock, config.DefaultConfig(), nil)
 	c.setHeight(currentHeight)
 	c.setRound(currentRound)
 	c.setStep(prevote)
@@ -1039,7 +1039,7 @@ func TestPrecommitTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		//TODO: this should be changed to Step(rand.Intn(3)) to make sure precommit timeout can be started from any step
@@ -1076,7 +1076,7 @@ func TestPrecommitTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		//TODO: this should be changed to Step(rand.Intn(3)) to make sure precommit timeout can be started from any step
@@ -1117,7 +1117,7 @@ func TestPrecommitTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		//TODO: this should be changed to Step(rand.Intn(3)) to make sure precommit timeout can be started from any step
@@ -1148,7 +1148,7 @@ func TestPrecommitTimeout(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		//TODO: this should be changed to Step(rand.Intn(3)) to make sure precommit timeout can be started from any step
@@ -1190,7 +1190,7 @@ func TestQuorumPrecommit(t *testing.T) {
 	backendMock := NewMockBackend(ctrl)
 	backendMock.EXPECT().Address().Return(clientAddr)
 
-	c := New(backendMock, config.RoundRobinConfig())
+	c := New(backendMock, config.RoundRobinConfig(), nil)
 	c.setHeight(currentHeight)
 	c.setRound(currentRound)
 	c.setStep(precommit)
@@ -1261,7 +1261,7 @@ func TestFutureRoundChange(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(currentStep)
@@ -1295,7 +1295,7 @@ func TestFutureRoundChange(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(clientAddr)
 
-		c := New(backendMock, config.DefaultConfig())
+		c := New(backendMock, config.DefaultConfig(), nil)
 		c.setHeight(currentHeight)
 		c.setRound(currentRound)
 		c.setStep(currentStep)
@@ -1349,7 +1349,7 @@ func TestHandleMessage(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(key1PubAddr)
 
-		core := New(backendMock, config.DefaultConfig())
+		core := New(backendMock, config.DefaultConfig(), nil)
 		core.setCommitteeSet(committeeSet)
 		core.lastHeader = prevBlock.Header()
 		err = core.handleMsg(context.Background(), msg)
@@ -1374,7 +1374,7 @@ func TestHandleMessage(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(key1PubAddr)
 
-		core := New(backendMock, config.DefaultConfig())
+		core := New(backendMock, config.DefaultConfig(), nil)
 		core.setCommitteeSet(committeeSet)
 		core.lastHeader = prevBlock.Header()
 		err = core.handleMsg(context.Background(), msg)
@@ -1396,7 +1396,7 @@ func TestHandleMessage(t *testing.T) {
 		backendMock := NewMockBackend(ctrl)
 		backendMock.EXPECT().Address().Return(key1PubAddr)
 
-		core := New(backendMock, config.DefaultConfig())
+		core := New(backendMock, config.DefaultConfig(), nil)
 		core.setCommitteeSet(committeeSet)
 		core.lastHeader = prevBlock.Header()
 		err = core.handleMsg(context.Background(), msg)
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+const (
+	walMessage uint64 = iota // a message signed and broadcast by this node
+	walLock                  // an update of lockedRound and lockedValue
+	walValid                 // an update of validRound and validValue
+	walRound                 // the start of a new round
+)
+
+// walEntry is the persisted form of a single write-ahead log record.
+type walEntry struct {
+	Kind  uint64
+	Round uint64
+	Data  []byte // message payload for walMessage, rlp encoded block for walLock and walValid
+}
+
+// walState is the consensus state of a height rebuilt from the write-ahead log.
+type walState struct {
+	round       int64
+	lockedRound int64
+	lockedValue *types.Block
+	validRound  int64
+	validValue  *types.Block
+	messages    []*Message
+}
+
+// wal is the consensus write-ahead log. Every message signed by this node,
+// every round change and every change of the locked and valid values is
+// persisted before it takes effect, so that a node restarting in the middle of a height resumes from
+// where it stopped instead of casting votes conflicting with the ones it has
+// already sent. A nil wal is valid and records nothing.
+type wal struct {
+	db     ethdb.Database
+	height uint64
+	seq    uint64
+	logger log.Logger
+}
+
+func newWAL(db ethdb.Database, logger log.Logger) *wal {
+	if db == nil {
+		return nil
+	}
+	return &wal{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// setHeight moves the log to the given height and discards the entries of
+// the previous heights which are not needed anymore.
+func (w *wal) setHeight(height *big.Int) {
+	if w == nil {
+		return
+	}
+	h := height.Uint64()
+	if h != w.height {
+		rawdb.DeleteConsensusWALBelow(w.db, h)
+	}
+	w.height = h
+	w.seq = uint64(len(rawdb.ReadConsensusWALEntries(w.db, h)))
+}
+
+func (w *wal) writeMessage(round int64, payload []byte) {
+	w.write(walMessage, round, payload)
+}
+
+func (w *wal) writeLock(round int64, block *types.Block) {
+	w.writeBlock(walLock, round, block)
+}
+
+func (w *wal) writeValid(round int64, block *types.Block) {
+	w.writeBlock(walValid, round, block)
+}
+
+func (w *wal) writeRound(round int64) {
+	w.write(walRound, round, nil)
+}
+
+func (w *wal) writeBlock(kind uint64, round int64, block *types.Block) {
+	if w == nil {
+		return
+	}
+	data, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		w.logger.Error("Failed to encode WAL block", "round", round, "err", err)
+		return
+	}
+	w.write(kind, round, data)
+}
+
+func (w *wal) write(kind uint64, round int64, data []byte) {
+	if w == nil {
+		return
+	}
+	enc, err := rlp.EncodeToBytes(&walEntry{Kind: kind, Round: uint64(round), Data: data})
+	if err != nil {
+		w.logger.Error("Failed to encode WAL entry", "kind", kind, "round", round, "err", err)
+		return
+	}
+	rawdb.WriteConsensusWALEntry(w.db, w.height, w.seq, enc)
+	w.seq++
+}
+
+// entries returns the encoded entries recorded for the given height.
+func (w *wal) entries(height *big.Int) [][]byte {
+	if w == nil {
+		return nil
+	}
+	return rawdb.ReadConsensusWALEntries(w.db, height.Uint64())
+}
+
+// decodeWAL rebuilds the consensus state of the given height from its encoded
+// entries.
+func decodeWAL(height *big.Int, entries [][]byte) (*walState, error) {
+	state := &walState{lockedRound: -1, validRound: -1}
+	for i, enc := range entries {
+		var entry walEntry
+		if err := rlp.DecodeBytes(enc, &entry); err != nil {
+			return nil, fmt.Errorf("invalid WAL entry %d at height %v: %v", i, height, err)
+		}
+		round := int64(entry.Round)
+		if round > state.round {
+			state.round = round
+		}
+
+		switch entry.Kind {
+		case walMessage:
+			msg := new(Message)
+			if err := msg.FromPayload(entry.Data); err != nil {
+				return nil, fmt.Errorf("invalid WAL message %d at height %v: %v", i, height, err)
+			}
+			state.messages = append(state.messages, msg)
+		case walLock, walValid:
+			block := new(types.Block)
+			if err := rlp.DecodeBytes(entry.Data, block); err != nil {
+				return nil, fmt.Errorf("invalid WAL block %d at height %v: %v", i, height, err)
+			}
+			if entry.Kind == walLock {
+				state.lockedRound, state.lockedValue = round, block
+			} else {
+				state.validRound, state.validValue = round, block
+			}
+		case walRound:
+			// Nothing else than the round to restore.
+		default:
+			return nil, fmt.Errorf("unknown WAL entry kind %d at height %v", entry.Kind, height)
+		}
+	}
+	return state, nil
+}
+
+// replayWAL restores the state of the current height from the write-ahead log
+// and broadcasts again every message this node signed before it stopped. It
+// returns false if there is nothing to resume from, in which case the height
+// must be started from scratch.
+func (c *core) replayWAL(ctx context.Context) bool {
+	if c.wal == nil {
+		return false
+	}
+	lastBlockMined, _ := c.backend.LastCommittedProposal()
+	height := new(big.Int).Add(lastBlockMined.Number(), common.Big1)
+	entries := c.wal.entries(height)
+	if len(entries) == 0 {
+		return false
+	}
+	// The entries are traced for the replay to resume from them as well.
+	c.trace.resume(entries)
+	return c.resumeWAL(ctx, height, entries)
+}
+
+// resumeWAL restores the state of the given height from the encoded entries
+// of its write-ahead log, it returns false if they are invalid.
+func (c *core) resumeWAL(ctx context.Context, height *big.Int, entries [][]byte) bool {
+	state, err := decodeWAL(height, entries)
+	if err != nil {
+		c.logger.Error("Failed to load consensus WAL", "height", height, "err", err)
+		return false
+	}
+	c.logger.Info("Resuming consensus from WAL", "height", height, "round", state.round, "messages", len(state.messages))
+
+	c.setInitialState(0)
+	if state.round > 0 {
+		c.setInitialState(state.round)
+	}
+	c.lockedRound, c.lockedValue = state.lockedRound, state.lockedValue
+	c.validRound, c.validValue = state.validRound, state.validValue
+
+	for _, msg := range state.messages {
+		if round, _ := msg.Round(); round == state.round {
+			switch msg.Code {
+			case msgProposal:
+				c.sentProposal = true
+			case msgPrevote:
+				c.sentPrevote = true
+			case msgPrecommit:
+				c.sentPrecommit = true
+			}
+		}
+		// Our messages are sent again as they are, which adds them back to our
+		// own message sets and reaches the peers which might have missed them.
+		if err := c.backend.Broadcast(ctx, c.committeeSet().Committee(), msg.Payload()); err != nil {
+			c.logger.Error("Failed to broadcast WAL message", "msg", msg, "err", err)
+		}
+	}
+
+	switch {
+	case c.sentPrecommit:
+		c.setStep(precommit)
+	case c.sentPrevote:
+		c.setStep(prevote)
+	case c.sentProposal:
+		// We wait for our own proposal to come back instead of proposing again.
+		c.setStep(propose)
+	default:
+		// Nothing was signed in the last round yet, so it can be started
+		// normally. The locks are kept since only round 0 resets them.
+		c.startRound(ctx, state.round)
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/ethdb"
+)
+
+// The following tests kill a node at each step of a height and restart it on the same database, the
+// restarted node must resume the height without signing anything conflicting with what it sent before.
+func TestWALReplay(t *testing.T) {
+	const committeeSize = 4
+	prevHeight := big.NewInt(10)
+	height := big.NewInt(11)
+
+	committeeSet, keys := prepareCommittee(t, committeeSize)
+	members := committeeSet.Committee()
+	prevBlock := generateBlock(prevHeight)
+	// The last member sealed the previous block, so the proposer of round r is members[r % committeeSize].
+	setCommitteeAndSealOnBlock(t, prevBlock, committeeSet, keys, committeeSize-1)
+
+	// startNode creates the core of the given member on top of db, every payload it broadcasts is recorded.
+	startNode := func(t *testing.T, ctrl *gomock.Controller, db ethdb.Database, member types.CommitteeMember) (*core, *[][]byte) {
+		var sent [][]byte
+		backendMock := NewMockBackend(ctrl)
+		backendMock.EXPECT().Address().Return(member.Address)
+		backendMock.EXPECT().LastCommittedProposal().Return(prevBlock, members[committeeSize-1].Address).AnyTimes()
+		backendMock.EXPECT().Sign(gomock.Any()).DoAndReturn(func(data []byte) ([]byte, error) {
+			return sign(data, keys[member.Address])
+		}).AnyTimes()
+		backendMock.EXPECT().Broadcast(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ types.Committee, payload []byte) error {
+				sent = append(sent, payload)
+				return nil
+			}).AnyTimes()
+		backendMock.EXPECT().Post(gomock.Any()).AnyTimes()
+		return New(backendMock, config.RoundRobinConfig(), db), &sent
+	}
+
+	stopTimers := func(c *core) {
+		_ = c.proposeTimeout.stopTimer()
+		_ = c.prevoteTimeout.stopTimer()
+		_ = c.precommitTimeout.stopTimer()
+	}
+
+	// receiveProposal sets a verified proposal for the current round of c.
+	receiveProposal := func(t *testing.T, c *core, block *types.Block) {
+		proposer := members[c.Round()%committeeSize]
+		msg, _, _ := prepareProposal(t, c.Round(), height, -1, block, proposer.Address, keys[proposer.Address])
+		var proposal Proposal
+		require.NoError(t, msg.Decode(&proposal))
+		c.curRoundMessages.SetProposal(&proposal, msg, true)
+	}
+
+	// receivePrevotes makes c reach a quorum of prevotes for the given block in its current round.
+	receivePrevotes := func(t *testing.T, c *core, block *types.Block) {
+		for _, m := range members {
+			if m.Address == c.address {
+				continue
+			}
+			msg, _, _ := prepareVote(t, msgPrevote, c.Round(), height, block.Hash(), m.Address, keys[m.Address])
+			require.NoError(t, c.handlePrevote(context.Background(), msg))
+		}
+	}
+
+	t.Run("nothing to replay on a fresh database", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c, sent := startNode(t, ctrl, rawdb.NewMemoryDatabase(), members[2])
+		assert.False(t, c.replayWAL(context.Background()))
+		assert.Empty(t, *sent)
+	})
+
+	t.Run("killed after sending a prevote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		db := rawdb.NewMemoryDatabase()
+		block := generateBlock(height)
+
+		c, sent := startNode(t, ctrl, db, members[2])
+		c.setInitialState(0)
+		receiveProposal(t, c, block)
+		c.sendPrevote(context.Background(), false)
+		c.setStep(prevote)
+		require.Len(t, *sent, 1)
+
+		restarted, resent := startNode(t, ctrl, db, members[2])
+		require.True(t, restarted.replayWAL(context.Background()))
+		assert.Equal(t, *sent, *resent)
+		assert.Equal(t, height, restarted.Height())
+		assert.Equal(t, int64(0), restarted.Round())
+		assert.Equal(t, prevote, restarted.step)
+		assert.True(t, restarted.sentPrevote)
+
+		// A propose timeout firing now must not make the node prevote nil.
+		restarted.handleTimeoutPropose(context.Background(), TimeoutEvent{roundWhenCalled: 0, heightWhenCalled: height, step: msgProposal})
+		assert.Len(t, *resent, 1)
+	})
+
+	t.Run("killed after locking and sending a precommit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		db := rawdb.NewMemoryDatabase()
+		block := generateBlock(height)
+
+		c, sent := startNode(t, ctrl, db, members[2])
+		c.setInitialState(0)
+		receiveProposal(t, c, block)
+		c.sendPrevote(context.Background(), false)
+		c.setStep(prevote)
+		receivePrevotes(t, c, block)
+		require.Equal(t, precommit, c.step)
+		require.Len(t, *sent, 2)
+
+		restarted, resent := startNode(t, ctrl, db, members[2])
+		require.True(t, restarted.replayWAL(context.Background()))
+		assert.Equal(t, *sent, *resent)
+		assert.Equal(t, precommit, restarted.step)
+		assert.True(t, restarted.sentPrevote)
+		assert.True(t, restarted.sentPrecommit)
+		assert.Equal(t, int64(0), restarted.lockedRound)
+		assert.Equal(t, block.Hash(), restarted.lockedValue.Hash())
+		assert.Equal(t, int64(0), restarted.validRound)
+		assert.Equal(t, block.Hash(), restarted.validValue.Hash())
+
+		// The prevote timeout must not make the node precommit nil.
+		restarted.handleTimeoutPrevote(context.Background(), TimeoutEvent{roundWhenCalled: 0, heightWhenCalled: height, step: msgPrevote})
+		assert.Len(t, *resent, 2)
+	})
+
+	t.Run("killed after a round change while locked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		db := rawdb.NewMemoryDatabase()
+		lockedBlock := generateBlock(height)
+
+		c, _ := startNode(t, ctrl, db, members[2])
+		c.setInitialState(0)
+		receiveProposal(t, c, lockedBlock)
+		c.sendPrevote(context.Background(), false)
+		c.setStep(prevote)
+		receivePrevotes(t, c, lockedBlock)
+		c.handleTimeoutPrecommit(context.Background(), TimeoutEvent{roundWhenCalled: 0, heightWhenCalled: height, step: msgPrecommit})
+		require.Equal(t, int64(1), c.Round())
+		stopTimers(c)
+
+		restarted, resent := startNode(t, ctrl, db, members[2])
+		require.True(t, restarted.replayWAL(context.Background()))
+		defer stopTimers(restarted)
+		assert.Equal(t, int64(1), restarted.Round())
+		assert.Equal(t, propose, restarted.step)
+		assert.False(t, restarted.sentPrevote)
+		assert.Equal(t, int64(0), restarted.lockedRound)
+		assert.Equal(t, lockedBlock.Hash(), restarted.lockedValue.Hash())
+		require.Len(t, *resent, 2)
+
+		// A proposal for another block in round 1 must be prevoted nil because of the lock.
+		otherBlock := generateBlock(height)
+		receiveProposal(t, restarted, otherBlock)
+		restarted.sendPrevote(context.Background(), !(restarted.lockedRound == -1 || otherBlock.Hash() == restarted.lockedValue.Hash()))
+		require.Len(t, *resent, 3)
+		msg := new(Message)
+		require.NoError(t, msg.FromPayload((*resent)[2]))
+		var vote Vote
+		require.NoError(t, msg.Decode(&vote))
+		assert.Equal(t, common.Hash{}, vote.ProposedBlockHash)
+	})
+
+	t.Run("proposer killed after sending its proposal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		db := rawdb.NewMemoryDatabase()
+
+		c, sent := startNode(t, ctrl, db, members[0])
+		c.setInitialState(0)
+		require.True(t, c.isProposer())
+		c.backend.(*MockBackend).EXPECT().SetProposedBlockHash(gomock.Any())
+		c.sendProposal(context.Background(), generateBlock(height))
+		require.Len(t, *sent, 1)
+
+		// The restarted proposer must not propose another block.
+		restarted, resent := startNode(t, ctrl, db, members[0])
+		require.True(t, restarted.replayWAL(context.Background()))
+		assert.Equal(t, *sent, *resent)
+		assert.True(t, restarted.sentProposal)
+		assert.Equal(t, propose, restarted.step)
+	})
+
+	t.Run("entries of previous heights are pruned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		db := rawdb.NewMemoryDatabase()
+
+		c, _ := startNode(t, ctrl, db, members[2])
+		c.setInitialState(0)
+		c.sendPrevote(context.Background(), true)
+		require.Len(t, rawdb.ReadConsensusWALEntries(db, height.Uint64()), 1)
+
+		c.wal.setHeight(new(big.Int).Add(height, common.Big1))
+		assert.Empty(t, rawdb.ReadConsensusWALEntries(db, height.Uint64()))
+	})
+}
@@ -29,6 +29,9 @@ type NewUnminedBlockEvent struct {
 // MessageEvent is posted for Istanbul engine communication
 type MessageEvent struct {
 	Payload []byte
+	// Sender is the peer the message was received from, the zero address for
+	// the messages of the node itself.
+	Sender common.Address
 }
 
 type Poster interface {
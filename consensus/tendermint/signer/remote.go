@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"context"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/common/hexutil"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/rlp"
+	"github.com/clearmatics/autonity/rpc"
+)
+
+// Namespace is the RPC namespace the signing service is served under.
+const Namespace = "tendermintsigner"
+
+// requestTimeout bounds the time a signing request may take, the consensus
+// step timeouts being in the order of a second.
+const requestTimeout = 2 * time.Second
+
+// remoteSigner signs through a signing service served over RPC.
+type remoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// Dial connects to the signing service listening on the local socket at the
+// given path. The service is only served over IPC as it does not
+// authenticate its clients, the permissions of the socket do.
+func Dial(path string) (Signer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	client, err := rpc.DialIPC(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemote(client)
+}
+
+// NewRemote returns a signer signing through the signing service the client
+// is connected to.
+func NewRemote(client *rpc.Client) (Signer, error) {
+	s := &remoteSigner{client: client}
+	if err := s.call(&s.address, "address"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *remoteSigner) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return s.client.CallContext(ctx, result, Namespace+"_"+method, args...)
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *remoteSigner) Sign(data []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	err := s.call(&sig, "sign", hexutil.Bytes(data))
+	return sig, err
+}
+
+func (s *remoteSigner) SignBLS(seal []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	err := s.call(&sig, "signBLS", hexutil.Bytes(seal))
+	return sig, err
+}
+
+// SignHeader sends the whole header rather than its seal hash, so that the
+// service only ever signs data it can make sense of.
+func (s *remoteSigner) SignHeader(header *types.Header) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	var sig hexutil.Bytes
+	err = s.call(&sig, "signHeader", hexutil.Bytes(enc))
+	return sig, err
+}
+
+func (s *remoteSigner) BLSKeyRegistration() ([]byte, error) {
+	var registration hexutil.Bytes
+	err := s.call(&registration, "blsKeyRegistration")
+	return registration, err
+}
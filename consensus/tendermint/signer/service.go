@@ -0,0 +1,204 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/common/hexutil"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+)
+
+var (
+	// errDoubleSign is returned when asked to sign a value for a step a
+	// different value was already signed for.
+	errDoubleSign = errors.New("refusing to double sign")
+	// errStaleHeight is returned when asked to sign for a height below the
+	// last one signed for, whose signed steps are forgotten.
+	errStaleHeight = errors.New("refusing to sign for a past height")
+	// errInvalidHeader is returned when asked to seal a header which is not
+	// the canonical encoding of a tendermint header.
+	errInvalidHeader = errors.New("invalid header to seal")
+)
+
+// Service is the signing service a remote signer talks to. It only signs
+// consensus messages, committed seals and headers it can decode, and refuses
+// to sign two different values for the same step of a round of a height. The
+// values signed are stored before any signature is returned, so that they are
+// not forgotten across restarts.
+type Service struct {
+	signer Signer
+	path   string // file the signed values are stored in
+
+	mu     sync.Mutex
+	height uint64
+	signed map[tendermintCore.SignedStep]common.Hash // the values signed, by step
+}
+
+// signedState is the content of the file the signed values are stored in.
+type signedState struct {
+	Height uint64                      `json:"height"`
+	Signed []tendermintCore.SignedStep `json:"signed"`
+}
+
+// NewService returns a signing service signing with the given signer, which
+// stores the values it signs in the file at path and resumes from it.
+func NewService(signer Signer, path string) (*Service, error) {
+	s := &Service{
+		signer: signer,
+		path:   path,
+		signed: make(map[tendermintCore.SignedStep]common.Hash),
+	}
+	enc, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state signedState
+	if err := json.Unmarshal(enc, &state); err != nil {
+		return nil, fmt.Errorf("invalid signed values in %s: %v", path, err)
+	}
+	s.height = state.Height
+	for _, step := range state.Signed {
+		key := step
+		key.Value = common.Hash{}
+		s.signed[key] = step.Value
+	}
+	return s, nil
+}
+
+// Address returns the address of the validator the service signs for.
+func (s *Service) Address() common.Address {
+	return s.signer.Address()
+}
+
+// Sign signs the payload of a consensus message without its signature or a
+// committed seal.
+func (s *Service) Sign(data hexutil.Bytes) (hexutil.Bytes, error) {
+	step, err := tendermintCore.MessageStep(data)
+	if err != nil {
+		if step, err = tendermintCore.CommittedSealStep(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.check(step); err != nil {
+		return nil, err
+	}
+	return s.signer.Sign(data)
+}
+
+// SignBLS signs a committed seal with the BLS key of the validator.
+func (s *Service) SignBLS(seal hexutil.Bytes) (hexutil.Bytes, error) {
+	step, err := tendermintCore.CommittedSealStep(seal)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.check(step); err != nil {
+		return nil, err
+	}
+	return s.signer.SignBLS(seal)
+}
+
+// SignHeader returns the proposer seal of an RLP encoded header. Unlike the
+// proposal it is sent with, it does not commit the validator to the block,
+// it is still only given for the heights the service may sign for.
+func (s *Service) SignHeader(enc hexutil.Bytes) (hexutil.Bytes, error) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(enc, header); err != nil {
+		return nil, err
+	}
+	if canonical, err := rlp.EncodeToBytes(header); err != nil || !bytes.Equal(canonical, enc) {
+		return nil, errInvalidHeader
+	}
+	if header.MixDigest != types.BFTDigest || header.Number == nil || header.Number.Sign() <= 0 || !header.Number.IsUint64() {
+		return nil, errInvalidHeader
+	}
+	s.mu.Lock()
+	height := s.height
+	s.mu.Unlock()
+	if header.Number.Uint64() < height {
+		return nil, fmt.Errorf("%w: height %d, signed up to %d", errStaleHeight, header.Number, height)
+	}
+	return s.signer.SignHeader(header)
+}
+
+// BlsKeyRegistration returns the BLS public key of the validator followed by
+// its proof of possession. It is spelt so as to be served as blsKeyRegistration.
+func (s *Service) BlsKeyRegistration() (hexutil.Bytes, error) {
+	return s.signer.BLSKeyRegistration()
+}
+
+// check records the value signed for step, unless a different one was signed
+// for it already. Only the steps of the last height signed for are kept. The
+// record is stored before check returns, a value it could not store is not
+// signed.
+func (s *Service) check(step *tendermintCore.SignedStep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if step.Height < s.height {
+		return fmt.Errorf("%w: height %d, signed up to %d", errStaleHeight, step.Height, s.height)
+	}
+	signed := s.signed
+	if step.Height > s.height {
+		signed = make(map[tendermintCore.SignedStep]common.Hash)
+	}
+	key := *step
+	key.Value = common.Hash{}
+	if value, ok := signed[key]; ok {
+		if value != step.Value {
+			log.Warn("Refused to double sign", "height", step.Height, "round", step.Round, "step", step.Step, "signed", value, "value", step.Value)
+			return fmt.Errorf("%w: height %d round %d step %d", errDoubleSign, step.Height, step.Round, step.Step)
+		}
+		return nil
+	}
+	signed[key] = step.Value
+	if err := s.store(step.Height, signed); err != nil {
+		delete(signed, key)
+		log.Error("Failed to store the signed values", "path", s.path, "err", err)
+		return err
+	}
+	s.height, s.signed = step.Height, signed
+	return nil
+}
+
+// store atomically replaces the file the signed values are stored in, syncing
+// it to disk.
+func (s *Service) store(height uint64, signed map[tendermintCore.SignedStep]common.Hash) error {
+	state := signedState{Height: height}
+	for key, value := range signed {
+		key.Value = value
+		state.Signed = append(state.Signed, key)
+	}
+	enc, err := json.Marshal(&state)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(enc); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	f.Close()
+	return os.Rename(f.Name(), s.path)
+}
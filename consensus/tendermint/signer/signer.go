@@ -0,0 +1,86 @@
+// Package signer holds the consensus keys of a validator, either in the node
+// process or in a signing service the node talks to, so that the keys need not
+// be kept on the node host.
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
+	"github.com/clearmatics/autonity/log"
+)
+
+// ErrNoBLSKey is returned when the BLS key could not be derived from the key
+// of the validator.
+var ErrNoBLSKey = errors.New("no BLS key")
+
+// Signer signs on behalf of a validator.
+type Signer interface {
+	// Address returns the address of the validator.
+	Address() common.Address
+
+	// Sign signs the Keccak256 hash of data, which is either the payload
+	// of a consensus message without its signature or a committed seal.
+	Sign(data []byte) ([]byte, error)
+
+	// SignBLS signs a committed seal with the BLS key of the validator.
+	SignBLS(seal []byte) ([]byte, error)
+
+	// SignHeader returns the proposer seal of a header.
+	SignHeader(header *types.Header) ([]byte, error)
+
+	// BLSKeyRegistration returns the BLS public key of the validator
+	// followed by its proof of possession.
+	BLSKeyRegistration() ([]byte, error)
+}
+
+// localSigner signs with keys held by the node process.
+type localSigner struct {
+	key     *ecdsa.PrivateKey
+	blsKey  *bls.SecretKey
+	address common.Address
+}
+
+// NewLocal returns a signer holding the given key in process. The BLS key is
+// derived from it so that it needs no separate management.
+func NewLocal(key *ecdsa.PrivateKey) Signer {
+	blsKey, err := bls.DeriveKey(crypto.FromECDSA(key))
+	if err != nil {
+		log.Error("Failed to derive the BLS key", "err", err)
+	}
+	return &localSigner{
+		key:     key,
+		blsKey:  blsKey,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+func (s *localSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *localSigner) Sign(data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), s.key)
+}
+
+func (s *localSigner) SignBLS(seal []byte) ([]byte, error) {
+	if s.blsKey == nil {
+		return nil, ErrNoBLSKey
+	}
+	return s.blsKey.Sign(seal).Bytes(), nil
+}
+
+func (s *localSigner) SignHeader(header *types.Header) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(types.SigHash(header).Bytes()), s.key)
+}
+
+func (s *localSigner) BLSKeyRegistration() ([]byte, error) {
+	if s.blsKey == nil {
+		return nil, ErrNoBLSKey
+	}
+	return s.blsKey.Registration(), nil
+}
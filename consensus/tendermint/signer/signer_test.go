@@ -0,0 +1,138 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	tendermintCore "github.com/clearmatics/autonity/consensus/tendermint/core"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/rlp"
+	"github.com/clearmatics/autonity/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRemote(t *testing.T) (Signer, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	service, err := NewService(NewLocal(key), filepath.Join(t.TempDir(), "signed.json"))
+	require.NoError(t, err)
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName(Namespace, service))
+	t.Cleanup(server.Stop)
+	remote, err := NewRemote(rpc.DialInProc(server))
+	require.NoError(t, err)
+	return remote, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	remote, address := newTestRemote(t)
+	require.Equal(t, address, remote.Address())
+
+	seal := tendermintCore.PrepareCommittedSeal(common.HexToHash("0x01"), 1, big.NewInt(10))
+	sig, err := remote.Sign(seal)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(crypto.Keccak256(seal), sig)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+
+	_, err = remote.SignBLS(seal)
+	require.NoError(t, err)
+	_, err = remote.BLSKeyRegistration()
+	require.NoError(t, err)
+
+	header := &types.Header{Number: big.NewInt(10), MixDigest: types.BFTDigest}
+	sig, err = remote.SignHeader(header)
+	require.NoError(t, err)
+	require.NoError(t, types.WriteSeal(header, sig))
+	signer, err := types.Ecrecover(header)
+	require.NoError(t, err)
+	require.Equal(t, address, signer)
+}
+
+func TestServiceRefusesDoubleSign(t *testing.T) {
+	remote, _ := newTestRemote(t)
+	height := big.NewInt(10)
+	seal := tendermintCore.PrepareCommittedSeal(common.HexToHash("0x01"), 1, height)
+
+	_, err := remote.Sign(seal)
+	require.NoError(t, err)
+	// Signing the same value again, as when a vote is rebroadcast, is fine.
+	_, err = remote.Sign(seal)
+	require.NoError(t, err)
+
+	conflicting := tendermintCore.PrepareCommittedSeal(common.HexToHash("0x02"), 1, height)
+	_, err = remote.Sign(conflicting)
+	require.Error(t, err)
+	_, err = remote.SignBLS(conflicting)
+	require.Error(t, err)
+
+	// The same value at a later round is another step.
+	_, err = remote.Sign(tendermintCore.PrepareCommittedSeal(common.HexToHash("0x02"), 2, height))
+	require.NoError(t, err)
+
+	_, err = remote.Sign(tendermintCore.PrepareCommittedSeal(common.HexToHash("0x03"), 0, big.NewInt(11)))
+	require.NoError(t, err)
+	_, err = remote.Sign(conflicting)
+	require.Error(t, err)
+}
+
+func TestServiceCheck(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "signed.json")
+	service, err := NewService(NewLocal(key), path)
+	require.NoError(t, err)
+	step := &tendermintCore.SignedStep{Height: 5, Round: 0, Step: 2, Value: common.HexToHash("0x01")}
+
+	require.NoError(t, service.check(step))
+	conflicting := *step
+	conflicting.Value = common.HexToHash("0x02")
+	require.True(t, errors.Is(service.check(&conflicting), errDoubleSign))
+
+	next := *step
+	next.Height = 6
+	require.NoError(t, service.check(&next))
+	require.True(t, errors.Is(service.check(step), errStaleHeight))
+
+	// A restarted service remembers what it signed.
+	restarted, err := NewService(NewLocal(key), path)
+	require.NoError(t, err)
+	require.True(t, errors.Is(restarted.check(step), errStaleHeight))
+	require.NoError(t, restarted.check(&next))
+	conflicting = next
+	conflicting.Value = common.HexToHash("0x02")
+	require.True(t, errors.Is(restarted.check(&conflicting), errDoubleSign))
+}
+
+func TestServiceSignHeader(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	service, err := NewService(NewLocal(key), filepath.Join(t.TempDir(), "signed.json"))
+	require.NoError(t, err)
+
+	header := &types.Header{Number: big.NewInt(10), MixDigest: types.BFTDigest}
+	enc, err := rlp.EncodeToBytes(header)
+	require.NoError(t, err)
+	_, err = service.SignHeader(enc)
+	require.NoError(t, err)
+
+	_, err = service.SignHeader(append(enc, 0x80))
+	require.Error(t, err)
+	notBFT := &types.Header{Number: big.NewInt(10)}
+	enc, err = rlp.EncodeToBytes(notBFT)
+	require.NoError(t, err)
+	_, err = service.SignHeader(enc)
+	require.Equal(t, errInvalidHeader, err)
+
+	require.NoError(t, service.check(&tendermintCore.SignedStep{Height: 11}))
+	_, err = service.SignHeader(enc)
+	require.Error(t, err)
+	enc, err = rlp.EncodeToBytes(header)
+	require.NoError(t, err)
+	_, err = service.SignHeader(enc)
+	require.True(t, errors.Is(err, errStaleHeight))
+}
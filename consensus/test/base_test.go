@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/clearmatics/autonity/consensus/tendermint/config"
+	"github.com/clearmatics/autonity/core"
 	"github.com/zimmski/go-leak"
 	"gonum.org/v1/gonum/stat"
 )
@@ -21,6 +23,16 @@ func TestTendermintSuccess(t *testing.T) {
 			numBlocks:     5,
 			txPerPeer:     1,
 		},
+		{
+			name:          "no malicious, weighted round-robin proposer policy",
+			numValidators: 5,
+			numBlocks:     10,
+			txPerPeer:     1,
+			genesisHook: func(g *core.Genesis) *core.Genesis {
+				g.Config.Tendermint.ProposerPolicy = config.WeightedRoundRobin
+				return g
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -79,6 +79,44 @@ func TestDynamicRpcs(t *testing.T) {
 	runTest(t, tc)
 }
 
+// This test checks that the dynamic rpcs can be run against the state of a
+// past block, given by number or by hash.
+func TestDynamicRpcsAtBlock(t *testing.T) {
+	tc := &testCase{
+		numValidators: 1,
+		numBlocks:     3,
+		finalAssert: func(t *testing.T, validators map[string]*testNode) {
+			n := validators["VA"]
+			ep := n.node.HTTPEndpoint()
+			call := func(params interface{}) map[string]interface{} {
+				payload, err := json.Marshal(&rpcCall{
+					Method:  "aut_getCommittee",
+					Jsonrpc: "2.0",
+					Id:      1,
+					Params:  params,
+				})
+				require.NoError(t, err)
+				responseMap := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(callRPC(t, ep, payload), &responseMap))
+				return responseMap
+			}
+
+			latest := call(nil)
+			require.Nil(t, latest["error"])
+			for _, block := range []interface{}{"latest", "0x0", "0x1", n.service.BlockChain().GetHeaderByNumber(1).Hash().Hex()} {
+				response := call([]interface{}{block})
+				assert.Nil(t, response["error"], "block %v", block)
+				assert.Equal(t, latest["result"], response["result"], "block %v", block)
+			}
+
+			// A block which is not known yet.
+			response := call([]interface{}{"0xffffff"})
+			assert.NotNil(t, response["error"])
+		},
+	}
+	runTest(t, tc)
+}
+
 type rpcCall struct {
 	Jsonrpc string      `json:"jsonrpc,omitempty"` // nolint
 	Method  string      `json:"method,omitempty"`
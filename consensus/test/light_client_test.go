@@ -0,0 +1,99 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/clearmatics/autonity/common/keygenerator"
+	"github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/eth/downloader"
+	"github.com/clearmatics/autonity/les"
+	"github.com/clearmatics/autonity/node"
+	"github.com/clearmatics/autonity/params"
+)
+
+func TestTendermintLightClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	lightKey, err := keygenerator.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lightNode, err := newNode(lightKey, "PL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []*testCase{
+		{
+			name:          "light client syncs the headers of a validator",
+			numValidators: 5,
+			numBlocks:     10,
+			txPerPeer:     1,
+			lightServers:  map[string]bool{"VA": true},
+			genesisHook: func(g *core.Genesis) *core.Genesis {
+				// the light client must be whitelisted to be accepted by the validators.
+				address := crypto.PubkeyToAddress(lightKey.PublicKey)
+				g.Config.AutonityContractConfig.Users = append(g.Config.AutonityContractConfig.Users, params.User{
+					Address: &address,
+					Enode:   lightNode.url,
+					Type:    params.UserParticipant,
+				})
+				return g
+			},
+			finalAssert: func(t *testing.T, validators map[string]*testNode) {
+				server := validators["VA"]
+				lightNode.listener[0].Close()
+				lightNode.listener[1].Close()
+
+				nodeConfig, ethConfig := makeNodeConfig(t, server.ethConfig.Genesis, lightKey,
+					fmt.Sprintf("127.0.0.1:%d", lightNode.port), lightNode.rpcPort, 0, 0)
+				ethConfig.SyncMode = downloader.LightSync
+
+				stack, err := node.New(nodeConfig)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer stack.Close()
+				client, err := les.New(stack, ethConfig)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = stack.Start(); err != nil {
+					t.Fatal(err)
+				}
+				stack.Server().AddPeer(server.node.Server().Self())
+
+				// Every header is checked against the committed seals of the committee of its parent.
+				target := server.service.BlockChain().CurrentHeader()
+				deadline := time.After(30 * time.Second)
+				for client.BlockChain().CurrentHeader().Number.Cmp(target.Number) < 0 {
+					select {
+					case <-deadline:
+						t.Fatalf("light client stuck at header %v, want %v",
+							client.BlockChain().CurrentHeader().Number, target.Number)
+					case <-time.After(100 * time.Millisecond):
+					}
+				}
+				for n := uint64(1); n <= target.Number.Uint64(); n++ {
+					want := server.service.BlockChain().GetHeaderByNumber(n)
+					got := client.BlockChain().GetHeaderByNumber(n)
+					if got == nil || got.Hash() != want.Hash() {
+						t.Fatalf("light client header mismatch at height %d", n)
+					}
+				}
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		testCase := testCase
+		t.Run(fmt.Sprintf("test case %s", testCase.name), func(t *testing.T) {
+			runTest(t, testCase)
+		})
+	}
+}
@@ -63,6 +63,7 @@ type testCase struct {
 	noQuorumTimeout      time.Duration
 	topology             *Topology
 	skipNoLeakCheck      bool
+	lightServers         map[string]bool //map[validatorIndex]servesLightClients
 }
 
 type injectors struct {
@@ -211,6 +212,10 @@ func runTest(t *testing.T, test *testCase) {
 		peer.nodeConfig, peer.ethConfig = makeNodeConfig(t, genesis, peer.privateKey,
 			fmt.Sprintf("127.0.0.1:%d", peer.port),
 			peer.rpcPort, rates.in, rates.out)
+		if test.lightServers[i] {
+			peer.ethConfig.LightServ = 100
+			peer.ethConfig.LightPeers = 10
+		}
 
 		if err != nil {
 			t.Fatal("cant make a node", i, err)
@@ -17,6 +17,7 @@ import (
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/crypto"
 	"github.com/clearmatics/autonity/eth"
+	"github.com/clearmatics/autonity/les"
 )
 
 type networkRate struct {
@@ -79,6 +80,12 @@ func (validator *testNode) startNode() error {
 		return err
 	}
 
+	if validator.ethConfig.LightServ > 0 {
+		if _, err = les.NewLesServer(validator.node, validator.service, validator.ethConfig); err != nil {
+			return err
+		}
+	}
+
 	if err := validator.node.Start(); err != nil {
 		return fmt.Errorf("cannot start a node %s", err)
 	}
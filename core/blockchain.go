@@ -310,13 +310,16 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		bytes, err := bc.GetKeyValue([]byte(autonity.ABISPEC))
 		if err == nil || bytes != nil {
 			JSONString = string(bytes)
+		} else if rawdb.ReadAutonityContractABI(bc.db, 0) == "" {
+			// The contract was never upgraded, the genesis ABI is the first entry of the ABI history.
+			rawdb.WriteAutonityContractABI(bc.db, 0, acConfig.ABI)
 		}
 		contract, err := autonity.NewAutonityContract(
 			bc,
 			acConfig.Operator,
 			acConfig.MinGasPrice,
 			JSONString,
-			&defaultEVMProvider{bc},
+			&defaultEVMProvider{bc: bc},
 		)
 		if err != nil {
 			return nil, err
@@ -579,6 +582,9 @@ func (bc *BlockChain) SetHead(head uint64) error {
 			rawdb.DeleteBody(db, hash, num)
 			rawdb.DeleteReceipts(db, hash, num)
 		}
+		// A contract upgrade of a removed block must not be seen as active anymore.
+		rawdb.DeleteAutonityContractABI(db, num)
+		rawdb.DeleteRewardDistribution(db, hash, num)
 		// Todo(rjl493456442) txlookup, bloombits, etc
 	}
 	// If SetHead was only called as a chain reparation method, try to skip
@@ -916,6 +922,24 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	return receipts
 }
 
+// GetRewardDistribution retrieves the rewards distributed by the block of the
+// given header. The blocks which were not processed by this node, like the
+// fast synced ones, are not indexed and their rewards are read from their
+// receipts instead.
+func (bc *BlockChain) GetRewardDistribution(header *types.Header) (*types.RewardDistribution, error) {
+	if rewards := rawdb.ReadRewardDistribution(bc.db, header.Hash(), header.Number.Uint64()); rewards != nil {
+		return rewards, nil
+	}
+	if bc.autonityContract == nil {
+		return nil, errors.New("the autonity contract is not specified")
+	}
+	receipts := bc.GetReceiptsByHash(header.Hash())
+	if receipts == nil {
+		return nil, fmt.Errorf("no receipts for block #%d", header.Number.Uint64())
+	}
+	return bc.autonityContract.RewardDistribution(header, receipts)
+}
+
 // GetBlocksFromHash returns the block corresponding to hash and up to n-1 ancestors.
 // [deprecated by eth/62]
 func (bc *BlockChain) GetBlocksFromHash(hash common.Hash, n int) (blocks []*types.Block) {
@@ -1511,6 +1535,22 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	// Note all the components of block(td, hash->number map, header, body, receipts)
 	// should be written atomically. BlockBatch is used for containing all components.
 	blockBatch := bc.db.NewBatch()
+	if bc.chainConfig.Tendermint != nil {
+		// Index the rewards distributed by the block, the metrics only keep the last ones.
+		// The block is valid regardless, GetRewardDistribution reads the rewards of the
+		// blocks which are not indexed from their receipts.
+		rewards, err := bc.GetAutonityContract().RewardDistribution(block.Header(), receipts)
+		if err != nil {
+			log.Warn("Failed to index the reward distribution", "number", block.Number(), "hash", block.Hash(), "err", err)
+		} else {
+			rawdb.WriteRewardDistribution(blockBatch, rewards)
+			bc.GetAutonityContract().MeasureRewardDistribution(rewards)
+		}
+		// Keep the minimum gas price of the next transactions cached for the tx pool and the gas price oracle.
+		if err := bc.GetAutonityContract().UpdateMinimumGasPrice(block.Header(), state, receipts); err != nil {
+			log.Warn("Failed to update the minimum gas price", "number", block.Number(), "hash", block.Hash(), "err", err)
+		}
+	}
 	rawdb.WriteTd(blockBatch, block.Hash(), block.NumberU64(), externTd)
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
@@ -1608,6 +1648,7 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	}
 	// Set new head.
 	if status == CanonStatTy {
+		bc.commitContractUpgrade(block, state)
 		bc.writeHeadBlock(block)
 	}
 	bc.futureBlocks.Remove(block.Hash())
@@ -2219,9 +2260,22 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 	} else {
 		log.Error("Impossible reorg, please file an issue", "oldnum", oldBlock.Number(), "oldhash", oldBlock.Hash(), "newnum", newBlock.Number(), "newhash", newBlock.Hash())
 	}
+	// A contract upgrade of a dropped block must not be seen as active anymore.
+	if bc.chainConfig.Tendermint != nil {
+		for _, block := range oldChain {
+			rawdb.DeleteAutonityContractABI(bc.db, block.NumberU64())
+		}
+	}
 	// Insert the new chain(except the head block(reverse order)),
 	// taking care of the proper incremental order.
 	for i := len(newChain) - 1; i >= 1; i-- {
+		if bc.chainConfig.Tendermint != nil {
+			if statedb, err := bc.StateAt(newChain[i].Root()); err != nil {
+				log.Warn("Missing state of reorged block", "number", newChain[i].Number(), "hash", newChain[i].Hash(), "err", err)
+			} else {
+				bc.commitContractUpgrade(newChain[i], statedb)
+			}
+		}
 		// Insert the block in the canonical way, re-writing history
 		bc.writeHeadBlock(newChain[i])
 
@@ -2583,6 +2637,25 @@ func (bc *BlockChain) GetKeyValue(key []byte) ([]byte, error) {
 	return rawdb.GetKeyValue(bc.db, key)
 }
 
+// commitContractUpgrade persists the ABI of the autonity contract upgraded by a
+// block joining the canonical chain, statedb being the state on top of it.
+func (bc *BlockChain) commitContractUpgrade(block *types.Block, statedb *state.StateDB) {
+	if bc.chainConfig.Tendermint == nil {
+		return
+	}
+	if err := bc.GetAutonityContract().CommitUpgrade(block.Header(), statedb); err != nil {
+		log.Error("Failed to commit the autonity contract upgrade", "number", block.Number(), "hash", block.Hash(), "err", err)
+	}
+}
+
+func (bc *BlockChain) WriteAutonityContractABI(number uint64, abi string) {
+	rawdb.WriteAutonityContractABI(bc.db, number, abi)
+}
+
+func (bc *BlockChain) ReadAutonityContractABI(number uint64) string {
+	return rawdb.ReadAutonityContractABI(bc.db, number)
+}
+
 func (bc *BlockChain) GetMinGasPrice(blockNumber ...uint64) (*big.Int, error) {
 	if bc.autonityContract == nil {
 		return nil, errors.New("the autonity contract is not specified")
@@ -2604,7 +2677,7 @@ func (bc *BlockChain) GetMinGasPrice(blockNumber ...uint64) (*big.Int, error) {
 	}
 
 	var contractMinGasPrice = new(big.Int)
-	minGasPrice, err := bc.autonityContract.GetMinimumGasPrice(block, statedb)
+	minGasPrice, err := bc.autonityContract.GetMinimumGasPrice(block.Header(), statedb)
 	if err != nil {
 		return nil, err
 	}
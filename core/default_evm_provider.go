@@ -3,6 +3,7 @@ package core
 import (
 	"math/big"
 
+	"github.com/clearmatics/autonity/autonity"
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/core/state"
 	"github.com/clearmatics/autonity/core/types"
@@ -11,7 +12,15 @@ import (
 
 // defaultEVMProvider implements autonity.EVMProvider
 type defaultEVMProvider struct {
-	bc *BlockChain
+	bc       *BlockChain
+	vmConfig *vm.Config // config of the EVMs, the one of the chain if nil
+}
+
+// NewEVMProvider returns an autonity.EVMProvider building the EVMs of the
+// chain with the given config, e.g. to trace the calls to the Autonity
+// contract.
+func NewEVMProvider(bc *BlockChain, vmConfig vm.Config) autonity.EVMProvider {
+	return &defaultEVMProvider{bc: bc, vmConfig: &vmConfig}
 }
 
 func (p *defaultEVMProvider) EVM(header *types.Header, origin common.Address, statedb *state.StateDB) *vm.EVM {
@@ -29,6 +38,9 @@ func (p *defaultEVMProvider) EVM(header *types.Header, origin common.Address, st
 		GasPrice:    new(big.Int).SetUint64(0x0),
 	}
 	vmConfig := *p.bc.GetVMConfig()
+	if p.vmConfig != nil {
+		vmConfig = *p.vmConfig
+	}
 	evm := vm.NewEVM(evmContext, statedb, p.bc.Config(), vmConfig)
 	return evm
 }
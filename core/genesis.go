@@ -36,6 +36,7 @@ import (
 	"github.com/clearmatics/autonity/core/rawdb"
 	"github.com/clearmatics/autonity/core/state"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto/bls"
 	"github.com/clearmatics/autonity/core/vm"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/log"
@@ -217,6 +218,11 @@ func SetupGenesisBlock(db ethdb.Database, genesis *Genesis) (*params.ChainConfig
 	if err := newcfg.CheckConfigForkOrder(); err != nil {
 		return newcfg, common.Hash{}, err
 	}
+	if newcfg.Tendermint != nil {
+		if err := newcfg.Tendermint.Validate(); err != nil {
+			return newcfg, common.Hash{}, err
+		}
+	}
 	storedcfg := rawdb.ReadChainConfig(db, stored)
 	if storedcfg == nil {
 		log.Warn("Found genesis block without chain config")
@@ -276,7 +282,9 @@ func (g *Genesis) ToBlock(db ethdb.Database) (*types.Block, error) {
 		if err != nil {
 			return nil, err
 		}
-		committee, err = extractCommittee(g.Config.AutonityContractConfig.Users)
+		// The committed seals of block #1 are verified against the genesis committee.
+		withBLSKeys := g.Config.Tendermint != nil && g.Config.Tendermint.IsAggregatedSeal(common.Big1)
+		committee, err = extractCommittee(g.Config.AutonityContractConfig.Users, withBLSKeys)
 		if err != nil {
 			return nil, err
 		}
@@ -372,6 +380,11 @@ func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
 	if err := g.Config.CheckConfigForkOrder(); err != nil {
 		return nil, err
 	}
+	if g.Config.Tendermint != nil {
+		if err := g.Config.Tendermint.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	block, err := g.ToBlock(db)
 	if err != nil {
@@ -408,8 +421,9 @@ func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
 
 // extractCommittee takes a slice of autonity users and extracts the validators
 // into a new type 'types.Committee' which is returned. It returns an error if
-// the provided users contained no validators.
-func extractCommittee(users []params.User) (types.Committee, error) {
+// the provided users contained no validators. The BLS public keys of the
+// validators are only kept if withBLSKeys is set.
+func extractCommittee(users []params.User, withBLSKeys bool) (types.Committee, error) {
 	var committee types.Committee
 	for _, v := range users {
 		if v.Type == params.UserValidator {
@@ -417,6 +431,10 @@ func extractCommittee(users []params.User) (types.Committee, error) {
 				Address:     *v.Address,
 				VotingPower: new(big.Int).SetUint64(v.Stake),
 			}
+			if withBLSKeys && len(v.BLSKey) > 0 {
+				// The proof of possession was checked when preparing the genesis.
+				member.BLSKey = common.CopyBytes(v.BLSKey[:bls.PublicKeyLength])
+			}
 			committee = append(committee, member)
 		}
 	}
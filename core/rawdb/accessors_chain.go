@@ -108,6 +108,77 @@ func GetKeyValue(db ethdb.KeyValueReader, key []byte) ([]byte, error) {
 	return bytes, nil
 }
 
+// WriteAutonityContractABI stores the autonity contract ABI activated at the
+// given block number.
+func WriteAutonityContractABI(db ethdb.KeyValueWriter, number uint64, abi string) {
+	if err := db.Put(autonityABIKey(number), []byte(abi)); err != nil {
+		log.Crit("Failed to store autonity contract ABI", "err", err)
+	}
+}
+
+// ReadAutonityContractABI retrieves the autonity contract ABI active at the
+// given block number, that is the last one activated at or before it. It
+// returns an empty string if no ABI was recorded up to this block.
+func ReadAutonityContractABI(db ethdb.Iteratee, number uint64) string {
+	it := db.NewIterator(autonityABIPrefix, nil)
+	defer it.Release()
+
+	var abi string
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(autonityABIPrefix)+8 {
+			continue
+		}
+		// Keys are sorted by block number, the following ones are activated later.
+		if binary.BigEndian.Uint64(key[len(autonityABIPrefix):]) > number {
+			break
+		}
+		abi = string(it.Value())
+	}
+	return abi
+}
+
+// DeleteAutonityContractABI removes the autonity contract ABI activated at the
+// given block number.
+func DeleteAutonityContractABI(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(autonityABIKey(number)); err != nil {
+		log.Crit("Failed to delete autonity contract ABI", "err", err)
+	}
+}
+
+// ReadRewardDistribution retrieves the distribution of the rewards of the
+// given block, or nil if it was not recorded.
+func ReadRewardDistribution(db ethdb.Reader, hash common.Hash, number uint64) *types.RewardDistribution {
+	data, _ := db.Get(rewardsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	rewards := new(types.RewardDistribution)
+	if err := rlp.DecodeBytes(data, rewards); err != nil {
+		log.Error("Invalid reward distribution RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return rewards
+}
+
+// WriteRewardDistribution stores the distribution of the rewards of a block.
+func WriteRewardDistribution(db ethdb.KeyValueWriter, rewards *types.RewardDistribution) {
+	data, err := rlp.EncodeToBytes(rewards)
+	if err != nil {
+		log.Crit("Failed to RLP encode reward distribution", "err", err)
+	}
+	if err := db.Put(rewardsKey(rewards.Number, rewards.Hash), data); err != nil {
+		log.Crit("Failed to store reward distribution", "err", err)
+	}
+}
+
+// DeleteRewardDistribution removes the distribution of the rewards of a block.
+func DeleteRewardDistribution(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(rewardsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete reward distribution", "err", err)
+	}
+}
+
 // DeleteCanonicalHash removes the number to hash canonical mapping.
 func DeleteCanonicalHash(db ethdb.KeyValueWriter, number uint64) {
 	if err := db.Delete(headerHashKey(number)); err != nil {
@@ -760,6 +831,7 @@ func WriteAncientBlock(db ethdb.AncientWriter, block *types.Block, receipts type
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteRewardDistribution(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 	DeleteTd(db, hash, number)
@@ -498,3 +498,59 @@ func TestCanonicalHashIteration(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the autonity contract ABI active at a block is the last one
+// activated at or before it.
+func TestAutonityContractABIStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	if abi := ReadAutonityContractABI(db, 10); abi != "" {
+		t.Fatalf("Non existent ABI returned: %v", abi)
+	}
+	WriteAutonityContractABI(db, 0, "genesis")
+	WriteAutonityContractABI(db, 5, "upgrade-1")
+	WriteAutonityContractABI(db, 300, "upgrade-2")
+
+	var cases = []struct {
+		number uint64
+		expect string
+	}{
+		{0, "genesis"},
+		{4, "genesis"},
+		{5, "upgrade-1"},
+		{299, "upgrade-1"},
+		{300, "upgrade-2"},
+		{1000, "upgrade-2"},
+	}
+	for i, c := range cases {
+		if abi := ReadAutonityContractABI(db, c.number); abi != c.expect {
+			t.Fatalf("Case %d failed, want %v, got %v", i, c.expect, abi)
+		}
+	}
+
+	DeleteAutonityContractABI(db, 300)
+	if abi := ReadAutonityContractABI(db, 1000); abi != "upgrade-1" {
+		t.Fatalf("Deleted ABI returned: %v", abi)
+	}
+}
+
+// Tests reward distribution storage and retrieval operations.
+func TestRewardDistributionStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	rewards := &types.RewardDistribution{
+		Number:  10,
+		Hash:    common.HexToHash("0x10"),
+		Amount:  big.NewInt(3),
+		Rewards: []types.Reward{{Address: common.HexToAddress("0x01"), Amount: big.NewInt(3)}},
+	}
+	if entry := ReadRewardDistribution(db, rewards.Hash, rewards.Number); entry != nil {
+		t.Fatalf("Non existent reward distribution returned: %v", entry)
+	}
+	WriteRewardDistribution(db, rewards)
+	if entry := ReadRewardDistribution(db, rewards.Hash, rewards.Number); !reflect.DeepEqual(entry, rewards) {
+		t.Fatalf("Retrieved reward distribution mismatch: have %v, want %v", entry, rewards)
+	}
+	DeleteRewardDistribution(db, rewards.Hash, rewards.Number)
+	if entry := ReadRewardDistribution(db, rewards.Hash, rewards.Number); entry != nil {
+		t.Fatalf("Deleted reward distribution returned: %v", entry)
+	}
+}
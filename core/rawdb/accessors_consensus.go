@@ -0,0 +1,133 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/log"
+)
+
+// ReadConsensusWALEntries retrieves all the consensus write-ahead log entries
+// recorded at the given height, in the order they were written.
+func ReadConsensusWALEntries(db ethdb.Iteratee, height uint64) [][]byte {
+	prefix := consensusWALKeyPrefix(height)
+
+	var entries [][]byte
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+8 {
+			entries = append(entries, common.CopyBytes(it.Value()))
+		}
+	}
+	return entries
+}
+
+// WriteConsensusWALEntry stores a consensus write-ahead log entry at the given
+// height and sequence number.
+func WriteConsensusWALEntry(db ethdb.KeyValueWriter, height uint64, seq uint64, entry []byte) {
+	if err := db.Put(consensusWALKey(height, seq), entry); err != nil {
+		log.Crit("Failed to store consensus WAL entry", "err", err)
+	}
+}
+
+// DeleteConsensusWALBelow removes all the consensus write-ahead log entries
+// recorded at a height lower than the given one.
+func DeleteConsensusWALBelow(db ethdb.KeyValueStore, height uint64) {
+	it := db.NewIterator(consensusWALPrefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(consensusWALPrefix)+16 {
+			continue
+		}
+		// Keys are sorted by height, we can stop at the first one we must keep.
+		if binary.BigEndian.Uint64(key[len(consensusWALPrefix):]) >= height {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			log.Crit("Failed to delete consensus WAL entry", "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete consensus WAL entries", "err", err)
+	}
+}
+
+// ReadConsensusEvidence retrieves all the misbehaviour evidence recorded at
+// the given height.
+func ReadConsensusEvidence(db ethdb.Iteratee, height uint64) [][]byte {
+	return readConsensusEvidence(db, consensusEvidenceKeyPrefix(height))
+}
+
+// ReadAllConsensusEvidence retrieves all the misbehaviour evidence recorded,
+// sorted by height.
+func ReadAllConsensusEvidence(db ethdb.Iteratee) [][]byte {
+	return readConsensusEvidence(db, consensusEvidencePrefix)
+}
+
+func readConsensusEvidence(db ethdb.Iteratee, prefix []byte) [][]byte {
+	var evidence [][]byte
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(consensusEvidencePrefix)+8+common.HashLength {
+			evidence = append(evidence, common.CopyBytes(it.Value()))
+		}
+	}
+	return evidence
+}
+
+// HasConsensusEvidence verifies the existence of the evidence with the given
+// hash at the given height.
+func HasConsensusEvidence(db ethdb.KeyValueReader, height uint64, hash common.Hash) bool {
+	has, _ := db.Has(consensusEvidenceKey(height, hash))
+	return has
+}
+
+// WriteConsensusEvidence stores a misbehaviour evidence at the given height.
+func WriteConsensusEvidence(db ethdb.KeyValueWriter, height uint64, hash common.Hash, evidence []byte) {
+	if err := db.Put(consensusEvidenceKey(height, hash), evidence); err != nil {
+		log.Crit("Failed to store consensus evidence", "err", err)
+	}
+}
+
+// ReadConsensusLastSigned retrieves the last consensus step the node signed
+// for, nil if it never signed any.
+func ReadConsensusLastSigned(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(consensusLastSignedKey)
+	return data
+}
+
+// WriteConsensusLastSigned stores the last consensus step the node signed for
+// and syncs it to disk, so that it is not lost in a crash once signed.
+func WriteConsensusLastSigned(db ethdb.Batcher, step []byte) {
+	batch := db.NewBatch()
+	if err := batch.Put(consensusLastSignedKey, step); err != nil {
+		log.Crit("Failed to store last signed consensus step", "err", err)
+	}
+	if err := batch.WriteSync(); err != nil {
+		log.Crit("Failed to store last signed consensus step", "err", err)
+	}
+}
@@ -60,6 +60,9 @@ var (
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	fastTxLookupLimitKey = []byte("FastTransactionLookupLimit")
 
+	// consensusLastSignedKey tracks the last consensus step the node signed for.
+	consensusLastSignedKey = []byte("TendermintLastSigned")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -78,6 +81,12 @@ var (
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-") // config prefix for the db
 
+	consensusWALPrefix      = []byte("tendermint-wal-")      // consensusWALPrefix + height (uint64 big endian) + seq (uint64 big endian) -> WAL entry
+	consensusEvidencePrefix = []byte("tendermint-evidence-") // consensusEvidencePrefix + height (uint64 big endian) + hash -> evidence
+
+	autonityABIPrefix = []byte("autonity-abi-")     // autonityABIPrefix + num (uint64 big endian) -> autonity contract ABI activated at num
+	rewardsPrefix     = []byte("autonity-rewards-") // rewardsPrefix + num (uint64 big endian) + hash -> reward distribution of the block
+
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 
@@ -215,3 +224,33 @@ func IsCodeKey(key []byte) (bool, []byte) {
 func configKey(hash common.Hash) []byte {
 	return append(configPrefix, hash.Bytes()...)
 }
+
+// consensusWALKeyPrefix = consensusWALPrefix + height (uint64 big endian)
+func consensusWALKeyPrefix(height uint64) []byte {
+	return append(consensusWALPrefix, encodeBlockNumber(height)...)
+}
+
+// consensusWALKey = consensusWALPrefix + height (uint64 big endian) + seq (uint64 big endian)
+func consensusWALKey(height uint64, seq uint64) []byte {
+	return append(consensusWALKeyPrefix(height), encodeBlockNumber(seq)...)
+}
+
+// consensusEvidenceKeyPrefix = consensusEvidencePrefix + height (uint64 big endian)
+func consensusEvidenceKeyPrefix(height uint64) []byte {
+	return append(consensusEvidencePrefix, encodeBlockNumber(height)...)
+}
+
+// consensusEvidenceKey = consensusEvidencePrefix + height (uint64 big endian) + hash
+func consensusEvidenceKey(height uint64, hash common.Hash) []byte {
+	return append(consensusEvidenceKeyPrefix(height), hash.Bytes()...)
+}
+
+// autonityABIKey = autonityABIPrefix + num (uint64 big endian)
+func autonityABIKey(number uint64) []byte {
+	return append(autonityABIPrefix, encodeBlockNumber(number)...)
+}
+
+// rewardsKey = rewardsPrefix + num (uint64 big endian) + hash
+func rewardsKey(number uint64, hash common.Hash) []byte {
+	return append(append(rewardsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
@@ -186,6 +186,11 @@ func (b *tableBatch) Write() error {
 	return b.batch.Write()
 }
 
+// WriteSync flushes any accumulated data to disk and syncs it.
+func (b *tableBatch) WriteSync() error {
+	return b.batch.WriteSync()
+}
+
 // Reset resets the batch for reuse.
 func (b *tableBatch) Reset() {
 	b.batch.Reset()
@@ -0,0 +1,203 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/clearmatics/autonity/autonity"
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/state/snapshot"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/trie"
+)
+
+var (
+	testAccount = common.HexToAddress("0x0100000000000000000000000000000000000000")
+	staleCode   = []byte{0x60, 0x01}
+	testABI     = []byte(`[{"type":"function","name":"f"}]`)
+	testEnodes  = []string{"enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303"}
+)
+
+// testChain is a database holding the states of a genesis, a stale and a head
+// block, with a snapshot of the head state.
+type testChain struct {
+	db                         ethdb.Database
+	head                       *types.Header
+	genesisRoot, stale, target common.Hash
+}
+
+// commitState applies update on top of the state root and commits the result
+// to the database.
+func commitState(t *testing.T, db ethdb.Database, root common.Hash, update func(*state.StateDB)) common.Hash {
+	sdb := state.NewDatabase(db)
+	statedb, err := state.New(root, sdb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update(statedb)
+	if root, err = statedb.Commit(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func newTestChain(t *testing.T) *testChain {
+	db := rawdb.NewMemoryDatabase()
+	genesisRoot := commitState(t, db, common.Hash{}, func(statedb *state.StateDB) {
+		statedb.SetCode(autonity.ContractAddress, []byte{0x60, 0x00})
+		statedb.SetState(autonity.ContractAddress, common.Hash{1}, common.Hash{1})
+	})
+	stale := commitState(t, db, genesisRoot, func(statedb *state.StateDB) {
+		statedb.SetState(autonity.ContractAddress, common.Hash{1}, common.Hash{2})
+		statedb.SetState(autonity.ContractAddress, common.Hash{2}, common.Hash{2})
+		statedb.AddBalance(testAccount, big.NewInt(1))
+		statedb.SetCode(testAccount, staleCode)
+	})
+	target := commitState(t, db, stale, func(statedb *state.StateDB) {
+		statedb.SetState(autonity.ContractAddress, common.Hash{2}, common.Hash{3})
+		statedb.AddBalance(testAccount, big.NewInt(1))
+		statedb.SetCode(testAccount, nil)
+	})
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Root: genesisRoot})
+	rawdb.WriteBlock(db, genesis)
+	rawdb.WriteCanonicalHash(db, genesis.Hash(), 0)
+
+	head := &types.Header{Number: big.NewInt(2), ParentHash: genesis.Hash(), Root: target}
+	rawdb.WriteBlock(db, types.NewBlockWithHeader(head))
+	rawdb.WriteCanonicalHash(db, head.Hash(), 2)
+	rawdb.WriteHeadBlockHash(db, head.Hash())
+
+	// The keys kept outside of the state trie.
+	if err := db.Put([]byte(autonity.ABISPEC), testABI); err != nil {
+		t.Fatal(err)
+	}
+	rawdb.WriteEnodeWhitelist(db, &types.Nodes{StrList: testEnodes})
+
+	snaptree := snapshot.New(db, trie.NewDatabase(db), 16, target, false)
+	if _, err := snaptree.Journal(target); err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{db: db, head: head, genesisRoot: genesisRoot, stale: stale, target: target}
+}
+
+// checkPruned checks that the stale state was deleted while the target and the
+// genesis states, the state bloom and the keys kept outside of the state trie
+// were left.
+func checkPruned(t *testing.T, chain *testChain, datadir string) {
+	if blob := rawdb.ReadTrieNode(chain.db, chain.stale); len(blob) != 0 {
+		t.Error("stale state root not pruned")
+	}
+	if code := rawdb.ReadCode(chain.db, crypto.Keccak256Hash(staleCode)); len(code) != 0 {
+		t.Error("stale code not pruned")
+	}
+	for _, root := range []common.Hash{chain.genesisRoot, chain.target} {
+		statedb, err := state.New(root, state.NewDatabase(chain.db), nil)
+		if err != nil {
+			t.Fatalf("state %x: %v", root, err)
+		}
+		it := statedb.StorageTrie(autonity.ContractAddress).NodeIterator(nil)
+		for it.Next(true) {
+		}
+		if err := it.Error(); err != nil {
+			t.Errorf("autonity contract storage at state %x: %v", root, err)
+		}
+		if len(statedb.GetCode(autonity.ContractAddress)) == 0 {
+			t.Errorf("autonity contract code at state %x missing", root)
+		}
+	}
+	if abi, _ := chain.db.Get([]byte(autonity.ABISPEC)); string(abi) != string(testABI) {
+		t.Errorf("ABISPEC: have %s, want %s", abi, testABI)
+	}
+	if whitelist := rawdb.ReadEnodeWhitelist(chain.db); len(whitelist.StrList) != len(testEnodes) {
+		t.Errorf("enode whitelist: have %v, want %v", whitelist.StrList, testEnodes)
+	}
+	if path, _, err := findBloomFilter(datadir); err != nil || path != "" {
+		t.Errorf("state bloom left: %q %v", path, err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(datadir)
+
+	chain := newTestChain(t)
+	p, err := NewPruner(chain.db, chain.head, datadir, "", 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Not enough layers for the default target.
+	if err := p.Prune(common.Hash{}); err == nil {
+		t.Fatal("pruning without enough snapshot layers succeeded")
+	}
+	if err := p.Prune(chain.target); err != nil {
+		t.Fatal(err)
+	}
+	checkPruned(t, chain, datadir)
+}
+
+func TestRecoverPruning(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(datadir)
+
+	chain := newTestChain(t)
+	if err := RecoverPruning(datadir, chain.db, ""); err != nil {
+		t.Fatal(err)
+	}
+	if blob := rawdb.ReadTrieNode(chain.db, chain.stale); len(blob) == 0 {
+		t.Fatal("state pruned without a state bloom")
+	}
+	// Commit the state bloom as done by an interrupted pruning.
+	snaptree, err := snapshot.Load(chain.db, trie.NewDatabase(chain.db), 16, chain.target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bloom, err := newStateBloomWithSize(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.GenerateTrie(snaptree, chain.target, chain.db, bloom); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractGenesis(chain.db, bloom); err != nil {
+		t.Fatal(err)
+	}
+	name := bloomFilterName(datadir, chain.target)
+	if err := bloom.Commit(name, name+stateBloomFileTempSuffix); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecoverPruning(datadir, chain.db, ""); err != nil {
+		t.Fatal(err)
+	}
+	checkPruned(t, chain, datadir)
+}
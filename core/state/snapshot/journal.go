@@ -62,7 +62,12 @@ type journalStorage struct {
 }
 
 // loadSnapshot loads a pre-existing state snapshot backed by a key-value store.
-func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash) (snapshot, error) {
+//
+// In offline mode, used by the tools working on a stopped node, a snapshot not
+// matching the expected head is accepted as the chain head may have been rewound
+// by an interrupted pruning, while a snapshot not fully generated is rejected
+// instead of resuming its generation.
+func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash, offline bool) (snapshot, error) {
 	// Retrieve the block number and hash of the snapshot, failing if no snapshot
 	// is present in the database (or crashed mid-update).
 	baseRoot := rawdb.ReadSnapshotRoot(diskdb)
@@ -88,6 +93,9 @@ func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int,
 	if err := r.Decode(&generator); err != nil {
 		return nil, fmt.Errorf("failed to load snapshot progress marker: %v", err)
 	}
+	if offline && !generator.Done {
+		return nil, errors.New("snapshot is not fully generated")
+	}
 	// Load all the snapshot diffs from the journal
 	snapshot, err := loadDiffLayer(base, r)
 	if err != nil {
@@ -96,7 +104,10 @@ func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int,
 	// Entire snapshot journal loaded, sanity check the head and return
 	// Journal doesn't exist, don't worry if it's not supposed to
 	if head := snapshot.Root(); head != root {
-		return nil, fmt.Errorf("head doesn't match snapshot: have %#x, want %#x", head, root)
+		if !offline {
+			return nil, fmt.Errorf("head doesn't match snapshot: have %#x, want %#x", head, root)
+		}
+		log.Warn("Snapshot is not continuous with chain", "snaproot", head, "chainroot", root)
 	}
 	// Everything loaded correctly, resume any suspended operations
 	if !generator.Done {
@@ -179,7 +179,7 @@ func New(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root comm
 		defer snap.waitBuild()
 	}
 	// Attempt to load a previously persisted snapshot and rebuild one if failed
-	head, err := loadSnapshot(diskdb, triedb, cache, root)
+	head, err := loadSnapshot(diskdb, triedb, cache, root, false)
 	if err != nil {
 		log.Warn("Failed to load snapshot, regenerating", "err", err)
 		snap.Rebuild(root)
@@ -193,6 +193,28 @@ func New(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root comm
 	return snap
 }
 
+// Load attempts to load an already existing and fully generated snapshot from
+// a persistent key-value store, for the tools working on the database of a
+// stopped node. Unlike New, it never rebuilds nor resumes the generation of the
+// snapshot but fails instead.
+func Load(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash) (*Tree, error) {
+	head, err := loadSnapshot(diskdb, triedb, cache, root, true)
+	if err != nil {
+		return nil, err
+	}
+	snap := &Tree{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  cache,
+		layers: make(map[common.Hash]snapshot),
+	}
+	for head != nil {
+		snap.layers[head.Root()] = head
+		head = head.Parent()
+	}
+	return snap, nil
+}
+
 // waitBuild blocks until the snapshot finishes rebuilding. This method is meant
 // to  be used by tests to ensure we're testing what we believe we are.
 func (t *Tree) waitBuild() {
@@ -223,6 +245,23 @@ func (t *Tree) Snapshot(blockRoot common.Hash) Snapshot {
 	return t.layers[blockRoot]
 }
 
+// Snapshots returns up to limits layers, starting from the one of the given
+// block root and going down the tree. The disk layer is left out if nodisk
+// is set.
+func (t *Tree) Snapshots(blockRoot common.Hash, limits int, nodisk bool) []Snapshot {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var layers []Snapshot
+	for layer := t.layers[blockRoot]; layer != nil && len(layers) < limits; layer = layer.Parent() {
+		if _, ok := layer.(*diskLayer); ok && nodisk {
+			break
+		}
+		layers = append(layers, layer)
+	}
+	return layers
+}
+
 // Update adds a new snapshot into the tree, if that can be linked to an existing
 // old parent. It is disallowed to insert a disk layer (the origin of all).
 func (t *Tree) Update(blockRoot common.Hash, parentRoot common.Hash, destructs map[common.Hash]struct{}, accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte) error {
@@ -77,9 +77,13 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 
 	var contractMinGasPrice = new(big.Int)
 	if p.autonityContract != nil {
-		minGasPrice, err := p.autonityContract.GetMinimumGasPrice(block, statedb)
-		if err == nil {
-			contractMinGasPrice.SetUint64(minGasPrice)
+		// The transactions of the block are priced on top of its parent, whose state is the one given,
+		// read from the state rather than the cache built from the events.
+		if parent := p.bc.GetHeader(block.ParentHash(), block.NumberU64()-1); parent != nil {
+			minGasPrice, err := p.autonityContract.ReadMinimumGasPrice(parent, statedb)
+			if err == nil {
+				contractMinGasPrice.SetUint64(minGasPrice)
+			}
 		}
 	}
 	// Iterate over and process the individual transactions
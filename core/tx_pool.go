@@ -64,6 +64,10 @@ var (
 	// configured for the transaction pool.
 	ErrUnderpriced = errors.New("transaction underpriced")
 
+	// ErrUnderpricedMinGasPrice is returned if a transaction's gas price is below
+	// the minimum set in the Autonity contract.
+	ErrUnderpricedMinGasPrice = errors.New("transaction gas price is less than Autonity contract minimum gas price")
+
 	// ErrReplaceUnderpriced is returned if a transaction is attempted to be replaced
 	// with a different one without the required price bump.
 	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
@@ -231,6 +235,7 @@ type TxPool struct {
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
+	minGasPrice   *big.Int       // Minimum gas price of the Autonity contract on top of the head
 
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
@@ -435,6 +440,24 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 	log.Info("Transaction pool price threshold updated", "price", price)
 }
 
+// setMinGasPrice updates the minimum gas price of the Autonity contract, and
+// drops all transactions below a raised one, the local ones included as no block
+// can include them anymore.
+func (pool *TxPool) setMinGasPrice(price *big.Int) {
+	raised := pool.minGasPrice == nil || price.Cmp(pool.minGasPrice) > 0
+	pool.minGasPrice = price
+	if !raised {
+		return
+	}
+	drop := pool.priced.Cap(price, newAccountSet(pool.signer))
+	for _, tx := range drop {
+		pool.removeTx(tx.Hash(), false)
+	}
+	if len(drop) > 0 {
+		log.Info("Transactions under the Autonity minimum gas price dropped", "price", price, "dropped", len(drop))
+	}
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *TxPool) Nonce(addr common.Address) uint64 {
@@ -563,14 +586,9 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return err
 	}
 
-	if pool.chain.GetAutonityContract() != nil {
-		if gp, err := pool.chain.GetAutonityContract().GetMinimumGasPrice(pool.chain.CurrentBlock(), pool.currentState); err == nil {
-			if new(big.Int).SetUint64(gp).Cmp(tx.GasPrice()) > 0 {
-				return errors.New("transaction gas price is less than Autonity evmContract minimum gas price")
-			}
-		} else {
-			return err
-		}
+	// Drop transactions under the minimum gas price of the Autonity contract, local ones included
+	if pool.minGasPrice != nil && tx.GasPriceIntCmp(pool.minGasPrice) < 0 {
+		return ErrUnderpricedMinGasPrice
 	}
 
 	if tx.Gas() < intrGas {
@@ -1191,6 +1209,15 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.pendingNonces = newTxNoncer(statedb)
 	pool.currentMaxGas = newHead.GasLimit
 
+	if contract := pool.chain.GetAutonityContract(); contract != nil {
+		price, err := contract.GetMinimumGasPrice(newHead, statedb)
+		if err != nil {
+			log.Error("Failed to read the minimum gas price", "err", err)
+		} else {
+			pool.setMinGasPrice(new(big.Int).SetUint64(price))
+		}
+	}
+
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	pool.senderCacher.recover(pool.signer, reinject)
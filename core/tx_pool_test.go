@@ -1425,6 +1425,61 @@ func TestTransactionPoolRepricingKeepsLocals(t *testing.T) {
 	validate()
 }
 
+// Tests that raising the minimum gas price of the Autonity contract drops the
+// transactions under it, local ones included, and rejects the new ones.
+func TestTransactionPoolMinGasPrice(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain, NewTxSenderCacher())
+	defer pool.Stop()
+
+	local, _ := crypto.GenerateKey()
+	remote, _ := crypto.GenerateKey()
+	for _, key := range []*ecdsa.PrivateKey{local, remote} {
+		pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	// Pending transactions priced 1 to 4 and a queued one priced 5 per account
+	for i := uint64(0); i < 4; i++ {
+		if err := pool.AddLocal(pricedTransaction(i, 100000, big.NewInt(int64(i+1)), local)); err != nil {
+			t.Fatal(err)
+		}
+		if err := pool.addRemoteSync(pricedTransaction(i, 100000, big.NewInt(int64(i+1)), remote)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pool.AddLocal(pricedTransaction(5, 100000, big.NewInt(5), local)); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(5, 100000, big.NewInt(5), remote)); err != nil {
+		t.Fatal(err)
+	}
+	pool.mu.Lock()
+	pool.setMinGasPrice(big.NewInt(3))
+	pool.mu.Unlock()
+	<-pool.requestPromoteExecutables(newAccountSet(pool.signer))
+
+	// The transactions priced 1 and 2 are dropped, the ones after are gapped
+	pending, queued := pool.Stats()
+	if pending != 0 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 0)
+	}
+	if queued != 6 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 6)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	if err := pool.AddLocal(pricedTransaction(0, 100000, big.NewInt(2), local)); err != ErrUnderpricedMinGasPrice {
+		t.Fatalf("adding underpriced local transaction error mismatch: have %v, want %v", err, ErrUnderpricedMinGasPrice)
+	}
+	if err := pool.AddLocal(pricedTransaction(0, 100000, big.NewInt(3), local)); err != nil {
+		t.Fatalf("failed to add local transaction at the minimum gas price: %v", err)
+	}
+}
+
 // Tests that when the pool reaches its global transaction limit, underpriced
 // transactions are gradually shifted out for more expensive ones and any gapped
 // pending transactions are moved into the queue.
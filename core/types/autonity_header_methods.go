@@ -35,3 +35,13 @@ func (c Committee) TotalVotingPower() uint64 {
 	}
 	return total
 }
+
+// HasBLSKeys returns whether every member of the committee has a BLS key.
+func (c Committee) HasBLSKeys() bool {
+	for _, m := range c {
+		if len(m.BLSKey) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -18,6 +18,7 @@ package types
 
 import (
 	"errors"
+	"math/big"
 	"strings"
 
 	"github.com/clearmatics/autonity/common"
@@ -50,6 +51,11 @@ var (
 	ErrEmptyCommittedSeals = errors.New("zero committed seals")
 	// ErrNegativeRound is returned if the round field is negative
 	ErrNegativeRound = errors.New("negative round")
+	// ErrInvalidAggregatedSeal is returned if the aggregated seal or its signers are malformed.
+	ErrInvalidAggregatedSeal = errors.New("invalid aggregated seal")
+
+	errInvalidCommitteeKeys = errors.New("committee BLS keys mismatch")
+	errInvalidHeaderExtra   = errors.New("invalid header extensions")
 )
 
 // BFTFilteredHeader returns a filtered header which some information (like seal, committed seals)
@@ -60,6 +66,8 @@ func BFTFilteredHeader(h *Header, keepSeal bool) *Header {
 		newHeader.ProposerSeal = []byte{}
 	}
 	newHeader.CommittedSeals = [][]byte{}
+	newHeader.AggregatedSeal = nil
+	newHeader.SealSigners = nil
 	newHeader.Round = 0
 	newHeader.Extra = []byte{}
 	return newHeader
@@ -141,6 +149,48 @@ func WriteCommittedSeals(h *Header, committedSeals [][]byte) error {
 	return nil
 }
 
+// WriteAggregatedSeal writes the aggregated committed seal of a block header
+// along with the bitmap of the parent committee members having signed it.
+func WriteAggregatedSeal(h *Header, seal []byte, signers []byte) error {
+	if len(seal) == 0 || len(signers) == 0 {
+		return ErrInvalidAggregatedSeal
+	}
+	h.AggregatedSeal = make([]byte, len(seal))
+	copy(h.AggregatedSeal, seal)
+	h.SealSigners = make([]byte, len(signers))
+	copy(h.SealSigners, signers)
+	return nil
+}
+
+// SealSignersBitmap returns the bitmap of the given committee indexes, as
+// stored in Header.SealSigners.
+func SealSignersBitmap(size int, indexes []int) []byte {
+	bitmap := make([]byte, (size+7)/8)
+	for _, i := range indexes {
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+	return bitmap
+}
+
+// SealSignerIndexes returns the committee indexes set in the bitmap, it fails
+// if the bitmap does not match a committee of the given size.
+func SealSignerIndexes(size int, bitmap []byte) ([]int, error) {
+	if len(bitmap) != (size+7)/8 {
+		return nil, ErrInvalidAggregatedSeal
+	}
+	var indexes []int
+	for i := 0; i < len(bitmap)*8; i++ {
+		if bitmap[i/8]&(1<<uint(i%8)) == 0 {
+			continue
+		}
+		if i >= size {
+			return nil, ErrInvalidAggregatedSeal
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes, nil
+}
+
 func RLPHash(v interface{}) (h common.Hash) {
 	hw := sha3.NewLegacyKeccak256()
 	rlp.Encode(hw, v)
@@ -160,6 +210,24 @@ func GetSignatureAddress(data []byte, sig []byte) (common.Address, error) {
 	return crypto.PubkeyToAddress(*pubkey), nil
 }
 
+// BlockSigner is a committee member which sealed a block.
+type BlockSigner struct {
+	Address     common.Address `json:"address"`
+	VotingPower *big.Int       `json:"votingPower"`
+}
+
+// BlockSigners are the committee members which sealed a block, against the
+// quorum of the committee which decided it.
+type BlockSigners struct {
+	Number      uint64         `json:"number"`
+	Hash        common.Hash    `json:"hash"`
+	Round       uint64         `json:"round"`
+	Proposer    common.Address `json:"proposer"`
+	Signers     []BlockSigner  `json:"signers"`
+	VotingPower uint64         `json:"votingPower"` // Voting power of the signers
+	Quorum      uint64         `json:"quorum"`
+}
+
 func (c Committee) String() string {
 	var ret string
 	for _, val := range c {
@@ -168,6 +236,19 @@ func (c Committee) String() string {
 	return ret
 }
 
+// Hash returns the hash of the committee, BLS keys included, which the headers
+// within an epoch carry in place of the committee of the epoch header.
+func (c Committee) Hash() common.Hash {
+	members := make([]committeeMemberText, len(c))
+	for i, member := range c {
+		members[i] = committeeMemberText{Address: member.Address, VotingPower: member.VotingPower}
+		if len(member.BLSKey) > 0 {
+			members[i].BLSKey = [][]byte{member.BLSKey}
+		}
+	}
+	return rlpHash(members)
+}
+
 func (c Committee) Len() int {
 	return len(c)
 }
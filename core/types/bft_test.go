@@ -17,11 +17,13 @@
 package types
 
 import (
+	"bytes"
 	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/rlp"
 )
 
 func TestHeaderHash(t *testing.T) {
@@ -143,3 +145,115 @@ func setExtra(h Header, hExtra headerExtra) Header {
 
 	return h
 }
+
+func TestHeaderAggregatedSeal(t *testing.T) {
+	header := &Header{
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		MixDigest:  BFTDigest,
+		Committee: Committee{
+			{
+				Address:     common.HexToAddress("0x1234566"),
+				VotingPower: big.NewInt(12),
+				BLSKey:      common.Hex2Bytes("b15b15"),
+			},
+			{
+				Address:     common.HexToAddress("0x13371337"),
+				VotingPower: big.NewInt(1337),
+			},
+		},
+	}
+	hash := header.Hash()
+
+	withoutKeys := CopyHeader(header)
+	withoutKeys.Committee[0].BLSKey = nil
+	if withoutKeys.Hash() == hash {
+		t.Fatal("committee BLS keys not part of the header hash")
+	}
+
+	if err := WriteAggregatedSeal(header, common.Hex2Bytes("a99a99"), SealSignersBitmap(2, []int{0, 1})); err != nil {
+		t.Fatal(err)
+	}
+	if header.Hash() != hash {
+		t.Fatal("aggregated seal is part of the header hash")
+	}
+
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Header
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec.Committee[0].BLSKey, header.Committee[0].BLSKey) || len(dec.Committee[1].BLSKey) != 0 {
+		t.Fatalf("committee BLS keys mismatch: have %v", dec.Committee)
+	}
+	if !bytes.Equal(dec.AggregatedSeal, header.AggregatedSeal) || !bytes.Equal(dec.SealSigners, header.SealSigners) {
+		t.Fatal("aggregated seal mismatch")
+	}
+	if dec.Hash() != hash {
+		t.Fatal("header hash mismatch after decoding")
+	}
+}
+
+func TestSealSigners(t *testing.T) {
+	bitmap := SealSignersBitmap(10, []int{0, 3, 9})
+	indexes, err := SealSignerIndexes(10, bitmap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indexes, []int{0, 3, 9}) {
+		t.Fatalf("signers mismatch: have %v", indexes)
+	}
+	if _, err := SealSignerIndexes(9, bitmap); err != ErrInvalidAggregatedSeal {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrInvalidAggregatedSeal)
+	}
+	if _, err := SealSignerIndexes(17, bitmap); err != ErrInvalidAggregatedSeal {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrInvalidAggregatedSeal)
+	}
+}
+
+func TestHeaderCommitteeHash(t *testing.T) {
+	committee := Committee{
+		{
+			Address:     common.HexToAddress("0x1234566"),
+			VotingPower: big.NewInt(12),
+			BLSKey:      common.Hex2Bytes("b15b15"),
+		},
+	}
+	header := &Header{
+		Number:        big.NewInt(2),
+		Difficulty:    big.NewInt(1),
+		MixDigest:     BFTDigest,
+		CommitteeHash: committee.Hash(),
+	}
+	hash := header.Hash()
+
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Header
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.CommitteeHash != header.CommitteeHash || len(dec.Committee) != 0 {
+		t.Fatalf("committee hash mismatch: have %v, %v", dec.CommitteeHash, dec.Committee)
+	}
+	if dec.AggregatedSeal != nil || dec.SealSigners != nil {
+		t.Fatal("aggregated seal set after decoding")
+	}
+
+	// The committee resolved from the epoch header is not part of the hash.
+	resolved := CopyHeader(&dec)
+	resolved.Committee = committee
+	if resolved.Hash() != hash {
+		t.Fatal("resolved committee is part of the header hash")
+	}
+
+	withoutKeys := Committee{{Address: committee[0].Address, VotingPower: committee[0].VotingPower}}
+	if withoutKeys.Hash() == committee.Hash() {
+		t.Fatal("committee BLS keys not part of the committee hash")
+	}
+}
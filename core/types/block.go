@@ -91,6 +91,11 @@ type Header struct {
 		for computing the sigHash.
 	*/
 	Committee Committee `json:"committee"           gencodec:"required"`
+	// CommitteeHash replaces the committee in the headers within an epoch,
+	// which keep the committee of the epoch header. The committee of such a
+	// header is not encoded, so that it can be resolved in memory without
+	// changing the header hash.
+	CommitteeHash common.Hash `json:"committeeHash,omitempty"`
 	// used for committee member lookup, lazily initialised.
 	committeeMap map[common.Address]*CommitteeMember
 	// Used to ensure the committeeMap is created only once.
@@ -100,16 +105,39 @@ type Header struct {
 	Round              uint64   `json:"round"               gencodec:"required"`
 	CommittedSeals     [][]byte `json:"committedSeals"      gencodec:"required"`
 	PastCommittedSeals [][]byte `json:"pastCommittedSeals"  gencodec:"required"`
+
+	/*
+		Aggregated committed seals, replacing CommittedSeals once the
+		aggregated seal fork is active. SealSigners is a bitmap of the
+		members of the parent committee having signed AggregatedSeal.
+	*/
+	AggregatedSeal []byte `json:"aggregatedSeal,omitempty"`
+	SealSigners    []byte `json:"sealSigners,omitempty"`
 }
 
 type CommitteeMember struct {
 	Address     common.Address `json:"address"            gencodec:"required"       abi:"addr"`
 	VotingPower *big.Int       `json:"votingPower"        gencodec:"required"`
+	// BLSKey is the BLS public key of the member, used to verify aggregated
+	// committed seals. It is encoded apart from the member in the header.
+	BLSKey []byte `json:"blsKey,omitempty"  rlp:"-"`
+}
+
+// committeeMemberText is the encoding of a committee member in its text form,
+// the BLS key is only appended when present.
+type committeeMemberText struct {
+	Address     common.Address
+	VotingPower *big.Int
+	BLSKey      [][]byte `rlp:"tail"`
 }
 
 // MarshalText encodes b as a hex string with 0x prefix.
 func (c *CommitteeMember) MarshalText() ([]byte, error) {
-	data, err := rlp.EncodeToBytes(c)
+	enc := committeeMemberText{Address: c.Address, VotingPower: c.VotingPower}
+	if len(c.BLSKey) > 0 {
+		enc.BLSKey = [][]byte{c.BLSKey}
+	}
+	data, err := rlp.EncodeToBytes(enc)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +151,15 @@ func (c *CommitteeMember) UnmarshalText(input []byte) error {
 	if err != nil {
 		return err
 	}
-	return rlp.DecodeBytes(b, &c)
+	var dec committeeMemberText
+	if err := rlp.DecodeBytes(b, &dec); err != nil {
+		return err
+	}
+	c.Address, c.VotingPower, c.BLSKey = dec.Address, dec.VotingPower, nil
+	if len(dec.BLSKey) > 0 {
+		c.BLSKey = dec.BLSKey[0]
+	}
+	return nil
 }
 
 type Committee []CommitteeMember
@@ -153,6 +189,18 @@ type headerExtra struct {
 	Round              uint64    `json:"round"               gencodec:"required"`
 	CommittedSeals     [][]byte  `json:"committedSeals"      gencodec:"required"`
 	PastCommittedSeals [][]byte  `json:"pastCommittedSeals"  gencodec:"required"`
+	// The extensions are kept at the tail so that the encoding of the headers
+	// without them is unchanged: the BLS fields, then the committee hash. The
+	// BLS fields are left empty in the headers with a committee hash only.
+	Extensions []rlp.RawValue `rlp:"tail"`
+}
+
+// headerBLSExtra holds the BLS fields of the header, CommitteeKeys follows the
+// order of the committee with empty entries for the members without key.
+type headerBLSExtra struct {
+	CommitteeKeys  [][]byte
+	AggregatedSeal []byte
+	SealSigners    []byte
 }
 
 // headerMarshaling is used by gencodec (which can be invoked bu running go
@@ -175,6 +223,8 @@ type headerMarshaling struct {
 	Round              hexutil.Uint64
 	CommittedSeals     []hexutil.Bytes
 	PastCommittedSeals []hexutil.Bytes
+	AggregatedSeal     hexutil.Bytes
+	SealSigners        hexutil.Bytes
 }
 
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
@@ -239,6 +289,36 @@ func (h *Header) DecodeRLP(s *rlp.Stream) error {
 		h.PastCommittedSeals = hExtra.PastCommittedSeals
 		h.ProposerSeal = hExtra.ProposerSeal
 		h.Round = hExtra.Round
+		// A header with a committee hash carries no committee of its own.
+		if len(hExtra.Extensions) > 2 {
+			return errInvalidHeaderExtra
+		}
+		if len(hExtra.Extensions) > 1 {
+			if err := rlp.DecodeBytes(hExtra.Extensions[1], &h.CommitteeHash); err != nil {
+				return err
+			}
+			if h.CommitteeHash == (common.Hash{}) || len(h.Committee) > 0 {
+				return errInvalidHeaderExtra
+			}
+		}
+		if len(hExtra.Extensions) > 0 {
+			var bls headerBLSExtra
+			if err := rlp.DecodeBytes(hExtra.Extensions[0], &bls); err != nil {
+				return err
+			}
+			if len(bls.CommitteeKeys) != len(h.Committee) {
+				return errInvalidCommitteeKeys
+			}
+			for i := range h.Committee {
+				if len(bls.CommitteeKeys[i]) > 0 {
+					h.Committee[i].BLSKey = bls.CommitteeKeys[i]
+				}
+			}
+			if len(bls.AggregatedSeal) > 0 || len(bls.SealSigners) > 0 {
+				h.AggregatedSeal = bls.AggregatedSeal
+				h.SealSigners = bls.SealSigners
+			}
+		}
 	} else {
 		h.Extra = origin.Extra
 	}
@@ -279,6 +359,27 @@ func (h *Header) EncodeRLP(w io.Writer) error {
 		CommittedSeals:     h.CommittedSeals,
 		PastCommittedSeals: h.PastCommittedSeals,
 	}
+	if h.CommitteeHash != (common.Hash{}) {
+		hExtra.Committee = Committee{}
+	}
+	bls := h.blsExtra()
+	if bls == nil && h.CommitteeHash != (common.Hash{}) {
+		bls = &headerBLSExtra{CommitteeKeys: [][]byte{}, AggregatedSeal: []byte{}, SealSigners: []byte{}}
+	}
+	if bls != nil {
+		ext, err := rlp.EncodeToBytes(bls)
+		if err != nil {
+			return err
+		}
+		hExtra.Extensions = append(hExtra.Extensions, ext)
+	}
+	if h.CommitteeHash != (common.Hash{}) {
+		ext, err := rlp.EncodeToBytes(h.CommitteeHash)
+		if err != nil {
+			return err
+		}
+		hExtra.Extensions = append(hExtra.Extensions, ext)
+	}
 
 	original := h.original()
 	if h.MixDigest == BFTDigest {
@@ -294,6 +395,44 @@ func (h *Header) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, *original)
 }
 
+// blsExtra returns the BLS fields of the header to encode, or nil if there are
+// none.
+func (h *Header) blsExtra() *headerBLSExtra {
+	// The committee of a header with a committee hash is not encoded.
+	committee := h.Committee
+	if h.CommitteeHash != (common.Hash{}) {
+		committee = nil
+	}
+	hasKey := false
+	keys := make([][]byte, len(committee))
+	for i, member := range committee {
+		keys[i] = member.BLSKey
+		if len(member.BLSKey) > 0 {
+			hasKey = true
+		}
+	}
+	if !hasKey && len(h.AggregatedSeal) == 0 && len(h.SealSigners) == 0 {
+		return nil
+	}
+	for i := range keys {
+		if keys[i] == nil {
+			keys[i] = []byte{}
+		}
+	}
+	aggregatedSeal, sealSigners := h.AggregatedSeal, h.SealSigners
+	if aggregatedSeal == nil {
+		aggregatedSeal = []byte{}
+	}
+	if sealSigners == nil {
+		sealSigners = []byte{}
+	}
+	return &headerBLSExtra{
+		CommitteeKeys:  keys,
+		AggregatedSeal: aggregatedSeal,
+		SealSigners:    sealSigners,
+	}
+}
+
 func (h *Header) original() *originalHeader {
 	return &originalHeader{
 		ParentHash:  h.ParentHash,
@@ -472,6 +611,10 @@ func CopyHeader(h *Header) *Header {
 				Address:     val.Address,
 				VotingPower: new(big.Int).Set(val.VotingPower),
 			}
+			if val.BLSKey != nil {
+				committee[i].BLSKey = make([]byte, len(val.BLSKey))
+				copy(committee[i].BLSKey, val.BLSKey)
+			}
 		}
 	}
 
@@ -516,11 +659,20 @@ func CopyHeader(h *Header) *Header {
 		MixDigest:          h.MixDigest,
 		Nonce:              h.Nonce,
 		Committee:          committee,
+		CommitteeHash:      h.CommitteeHash,
 		ProposerSeal:       proposerSeal,
 		Round:              h.Round,
 		CommittedSeals:     committedSeals,
 		PastCommittedSeals: pastCommittedSeals,
 	}
+	if h.AggregatedSeal != nil {
+		cpy.AggregatedSeal = make([]byte, len(h.AggregatedSeal))
+		copy(cpy.AggregatedSeal, h.AggregatedSeal)
+	}
+	if h.SealSigners != nil {
+		cpy.SealSigners = make([]byte, len(h.SealSigners))
+		copy(cpy.SealSigners, h.SealSigners)
+	}
 	return cpy
 }
 
@@ -32,10 +32,13 @@ func (h Header) MarshalJSON() ([]byte, error) {
 		MixDigest          common.Hash     `json:"mixHash"`
 		Nonce              BlockNonce      `json:"nonce"`
 		Committee          Committee       `json:"committee"           gencodec:"required"`
+		CommitteeHash      common.Hash     `json:"committeeHash,omitempty"`
 		ProposerSeal       hexutil.Bytes   `json:"proposerSeal"        gencodec:"required"`
 		Round              hexutil.Uint64  `json:"round"               gencodec:"required"`
 		CommittedSeals     []hexutil.Bytes `json:"committedSeals"      gencodec:"required"`
 		PastCommittedSeals []hexutil.Bytes `json:"pastCommittedSeals"  gencodec:"required"`
+		AggregatedSeal     hexutil.Bytes   `json:"aggregatedSeal,omitempty"`
+		SealSigners        hexutil.Bytes   `json:"sealSigners,omitempty"`
 		Hash               common.Hash     `json:"hash"`
 	}
 	var enc Header
@@ -55,6 +58,7 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	enc.MixDigest = h.MixDigest
 	enc.Nonce = h.Nonce
 	enc.Committee = h.Committee
+	enc.CommitteeHash = h.CommitteeHash
 	enc.ProposerSeal = h.ProposerSeal
 	enc.Round = hexutil.Uint64(h.Round)
 	if h.CommittedSeals != nil {
@@ -69,6 +73,8 @@ func (h Header) MarshalJSON() ([]byte, error) {
 			enc.PastCommittedSeals[k] = v
 		}
 	}
+	enc.AggregatedSeal = h.AggregatedSeal
+	enc.SealSigners = h.SealSigners
 	enc.Hash = h.Hash()
 	return json.Marshal(&enc)
 }
@@ -92,10 +98,13 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 		MixDigest          *common.Hash    `json:"mixHash"`
 		Nonce              *BlockNonce     `json:"nonce"`
 		Committee          *Committee      `json:"committee"           gencodec:"required"`
+		CommitteeHash      *common.Hash    `json:"committeeHash,omitempty"`
 		ProposerSeal       *hexutil.Bytes  `json:"proposerSeal"        gencodec:"required"`
 		Round              *hexutil.Uint64 `json:"round"               gencodec:"required"`
 		CommittedSeals     []hexutil.Bytes `json:"committedSeals"      gencodec:"required"`
 		PastCommittedSeals []hexutil.Bytes `json:"pastCommittedSeals"  gencodec:"required"`
+		AggregatedSeal     *hexutil.Bytes  `json:"aggregatedSeal,omitempty"`
+		SealSigners        *hexutil.Bytes  `json:"sealSigners,omitempty"`
 	}
 	var dec Header
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -163,6 +172,9 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'committee' for Header")
 	}
 	h.Committee = *dec.Committee
+	if dec.CommitteeHash != nil {
+		h.CommitteeHash = *dec.CommitteeHash
+	}
 	if dec.ProposerSeal == nil {
 		return errors.New("missing required field 'proposerSeal' for Header")
 	}
@@ -185,5 +197,11 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 	for k, v := range dec.PastCommittedSeals {
 		h.PastCommittedSeals[k] = v
 	}
+	if dec.AggregatedSeal != nil {
+		h.AggregatedSeal = *dec.AggregatedSeal
+	}
+	if dec.SealSigners != nil {
+		h.SealSigners = *dec.SealSigners
+	}
 	return nil
 }
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/clearmatics/autonity/common"
+)
+
+// Reward is the share of the fees of a block an account received.
+type Reward struct {
+	Address common.Address `json:"address"`
+	Amount  *big.Int       `json:"amount"`
+}
+
+// RewardDistribution is the redistribution of the fees of a block to the
+// stakeholders by the Autonity contract when the block is finalized.
+type RewardDistribution struct {
+	Number  uint64      `json:"number"`
+	Hash    common.Hash `json:"hash"`
+	Amount  *big.Int    `json:"amount"` // Sum of the rewards
+	Rewards []Reward    `json:"rewards"`
+}
@@ -0,0 +1,314 @@
+// Package bls implements BLS signatures over the BLS12-381 curve, with public
+// keys in G1 and signatures in G2, so that signatures of the same message can
+// be aggregated and verified with a single pairing check.
+//
+// Aggregating public keys is only safe against rogue key attacks if every key
+// comes with a proof of possession, see Prove and VerifyProof.
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/clearmatics/autonity/crypto/bls12381"
+)
+
+const (
+	// SecretKeyLength is the length in bytes of a serialized secret key.
+	SecretKeyLength = 32
+	// PublicKeyLength is the length in bytes of a serialized public key.
+	PublicKeyLength = 96
+	// SignatureLength is the length in bytes of a serialized signature.
+	SignatureLength = 192
+	// RegistrationLength is the length in bytes of a public key followed by
+	// its proof of possession, the form under which keys are registered.
+	RegistrationLength = PublicKeyLength + SignatureLength
+
+	// fieldElementLength is the number of uniform bytes reduced into an
+	// element of the base field when hashing to the curve.
+	fieldElementLength = 64
+)
+
+var (
+	// Domain separation tags of the messages signed and of the proofs of
+	// possession, those of the proof of possession scheme of the BLS signature
+	// draft so that signatures interoperate with other implementations.
+	signatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	proofDST     = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	// fieldModulus is the modulus of the base field of the curve.
+	fieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+	errInvalidSecretKey = errors.New("invalid BLS secret key")
+	errInvalidPublicKey = errors.New("invalid BLS public key")
+	errInvalidSignature = errors.New("invalid BLS signature")
+	errInvalidProof     = errors.New("invalid BLS proof of possession")
+	errNoSignature      = errors.New("no BLS signature to aggregate")
+)
+
+// SecretKey is a BLS secret key.
+type SecretKey struct {
+	scalar *big.Int
+}
+
+// PublicKey is a BLS public key, a point of G1.
+type PublicKey struct {
+	point *bls12381.PointG1
+}
+
+// Signature is a BLS signature, a point of G2.
+type Signature struct {
+	point *bls12381.PointG2
+}
+
+// GenerateKey creates a secret key from the randomness of r.
+func GenerateKey(r io.Reader) (*SecretKey, error) {
+	seed := make([]byte, 64)
+	if _, err := io.ReadFull(r, seed); err != nil {
+		return nil, err
+	}
+	return DeriveKey(seed)
+}
+
+// DeriveKey deterministically derives a secret key from the given seed, which
+// must hold at least 32 bytes of entropy.
+func DeriveKey(seed []byte) (*SecretKey, error) {
+	if len(seed) < SecretKeyLength {
+		return nil, errInvalidSecretKey
+	}
+	h := sha256.New()
+	h.Write([]byte("AUTONITY-BLS-KEYGEN-"))
+	h.Write(seed)
+	wide := h.Sum(nil)
+	h.Write([]byte{1})
+	wide = h.Sum(wide)
+	scalar := new(big.Int).Mod(new(big.Int).SetBytes(wide), bls12381.NewG1().Q())
+	if scalar.Sign() == 0 {
+		return nil, errInvalidSecretKey
+	}
+	return &SecretKey{scalar: scalar}, nil
+}
+
+// SecretKeyFromBytes decodes a serialized secret key.
+func SecretKeyFromBytes(b []byte) (*SecretKey, error) {
+	if len(b) != SecretKeyLength {
+		return nil, errInvalidSecretKey
+	}
+	scalar := new(big.Int).SetBytes(b)
+	if scalar.Sign() == 0 || scalar.Cmp(bls12381.NewG1().Q()) >= 0 {
+		return nil, errInvalidSecretKey
+	}
+	return &SecretKey{scalar: scalar}, nil
+}
+
+// Bytes serializes the secret key.
+func (sk *SecretKey) Bytes() []byte {
+	b := make([]byte, SecretKeyLength)
+	return sk.scalar.FillBytes(b)
+}
+
+// PublicKey returns the public key of sk.
+func (sk *SecretKey) PublicKey() *PublicKey {
+	g1 := bls12381.NewG1()
+	return &PublicKey{point: g1.MulScalar(g1.New(), g1.One(), sk.scalar)}
+}
+
+// Sign signs msg.
+func (sk *SecretKey) Sign(msg []byte) *Signature {
+	return sk.sign(msg, signatureDST)
+}
+
+// Prove returns the proof of possession of sk, a signature of its compressed
+// public key.
+func (sk *SecretKey) Prove() *Signature {
+	return sk.sign(compressG1(sk.PublicKey().Bytes()), proofDST)
+}
+
+func (sk *SecretKey) sign(msg, dst []byte) *Signature {
+	g2 := bls12381.NewG2()
+	return &Signature{point: g2.MulScalar(g2.New(), hashToG2(msg, dst), sk.scalar)}
+}
+
+// Registration returns the public key of sk followed by its proof of
+// possession.
+func (sk *SecretKey) Registration() []byte {
+	return append(sk.PublicKey().Bytes(), sk.Prove().Bytes()...)
+}
+
+// PublicKeyFromRegistration decodes a public key followed by its proof of
+// possession, it fails if the proof does not verify.
+func PublicKeyFromRegistration(b []byte) (*PublicKey, error) {
+	if len(b) != RegistrationLength {
+		return nil, errInvalidPublicKey
+	}
+	pk, err := PublicKeyFromBytes(b[:PublicKeyLength])
+	if err != nil {
+		return nil, err
+	}
+	proof, err := SignatureFromBytes(b[PublicKeyLength:])
+	if err != nil {
+		return nil, err
+	}
+	if !pk.VerifyProof(proof) {
+		return nil, errInvalidProof
+	}
+	return pk, nil
+}
+
+// PublicKeyFromBytes decodes a serialized public key, it fails if the key is
+// not a valid point of G1 or the identity.
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	g1 := bls12381.NewG1()
+	point, err := g1.FromBytes(b)
+	if err != nil {
+		return nil, errInvalidPublicKey
+	}
+	if g1.IsZero(point) || !g1.InCorrectSubgroup(point) {
+		return nil, errInvalidPublicKey
+	}
+	return &PublicKey{point: point}, nil
+}
+
+// Bytes serializes the public key.
+func (pk *PublicKey) Bytes() []byte {
+	return bls12381.NewG1().ToBytes(pk.point)
+}
+
+// Verify checks that sig is a signature of msg by pk.
+func (pk *PublicKey) Verify(msg []byte, sig *Signature) bool {
+	return verify(pk.point, hashToG2(msg, signatureDST), sig)
+}
+
+// VerifyProof checks that proof is the proof of possession of pk.
+func (pk *PublicKey) VerifyProof(proof *Signature) bool {
+	return verify(pk.point, hashToG2(compressG1(pk.Bytes()), proofDST), proof)
+}
+
+// AggregatePublicKeys returns the public key verifying the aggregate of the
+// signatures of the given keys on a same message.
+func AggregatePublicKeys(keys []*PublicKey) (*PublicKey, error) {
+	if len(keys) == 0 {
+		return nil, errInvalidPublicKey
+	}
+	g1 := bls12381.NewG1()
+	aggregate := g1.Zero()
+	for _, key := range keys {
+		g1.Add(aggregate, aggregate, key.point)
+	}
+	return &PublicKey{point: aggregate}, nil
+}
+
+// SignatureFromBytes decodes a serialized signature.
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	g2 := bls12381.NewG2()
+	point, err := g2.FromBytes(b)
+	if err != nil {
+		return nil, errInvalidSignature
+	}
+	if !g2.InCorrectSubgroup(point) {
+		return nil, errInvalidSignature
+	}
+	return &Signature{point: point}, nil
+}
+
+// Bytes serializes the signature.
+func (sig *Signature) Bytes() []byte {
+	return bls12381.NewG2().ToBytes(sig.point)
+}
+
+// AggregateSignatures combines signatures of a same message into one.
+func AggregateSignatures(sigs []*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, errNoSignature
+	}
+	g2 := bls12381.NewG2()
+	aggregate := g2.Zero()
+	for _, sig := range sigs {
+		g2.Add(aggregate, aggregate, sig.point)
+	}
+	return &Signature{point: aggregate}, nil
+}
+
+// verify checks that e(pk, h) == e(g1, sig).
+func verify(pk *bls12381.PointG1, h *bls12381.PointG2, sig *Signature) bool {
+	engine := bls12381.NewPairingEngine()
+	engine.AddPair(pk, h)
+	// AddPairInv negates the G1 point it is given in place.
+	engine.AddPairInv(engine.G1.One(), sig.point)
+	return engine.Check()
+}
+
+// hashToG2 hashes msg to a point of G2 with the BLS12381G2_XMD:SHA-256_SSWU_RO_
+// suite of RFC 9380, as the sum of the mapping of two field elements expanded
+// from msg. The cofactor is cleared by MapToCurve on each of them, which is
+// the same as clearing it on their sum.
+func hashToG2(msg, dst []byte) *bls12381.PointG2 {
+	g2 := bls12381.NewG2()
+	uniform := expandMessageXMD(msg, dst, 4*fieldElementLength)
+	point := g2.Zero()
+	for i := 0; i < 2; i++ {
+		mapped, err := g2.MapToCurve(hashToFp2(uniform[2*fieldElementLength*i:]))
+		if err != nil {
+			// Cannot happen, the field elements are reduced.
+			panic(err)
+		}
+		g2.Add(point, point, mapped)
+	}
+	return g2.Affine(point)
+}
+
+// hashToFp2 reduces the next two field elements of uniform into the serialized
+// element of Fp2 they are the coordinates c0 and c1 of, which bls12381 expects
+// as c1 followed by c0.
+func hashToFp2(uniform []byte) []byte {
+	out := make([]byte, 96)
+	for j := 0; j < 2; j++ {
+		element := new(big.Int).SetBytes(uniform[fieldElementLength*j : fieldElementLength*(j+1)])
+		element.Mod(element, fieldModulus)
+		element.FillBytes(out[48*(1-j) : 48*(2-j)])
+	}
+	return out
+}
+
+// expandMessageXMD implements expand_message_xmd of RFC 9380 with SHA-256,
+// expanding msg into length uniformly random bytes.
+func expandMessageXMD(msg, dst []byte, length int) []byte {
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, h.BlockSize()))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	out := make([]byte, 0, length)
+	bi := make([]byte, sha256.Size)
+	for i := 1; len(out) < length; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:length]
+}
+
+// compressG1 returns the compressed serialization of a point of G1, its x
+// coordinate flagged with the sign of y, in which public keys are signed by
+// their proof of possession.
+func compressG1(b []byte) []byte {
+	x, y := b[:48], b[48:]
+	compressed := append([]byte{}, x...)
+	compressed[0] |= 0x80
+	if new(big.Int).SetBytes(y).Cmp(new(big.Int).Rsh(fieldModulus, 1)) > 0 {
+		compressed[0] |= 0x20
+	}
+	return compressed
+}
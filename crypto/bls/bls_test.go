@@ -0,0 +1,203 @@
+package bls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/crypto/bls12381"
+)
+
+func newKeys(t *testing.T, n int) []*SecretKey {
+	keys := make([]*SecretKey, n)
+	for i := range keys {
+		key, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+func TestSignVerify(t *testing.T) {
+	key := newKeys(t, 1)[0]
+	msg := []byte("committed seal")
+	sig := key.Sign(msg)
+
+	if !key.PublicKey().Verify(msg, sig) {
+		t.Fatal("valid signature rejected")
+	}
+	if key.PublicKey().Verify([]byte("other message"), sig) {
+		t.Fatal("signature of another message accepted")
+	}
+	if newKeys(t, 1)[0].PublicKey().Verify(msg, sig) {
+		t.Fatal("signature of another key accepted")
+	}
+	// A proof of possession is not a signature of the public key.
+	if key.PublicKey().Verify(key.PublicKey().Bytes(), key.Prove()) {
+		t.Fatal("proof of possession accepted as a signature")
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	keys := newKeys(t, 2)
+	if !keys[0].PublicKey().VerifyProof(keys[0].Prove()) {
+		t.Fatal("valid proof rejected")
+	}
+	if keys[1].PublicKey().VerifyProof(keys[0].Prove()) {
+		t.Fatal("proof of another key accepted")
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	keys := newKeys(t, 2)
+	pk, err := PublicKeyFromRegistration(keys[0].Registration())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pk.Bytes(), keys[0].PublicKey().Bytes()) {
+		t.Fatal("registered public key mismatch")
+	}
+
+	forged := append(keys[0].PublicKey().Bytes(), keys[1].Prove().Bytes()...)
+	if _, err := PublicKeyFromRegistration(forged); err != errInvalidProof {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidProof)
+	}
+	if _, err := PublicKeyFromRegistration(keys[0].PublicKey().Bytes()); err != errInvalidPublicKey {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidPublicKey)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	keys := newKeys(t, 4)
+	msg := []byte("committed seal")
+
+	var (
+		sigs    []*Signature
+		pubKeys []*PublicKey
+	)
+	for _, key := range keys {
+		sigs = append(sigs, key.Sign(msg))
+		pubKeys = append(pubKeys, key.PublicKey())
+	}
+	sig, err := AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := AggregatePublicKeys(pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pubKey.Verify(msg, sig) {
+		t.Fatal("valid aggregated signature rejected")
+	}
+
+	partial, err := AggregatePublicKeys(pubKeys[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partial.Verify(msg, sig) {
+		t.Fatal("aggregated signature accepted without all the signers")
+	}
+
+	if _, err := AggregateSignatures(nil); err != errNoSignature {
+		t.Fatalf("error mismatch: have %v, want %v", err, errNoSignature)
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	key := newKeys(t, 1)[0]
+
+	decodedKey, err := SecretKeyFromBytes(key.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decodedKey.PublicKey().Bytes(), key.PublicKey().Bytes()) {
+		t.Fatal("secret key mismatch after decoding")
+	}
+
+	pubKey, err := PublicKeyFromBytes(key.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubKey.Bytes()) != PublicKeyLength {
+		t.Fatalf("public key length mismatch: have %d, want %d", len(pubKey.Bytes()), PublicKeyLength)
+	}
+
+	msg := []byte("committed seal")
+	sig, err := SignatureFromBytes(key.Sign(msg).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig.Bytes()) != SignatureLength {
+		t.Fatalf("signature length mismatch: have %d, want %d", len(sig.Bytes()), SignatureLength)
+	}
+	if !pubKey.Verify(msg, sig) {
+		t.Fatal("decoded signature rejected")
+	}
+
+	if _, err := PublicKeyFromBytes(make([]byte, PublicKeyLength)); err != errInvalidPublicKey {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidPublicKey)
+	}
+	if _, err := SignatureFromBytes([]byte{1, 2, 3}); err != errInvalidSignature {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidSignature)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, 32)
+	a, err := DeriveKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := DeriveKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Fatal("derived keys mismatch")
+	}
+	if _, err := DeriveKey(seed[:16]); err != errInvalidSecretKey {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidSecretKey)
+	}
+}
+
+// The vectors are those of the appendices of RFC 9380.
+func TestExpandMessageXMD(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"", "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+	}
+	for _, tt := range tests {
+		have := expandMessageXMD([]byte(tt.msg), dst, 0x20)
+		if want := common.FromHex(tt.want); !bytes.Equal(have, want) {
+			t.Fatalf("msg %q: have %x, want %x", tt.msg, have, want)
+		}
+	}
+}
+
+func TestHashToG2(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	// The point is serialized as x.c1, x.c0, y.c1 and y.c0.
+	want := common.FromHex("05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d" +
+		"0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a" +
+		"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6" +
+		"0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92")
+	if have := bls12381.NewG2().ToBytes(hashToG2(nil, dst)); !bytes.Equal(have, want) {
+		t.Fatalf("point mismatch: have %x, want %x", have, want)
+	}
+}
+
+func TestCompressG1(t *testing.T) {
+	g1 := bls12381.NewG1()
+	want := common.FromHex("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	if have := compressG1(g1.ToBytes(g1.One())); !bytes.Equal(have, want) {
+		t.Fatalf("generator mismatch: have %x, want %x", have, want)
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/clearmatics/autonity/accounts/abi/bind"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/ethclient/autonityclient"
+	"github.com/clearmatics/autonity/p2p/enode"
+	"github.com/clearmatics/autonity/test"
+	"github.com/stretchr/testify/require"
+)
+
+// This test checks that the operator can manage the users and the minimum
+// gas price through the typed Autonity contract client, and read them back.
+func TestAutonityClient(t *testing.T) {
+	network, err := test.NewNetwork(2, "10e18,v,1,0.0.0.0:%s,%s", 6780)
+	require.NoError(t, err)
+	defer network.Shutdown()
+
+	// The first user of the genesis is the operator.
+	operator := network[0]
+	client, err := autonityclient.Dial(operator.WSEndpoint())
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	committee, err := client.GetCommittee(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, committee, 2)
+
+	validator := network[1].Address
+	user, err := client.GetUser(ctx, validator, nil)
+	require.NoError(t, err)
+	require.Equal(t, validator, user.Addr)
+	require.Equal(t, autonityclient.Validator, user.UserType)
+	require.Equal(t, big.NewInt(1), user.Stake)
+
+	opts := bind.NewKeyedTransactor(operator.Key)
+	opts.Context = ctx
+
+	tx, err := client.Mint(opts, validator, big.NewInt(10))
+	require.NoError(t, err)
+	require.NoError(t, network.AwaitTransactions(ctx, tx))
+	balance, err := client.BalanceOf(ctx, validator, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11), balance)
+
+	tx, err = client.SetMinimumGasPrice(opts, big.NewInt(2))
+	require.NoError(t, err)
+	require.NoError(t, network.AwaitTransactions(ctx, tx))
+	price, err := client.GetMinimumGasPrice(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), price)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	participant := crypto.PubkeyToAddress(key.PublicKey)
+	node := enode.NewV4(&key.PublicKey, net.ParseIP("127.0.0.1"), 30303, 30303)
+	tx, err = client.AddUser(opts, participant, new(big.Int), node.String(), autonityclient.Participant)
+	require.NoError(t, err)
+	require.NoError(t, network.AwaitTransactions(ctx, tx))
+	user, err = client.GetUser(ctx, participant, nil)
+	require.NoError(t, err)
+	require.Equal(t, autonityclient.Participant, user.UserType)
+	require.Equal(t, node.String(), user.Enode)
+}
@@ -130,13 +130,15 @@ func (api *PrivateMinerAPI) SetExtra(extra string) (bool, error) {
 	return true, nil
 }
 
-// SetGasPrice sets the minimum accepted gas price for the miner.
+// SetGasPrice sets the minimum accepted gas price for the miner, the minimum
+// gas price of the Autonity contract prevails if higher. The given price is
+// kept to be floored again whenever the contract changes its price.
 func (api *PrivateMinerAPI) SetGasPrice(gasPrice hexutil.Big) bool {
 	api.e.lock.Lock()
 	api.e.gasPrice = (*big.Int)(&gasPrice)
 	api.e.lock.Unlock()
 
-	api.e.txPool.SetGasPrice((*big.Int)(&gasPrice))
+	api.e.txPool.SetGasPrice(api.e.floorGasPrice((*big.Int)(&gasPrice)))
 	return true
 }
 
@@ -287,11 +289,9 @@ func (api *PublicDebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error
 		_, stateDb := api.eth.miner.Pending()
 		return stateDb.RawDump(false, false, true), nil
 	}
-	var block *types.Block
-	if blockNr == rpc.LatestBlockNumber {
-		block = api.eth.blockchain.CurrentBlock()
-	} else {
-		block = api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
+	block, err := api.eth.blockByNumber(blockNr)
+	if err != nil {
+		return state.Dump{}, err
 	}
 	if block == nil {
 		return state.Dump{}, fmt.Errorf("block #%d not found", blockNr)
@@ -353,6 +353,29 @@ func (api *PrivateDebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs,
 	return results, nil
 }
 
+// SimulateContractUpgrade upgrades the Autonity contract to the given bytecode
+// and ABI on a copy of the state of the given block, the latest one if it is
+// omitted, and reports whether the upgrade would succeed and how it would
+// change the contract state and the committee. The chain is left untouched.
+func (api *PrivateDebugAPI) SimulateContractUpgrade(ctx context.Context, bytecode string, contractABI string, blockNrOrHash *rpc.BlockNumberOrHash) (*autonity.UpgradeReport, error) {
+	contract := api.eth.BlockChain().GetAutonityContract()
+	if contract == nil {
+		return nil, errors.New("no autonity contract")
+	}
+	number := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		number = *blockNrOrHash
+	}
+	stateDB, header, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if stateDB == nil || header == nil {
+		return nil, errors.New("block not found")
+	}
+	return contract.SimulateUpgrade(stateDB, header, bytecode, contractABI)
+}
+
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
@@ -368,11 +391,9 @@ func (api *PublicDebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, sta
 			// the miner and operate on those
 			_, stateDb = api.eth.miner.Pending()
 		} else {
-			var block *types.Block
-			if number == rpc.LatestBlockNumber {
-				block = api.eth.blockchain.CurrentBlock()
-			} else {
-				block = api.eth.blockchain.GetBlockByNumber(uint64(number))
+			block, err := api.eth.blockByNumber(number)
+			if err != nil {
+				return state.IteratorDump{}, err
 			}
 			if block == nil {
 				return state.IteratorDump{}, fmt.Errorf("block #%d not found", number)
@@ -552,7 +573,11 @@ type AutonityContractAPI struct {
 // themselves make no use of the method receiver. This design is required to be
 // able to fit into the current approach taken for registering rpc services.
 // See rpc.Server.RegisterName().
-func NewAutonityContractAPI(bc *core.BlockChain, ac *autonity.Contract) *AutonityContractAPI {
+//
+// Like eth_call, every method takes an optional block number or hash as last
+// argument and is run against the state of that block, the latest block is
+// used if it is omitted.
+func NewAutonityContractAPI(b ethapi.Backend, ac *autonity.Contract) *AutonityContractAPI {
 	var viewMethodStr = "view"
 	var contractABI = ac.ABI()
 	var contractViewMethods = make(map[string]reflect.Value)
@@ -561,9 +586,11 @@ func NewAutonityContractAPI(bc *core.BlockChain, ac *autonity.Contract) *Autonit
 		functionName := n
 		// Only expose read-only functions.
 		if m.StateMutability == viewMethodStr {
-			// The RPC service expect the first argument of an API method to be the receiver object.
-			inArgs := []reflect.Type{reflect.TypeOf(&AutonityContractAPI{})}
+			// The RPC service expect the first argument of an API method to be the receiver object,
+			// followed by the context of the call.
+			inArgs := []reflect.Type{reflect.TypeOf(&AutonityContractAPI{}), reflect.TypeOf((*context.Context)(nil)).Elem()}
 			inArgs = append(inArgs, m.Inputs.Types()...)
+			inArgs = append(inArgs, reflect.TypeOf(&rpc.BlockNumberOrHash{}))
 			sig := reflect.FuncOf(inArgs, []reflect.Type{
 				reflect.TypeOf((*interface{})(nil)).Elem(),
 				reflect.TypeOf((*error)(nil)).Elem(),
@@ -575,13 +602,22 @@ func NewAutonityContractAPI(bc *core.BlockChain, ac *autonity.Contract) *Autonit
 					makereturn := func(res interface{}, err error) []reflect.Value {
 						return []reflect.Value{reflect.ValueOf(&res).Elem(), reflect.ValueOf(&err).Elem()}
 					}
-					stateDB, err := bc.State()
+					// args[0] is the reflect.Value of *AutonityContractAPI, args[1] the context and the last
+					// one the optional block number or hash.
+					ctx := args[1].Interface().(context.Context)
+					blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+					if last := args[len(args)-1]; !last.IsNil() {
+						blockNrOrHash = *last.Interface().(*rpc.BlockNumberOrHash)
+					}
+					stateDB, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 					if err != nil {
 						return makereturn(nil, err)
 					}
+					if stateDB == nil || header == nil {
+						return makereturn(nil, errors.New("block not found"))
+					}
 					var iargs []interface{}
-					// args[0] is the reflect.Value of *AutonityContractAPI.
-					for i, arg := range args[1:] {
+					for i, arg := range args[2 : len(args)-1] {
 						// If the argument is a pointer it is then an optional parameter for the RPC handler. The
 						// json unmarshalling function set it to nil if the argument isn't set in the RPC call.
 						// There are no optional parameters for the Autonity contract methods. Solidity doesn't
@@ -592,16 +628,23 @@ func NewAutonityContractAPI(bc *core.BlockChain, ac *autonity.Contract) *Autonit
 						iargs = append(iargs, arg.Interface())
 					}
 
+					// The call is made with the ABI which was active at that block, which might differ from the
+					// current one if the contract was upgraded since.
+					callABI, err := ac.ABIAt(header.Number.Uint64())
+					if err != nil {
+						return makereturn(nil, err)
+					}
+
 					// Pack the arguments call the function and then unpack the result and return it.
-					packedArgs, err := contractABI.Pack(functionName, iargs...)
+					packedArgs, err := callABI.Pack(functionName, iargs...)
 					if err != nil {
 						return makereturn(nil, err)
 					}
-					packedResult, err := ac.CallContractFunc(stateDB, bc.CurrentHeader(), functionName, packedArgs)
+					packedResult, err := ac.CallContractFunc(stateDB, header, functionName, packedArgs)
 					if err != nil {
 						return makereturn(nil, err)
 					}
-					result, err := contractABI.Unpack(functionName, packedResult)
+					result, err := callABI.Unpack(functionName, packedResult)
 
 					// If the result slice contains only one element then just return the element.
 					if len(result) == 1 {
@@ -617,3 +660,32 @@ func NewAutonityContractAPI(bc *core.BlockChain, ac *autonity.Contract) *Autonit
 func (a *AutonityContractAPI) AllMethods() map[string]reflect.Value {
 	return a.calls
 }
+
+// PublicAutonityAPI serves the data derived from the autonity contract that
+// its view functions cannot provide, alongside them in the aut namespace.
+type PublicAutonityAPI struct {
+	eth *Ethereum
+}
+
+// NewPublicAutonityAPI creates a new API definition for the data derived from
+// the autonity contract.
+func NewPublicAutonityAPI(eth *Ethereum) *PublicAutonityAPI {
+	return &PublicAutonityAPI{eth: eth}
+}
+
+// GetRewardDistribution returns the rewards distributed to the stakeholders by
+// the given block, the latest one if it is omitted.
+func (api *PublicAutonityAPI) GetRewardDistribution(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*types.RewardDistribution, error) {
+	number := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		number = *blockNrOrHash
+	}
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("block not found")
+	}
+	return api.eth.BlockChain().GetRewardDistribution(header)
+}
@@ -71,6 +71,13 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentBlock().Header(), nil
 	}
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		block, err := b.eth.finalBlock(number)
+		if err != nil {
+			return nil, err
+		}
+		return block.Header(), nil
+	}
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
@@ -96,16 +103,7 @@ func (b *EthAPIBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*ty
 }
 
 func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
-	// Pending block is only known by the miner
-	if number == rpc.PendingBlockNumber {
-		block := b.eth.miner.PendingBlock()
-		return block, nil
-	}
-	// Otherwise resolve and return the block
-	if number == rpc.LatestBlockNumber {
-		return b.eth.blockchain.CurrentBlock(), nil
-	}
-	return b.eth.blockchain.GetBlockByNumber(uint64(number)), nil
+	return b.eth.blockByNumber(number)
 }
 
 func (b *EthAPIBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
@@ -276,8 +274,26 @@ func (b *EthAPIBackend) ProtocolVersion() int {
 	return b.eth.EthVersion()
 }
 
-func (b *EthAPIBackend) SuggestPrice(_ context.Context) (*big.Int, error) {
-	return b.eth.blockchain.GetMinGasPrice()
+func (b *EthAPIBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
+	return b.gpo.SuggestPrice(ctx)
+}
+
+// MinGasPrice implements gasprice.MinGasPriceBackend, it returns the minimum gas
+// price of the Autonity contract on top of the block of header.
+func (b *EthAPIBackend) MinGasPrice(ctx context.Context, header *types.Header) (*big.Int, error) {
+	contract := b.eth.blockchain.GetAutonityContract()
+	if contract == nil {
+		return new(big.Int), nil
+	}
+	statedb, err := b.eth.blockchain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	price, err := contract.GetMinimumGasPrice(header, statedb)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(price), nil
 }
 
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
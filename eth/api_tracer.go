@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"runtime"
 	"sync"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/common/hexutil"
+	"github.com/clearmatics/autonity/consensus"
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/rawdb"
 	"github.com/clearmatics/autonity/core/state"
@@ -78,18 +80,20 @@ type txTraceResult struct {
 // blockTraceTask represents a single block trace task when an entire chain is
 // being traced.
 type blockTraceTask struct {
-	statedb *state.StateDB   // Intermediate state prepped for tracing
-	block   *types.Block     // Block to trace the transactions from
-	rootref common.Hash      // Trie root reference held for this task
-	results []*txTraceResult // Trace results procudes by the task
+	statedb  *state.StateDB   // Intermediate state prepped for tracing
+	block    *types.Block     // Block to trace the transactions from
+	rootref  common.Hash      // Trie root reference held for this task
+	results  []*txTraceResult // Trace results procudes by the task
+	finalize []*txTraceResult // Trace results of the finalization of the block
 }
 
 // blockTraceResult represets the results of tracing a single block when an entire
 // chain is being traced.
 type blockTraceResult struct {
-	Block  hexutil.Uint64   `json:"block"`  // Block number corresponding to this trace
-	Hash   common.Hash      `json:"hash"`   // Block hash corresponding to this trace
-	Traces []*txTraceResult `json:"traces"` // Trace results produced by the task
+	Block    hexutil.Uint64   `json:"block"`              // Block number corresponding to this trace
+	Hash     common.Hash      `json:"hash"`               // Block hash corresponding to this trace
+	Traces   []*txTraceResult `json:"traces"`             // Trace results produced by the task
+	Finalize []*txTraceResult `json:"finalize,omitempty"` // Trace results of the finalization of the block
 }
 
 // txTraceTask represents a single transaction trace task when an entire block
@@ -103,23 +107,13 @@ type txTraceTask struct {
 // between two blocks (excluding start) and returns them as a JSON object.
 func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *TraceConfig) (*rpc.Subscription, error) {
 	// Fetch the block interval that we want to trace
-	var from, to *types.Block
-
-	switch start {
-	case rpc.PendingBlockNumber:
-		from = api.eth.miner.PendingBlock()
-	case rpc.LatestBlockNumber:
-		from = api.eth.blockchain.CurrentBlock()
-	default:
-		from = api.eth.blockchain.GetBlockByNumber(uint64(start))
+	from, err := api.eth.blockByNumber(start)
+	if err != nil {
+		return nil, err
 	}
-	switch end {
-	case rpc.PendingBlockNumber:
-		to = api.eth.miner.PendingBlock()
-	case rpc.LatestBlockNumber:
-		to = api.eth.blockchain.CurrentBlock()
-	default:
-		to = api.eth.blockchain.GetBlockByNumber(uint64(end))
+	to, err := api.eth.blockByNumber(end)
+	if err != nil {
+		return nil, err
 	}
 	// Trace the chain if we've found all our blocks
 	if from == nil {
@@ -204,6 +198,7 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 				signer := types.MakeSigner(api.eth.blockchain.Config(), task.block.Number())
 
 				// Trace all the transactions contained within
+				failed := false
 				for i, tx := range task.block.Transactions() {
 					msg, _ := tx.AsMessage(signer)
 					vmctx := core.NewEVMContext(msg, task.block.Header(), api.eth.blockchain, nil)
@@ -212,12 +207,26 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 					if err != nil {
 						task.results[i] = &txTraceResult{Error: err.Error()}
 						log.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
+						failed = true
 						break
 					}
 					// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
 					task.statedb.Finalise(api.eth.blockchain.Config().IsEIP158(task.block.Number()))
 					task.results[i] = &txTraceResult{Result: res}
 				}
+				// Trace the finalization of the block on top of its transactions
+				if _, ok := api.eth.engine.(consensus.FinalizeTracer); ok && !failed && task.block.NumberU64() > 0 {
+					txs := task.block.Transactions()
+					receipts := api.eth.blockchain.GetReceiptsByHash(task.block.Hash())
+					if len(receipts) < len(txs) {
+						task.finalize = []*txTraceResult{{Error: "receipts not found"}}
+					} else if res, err := api.traceFinalize(ctx, task.block, task.statedb, receipts[:len(txs)], config); err != nil {
+						task.finalize = []*txTraceResult{{Error: err.Error()}}
+						log.Warn("Finalize tracing failed", "block", task.block.NumberU64(), "err", err)
+					} else {
+						task.finalize = res
+					}
+				}
 				// Stream the result back to the user or abort on teardown
 				select {
 				case results <- task:
@@ -328,9 +337,10 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 		for res := range results {
 			// Queue up next received result
 			result := &blockTraceResult{
-				Block:  hexutil.Uint64(res.block.NumberU64()),
-				Hash:   res.block.Hash(),
-				Traces: res.results,
+				Block:    hexutil.Uint64(res.block.NumberU64()),
+				Hash:     res.block.Hash(),
+				Traces:   res.results,
+				Finalize: res.finalize,
 			}
 			done[uint64(result.Block)] = result
 
@@ -339,7 +349,7 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 
 			// Stream completed traces to the user, aborting on the first error
 			for result, ok := done[next]; ok; result, ok = done[next] {
-				if len(result.Traces) > 0 || next == end.NumberU64() {
+				if len(result.Traces) > 0 || len(result.Finalize) > 0 || next == end.NumberU64() {
 					notifier.Notify(sub.ID, result)
 				}
 				delete(done, next)
@@ -354,15 +364,9 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 // EVM and returns them as a JSON object.
 func (api *PrivateDebugAPI) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) ([]*txTraceResult, error) {
 	// Fetch the block that we want to trace
-	var block *types.Block
-
-	switch number {
-	case rpc.PendingBlockNumber:
-		block = api.eth.miner.PendingBlock()
-	case rpc.LatestBlockNumber:
-		block = api.eth.blockchain.CurrentBlock()
-	default:
-		block = api.eth.blockchain.GetBlockByNumber(uint64(number))
+	block, err := api.eth.blockByNumber(number)
+	if err != nil {
+		return nil, err
 	}
 	// Trace the block if it was found
 	if block == nil {
@@ -440,7 +444,8 @@ func (api *PrivateDebugAPI) StandardTraceBadBlockToFile(ctx context.Context, has
 
 // traceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
-// per transaction, dependent on the requestd tracer.
+// per transaction, dependent on the requestd tracer, followed by one item per call
+// of the finalization of the block if the consensus engine can trace it.
 func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
 	// Create the parent state database
 	if err := api.eth.engine.VerifyHeader(api.eth.blockchain, block.Header(), true); err != nil {
@@ -462,8 +467,9 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 	var (
 		signer = types.MakeSigner(api.eth.blockchain.Config(), block.Number())
 
-		txs     = block.Transactions()
-		results = make([]*txTraceResult, len(txs))
+		txs      = block.Transactions()
+		results  = make([]*txTraceResult, len(txs))
+		receipts = make(types.Receipts, 0, len(txs)) // Only the gas used, all the finalization needs
 
 		pend = new(sync.WaitGroup)
 		jobs = make(chan *txTraceTask, len(txs))
@@ -502,10 +508,12 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 		vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
 
 		vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{})
-		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+		res, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
+		if err != nil {
 			failed = err
 			break
 		}
+		receipts = append(receipts, &types.Receipt{GasUsed: res.UsedGas})
 		// Finalize the state so any modifications are written to the trie
 		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
 		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
@@ -517,6 +525,15 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 	if failed != nil {
 		return nil, failed
 	}
+	// Trace the finalization of the block on top of its transactions
+	if _, ok := api.eth.engine.(consensus.FinalizeTracer); ok && block.NumberU64() > 0 {
+		finalize, err := api.traceFinalize(ctx, block, statedb, receipts, config)
+		if err != nil {
+			log.Warn("Finalize tracing failed", "block", block.NumberU64(), "err", err)
+			finalize = []*txTraceResult{{Error: err.Error()}}
+		}
+		results = append(results, finalize...)
+	}
 	return results, nil
 }
 
@@ -736,7 +753,7 @@ func (api *PrivateDebugAPI) TraceCall(ctx context.Context, args ethapi.CallArgs,
 		if hash, ok := blockNrOrHash.Hash(); ok {
 			block = api.eth.blockchain.GetBlockByHash(hash)
 		} else if number, ok := blockNrOrHash.Number(); ok {
-			block = api.eth.blockchain.GetBlockByNumber(uint64(number))
+			block, _ = api.eth.blockByNumber(number)
 		}
 		if block == nil {
 			return nil, fmt.Errorf("block %v not found: %v", blockNrOrHash, err)
@@ -763,37 +780,12 @@ func (api *PrivateDebugAPI) TraceCall(ctx context.Context, args ethapi.CallArgs,
 // be tracer dependent.
 func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
 	// Assemble the structured logger or the JavaScript tracer
-	var (
-		tracer vm.Tracer
-		err    error
-	)
-	switch {
-	case config != nil && config.Tracer != nil:
-		// Define a meaningful timeout of a single transaction trace
-		timeout := defaultTraceTimeout
-		if config.Timeout != nil {
-			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, err
-			}
-		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
-			return nil, err
-		}
-		// Handle timeouts and RPC cancellations
-		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-		go func() {
-			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
-		}()
-		defer cancel()
-
-	case config == nil:
-		tracer = vm.NewStructLogger(nil)
-
-	default:
-		tracer = vm.NewStructLogger(config.LogConfig)
+	tracer, cancel, err := newTracer(ctx, config)
+	if err != nil {
+		return nil, err
 	}
+	defer cancel()
+
 	// Run the transaction with tracing enabled.
 	vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{Debug: true, Tracer: tracer})
 
@@ -824,6 +816,201 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 	}
 }
 
+// TraceFinalize returns the structured logs created during the finalization of
+// a block, which the consensus engine runs after the transactions of the block
+// with calls to the Autonity contract: the report of the absent committee
+// members, the redistribution of the fees and the computation of the next
+// committee, and the contract upgrade if any. Each call is traced on its own,
+// as a transaction from the deployer to the contract, and the return value
+// will be one item per call, in execution order, dependent on the requested
+// tracer.
+func (api *PrivateDebugAPI) TraceFinalize(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) ([]*txTraceResult, error) {
+	// Fetch the block that we want to trace
+	block, err := api.eth.blockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not finalized")
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, receipts, err := api.computeFinalizeEnv(block, reexec)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceFinalize(ctx, block, statedb, receipts, config)
+}
+
+// traceFinalize configures a new tracer according to the provided configuration
+// for each call of the finalization of the block, and finalizes the block in the
+// provided state, the one after its transactions of the given receipts.
+func (api *PrivateDebugAPI) traceFinalize(ctx context.Context, block *types.Block, statedb *state.StateDB, receipts types.Receipts, config *TraceConfig) ([]*txTraceResult, error) {
+	engine, ok := api.eth.engine.(consensus.FinalizeTracer)
+	if !ok {
+		return nil, errors.New("consensus engine does not support finalize tracing")
+	}
+	// Fail early on a bad configuration rather than on every call
+	_, cancel, err := newTracer(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	cancel()
+
+	tracer := &finalizeTracer{ctx: ctx, config: config}
+	defer tracer.release()
+
+	statedb.Prepare(common.ACHash(block.Number()), block.Hash(), len(block.Transactions()))
+	vmConfig := vm.Config{Debug: true, Tracer: tracer}
+	if _, err := engine.TraceFinalize(api.eth.blockchain, block.Header(), statedb, block.Transactions(), receipts, vmConfig); err != nil {
+		return nil, fmt.Errorf("tracing failed: %v", err)
+	}
+	return tracer.results, nil
+}
+
+// finalizeTracer traces each call of the finalization of a block with a new
+// tracer, as the calls are independent from each other.
+type finalizeTracer struct {
+	ctx    context.Context
+	config *TraceConfig
+
+	tracer  vm.Tracer          // Tracer of the running call
+	cancel  context.CancelFunc // Releases the tracer of the running call
+	results []*txTraceResult   // Trace results of the finished calls
+}
+
+func (t *finalizeTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	tracer, cancel, err := newTracer(t.ctx, t.config)
+	if err != nil {
+		return err
+	}
+	t.tracer, t.cancel = tracer, cancel
+	return tracer.CaptureStart(from, to, create, input, gas, value)
+}
+
+func (t *finalizeTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, rStack *vm.ReturnStack, rData []byte, contract *vm.Contract, depth int, err error) error {
+	if t.tracer == nil {
+		return nil
+	}
+	return t.tracer.CaptureState(env, pc, op, gas, cost, memory, stack, rStack, rData, contract, depth, err)
+}
+
+func (t *finalizeTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, rStack *vm.ReturnStack, contract *vm.Contract, depth int, err error) error {
+	if t.tracer == nil {
+		return nil
+	}
+	return t.tracer.CaptureFault(env, pc, op, gas, cost, memory, stack, rStack, contract, depth, err)
+}
+
+func (t *finalizeTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.tracer == nil {
+		return nil
+	}
+	defer t.release()
+	if err := t.tracer.CaptureEnd(output, gasUsed, d, err); err != nil {
+		return err
+	}
+	// Depending on the tracer type, format and record the output
+	switch tracer := t.tracer.(type) {
+	case *vm.StructLogger:
+		t.results = append(t.results, &txTraceResult{Result: &ethapi.ExecutionResult{
+			Gas:         gasUsed,
+			Failed:      err != nil,
+			ReturnValue: fmt.Sprintf("%x", output),
+			StructLogs:  ethapi.FormatLogs(tracer.StructLogs()),
+		}})
+
+	case *tracers.Tracer:
+		res, err := tracer.GetResult()
+		if err != nil {
+			t.results = append(t.results, &txTraceResult{Error: err.Error()})
+			return nil
+		}
+		t.results = append(t.results, &txTraceResult{Result: res})
+
+	default:
+		panic(fmt.Sprintf("bad tracer type %T", tracer))
+	}
+	return nil
+}
+
+// release releases the tracer of the running call, if any.
+func (t *finalizeTracer) release() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	t.tracer, t.cancel = nil, nil
+}
+
+// newTracer assembles the structured logger or the JavaScript tracer of the
+// provided configuration. The returned function releases the tracer.
+func newTracer(ctx context.Context, config *TraceConfig) (vm.Tracer, context.CancelFunc, error) {
+	switch {
+	case config != nil && config.Tracer != nil:
+		// Define a meaningful timeout of a single transaction trace
+		timeout := defaultTraceTimeout
+		if config.Timeout != nil {
+			var err error
+			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+				return nil, nil, err
+			}
+		}
+		// Constuct the JavaScript tracer to execute with
+		tracer, err := tracers.New(*config.Tracer)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Handle timeouts and RPC cancellations
+		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+		go func() {
+			<-deadlineCtx.Done()
+			tracer.Stop(errors.New("execution timeout"))
+		}()
+		return tracer, cancel, nil
+
+	case config == nil:
+		return vm.NewStructLogger(nil), func() {}, nil
+
+	default:
+		return vm.NewStructLogger(config.LogConfig), func() {}, nil
+	}
+}
+
+// computeFinalizeEnv returns the state a block is finalized in, the one after
+// its transactions, and the receipts of the transactions.
+func (api *PrivateDebugAPI) computeFinalizeEnv(block *types.Block, reexec uint64) (*state.StateDB, types.Receipts, error) {
+	// Create the parent state database
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := api.computeStateDB(parent, reexec)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Recompute all the transactions, the finalization depends on their receipts
+	var (
+		header   = block.Header()
+		gp       = new(core.GasPool).AddGas(block.GasLimit())
+		usedGas  = new(uint64)
+		receipts = make(types.Receipts, 0, len(block.Transactions()))
+	)
+	for i, tx := range block.Transactions() {
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		receipt, err := core.ApplyTransaction(api.eth.blockchain.Config(), api.eth.blockchain, nil, gp, statedb, header, tx, usedGas, vm.Config{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	return statedb, receipts, nil
+}
+
 // computeTxEnv returns the execution environment of a certain transaction.
 func (api *PrivateDebugAPI) computeTxEnv(block *types.Block, txIndex int, reexec uint64) (core.Message, vm.Context, *state.StateDB, error) {
 	// Create the parent state database
@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/vm"
+	"github.com/clearmatics/autonity/internal/ethapi"
+	"github.com/clearmatics/autonity/params"
+)
+
+// runFinalizeCalls makes two top-level calls, as the finalization of a block
+// does, with the given tracer.
+func runFinalizeCalls(t *testing.T, tracer vm.Tracer) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	first, second := common.HexToAddress("0x01ff"), common.HexToAddress("0x02ff")
+	statedb.SetCode(first, []byte{byte(vm.PUSH1), 0x01, byte(vm.POP), byte(vm.STOP)})
+	statedb.SetCode(second, []byte{byte(vm.PUSH1), 0x00, byte(vm.DUP1), byte(vm.REVERT)})
+
+	vmctx := vm.Context{CanTransfer: core.CanTransfer, Transfer: core.Transfer, BlockNumber: big.NewInt(1)}
+	evm := vm.NewEVM(vmctx, statedb, params.TestChainConfig, vm.Config{Debug: true, Tracer: tracer})
+	caller := vm.AccountRef(common.HexToAddress("0xaa"))
+	if _, _, err := evm.Call(caller, first, nil, 100000, new(big.Int)); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if _, _, err := evm.Call(caller, second, nil, 100000, new(big.Int)); err != vm.ErrExecutionReverted {
+		t.Fatalf("second call error mismatch: have %v, want %v", err, vm.ErrExecutionReverted)
+	}
+}
+
+func TestFinalizeTracerStructLogs(t *testing.T) {
+	tracer := &finalizeTracer{ctx: context.Background()}
+	runFinalizeCalls(t, tracer)
+
+	if len(tracer.results) != 2 {
+		t.Fatalf("results length mismatch: have %d, want 2", len(tracer.results))
+	}
+	for i, failed := range []bool{false, true} {
+		result, ok := tracer.results[i].Result.(*ethapi.ExecutionResult)
+		if !ok {
+			t.Fatalf("result %d type mismatch: have %T", i, tracer.results[i].Result)
+		}
+		if result.Failed != failed {
+			t.Errorf("result %d failure mismatch: have %v, want %v", i, result.Failed, failed)
+		}
+		if len(result.StructLogs) != 3 {
+			t.Errorf("result %d struct logs length mismatch: have %d, want 3", i, len(result.StructLogs))
+		}
+	}
+	if tracer.tracer != nil {
+		t.Error("tracer of the last call not released")
+	}
+}
+
+func TestFinalizeTracerCallTracer(t *testing.T) {
+	name := "callTracer"
+	tracer := &finalizeTracer{ctx: context.Background(), config: &TraceConfig{Tracer: &name}}
+	runFinalizeCalls(t, tracer)
+
+	if len(tracer.results) != 2 {
+		t.Fatalf("results length mismatch: have %d, want 2", len(tracer.results))
+	}
+	for i, to := range []string{"0x00000000000000000000000000000000000001ff", "0x00000000000000000000000000000000000002ff"} {
+		var frame struct {
+			To    string `json:"to"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(tracer.results[i].Result.(json.RawMessage), &frame); err != nil {
+			t.Fatalf("result %d not a call frame: %v", i, err)
+		}
+		if frame.To != to {
+			t.Errorf("result %d callee mismatch: have %s, want %s", i, frame.To, to)
+		}
+		if (frame.Error != "") != (i == 1) {
+			t.Errorf("result %d error mismatch: have %q", i, frame.Error)
+		}
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"sync/atomic"
 
 	tendermintBackend "github.com/clearmatics/autonity/consensus/tendermint/backend"
+	tendermintConfig "github.com/clearmatics/autonity/consensus/tendermint/config"
+	tendermintSigner "github.com/clearmatics/autonity/consensus/tendermint/signer"
 	"github.com/clearmatics/autonity/crypto"
 	"github.com/clearmatics/autonity/p2p/enode"
 
@@ -38,11 +40,13 @@ import (
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/bloombits"
 	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state/pruner"
 	"github.com/clearmatics/autonity/core/types"
 	"github.com/clearmatics/autonity/core/vm"
 	"github.com/clearmatics/autonity/eth/downloader"
 	"github.com/clearmatics/autonity/eth/filters"
 	"github.com/clearmatics/autonity/eth/gasprice"
+	"github.com/clearmatics/autonity/eth/protocols/snap"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/internal/ethapi"
@@ -91,6 +95,13 @@ type Ethereum struct {
 
 	glienickeCh  chan core.WhitelistEvent
 	glienickeSub event.Subscription
+
+	chainHeadCh  chan core.ChainHeadEvent
+	chainHeadSub event.Subscription
+
+	// the sentry nodes of a validator and the validators of a sentry node
+	sentries         []*enode.Node
+	sentryValidators []*enode.Node
 }
 
 // New creates a new Ethereum object (including the
@@ -123,10 +134,24 @@ func New(stack *node.Node, config *Config, cons func(basic consensus.Engine) con
 	if err != nil {
 		return nil, err
 	}
+	// Resume a state pruning interrupted midway, the state is left inconsistent
+	// until it is done.
+	if err := pruner.RecoverPruning(stack.ResolvePath(""), chainDb, stack.ResolvePath(config.TrieCleanCacheJournal)); err != nil {
+		log.Error("Failed to recover state", "error", err)
+	}
 	chainConfig, genesisHash, genesisErr := core.SetupGenesisBlock(chainDb, config.Genesis)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
 	}
+	var sentries, sentryValidators []*enode.Node
+	if chainConfig.Tendermint != nil {
+		if err := config.Tendermint.Validate(); err != nil {
+			return nil, err
+		}
+		// The enodes were validated with the config.
+		sentries, _ = tendermintConfig.ParseEnodes(config.Tendermint.Sentries)
+		sentryValidators, _ = tendermintConfig.ParseEnodes(config.Tendermint.SentryValidators)
+	}
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
@@ -164,7 +189,10 @@ func New(stack *node.Node, config *Config, cons func(basic consensus.Engine) con
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
 		bloomIndexer:      NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		glienickeCh:       make(chan core.WhitelistEvent),
+		chainHeadCh:       make(chan core.ChainHeadEvent, 10),
 		p2pServer:         stack.Server(),
+		sentries:          sentries,
+		sentryValidators:  sentryValidators,
 	}
 
 	// force to set the istanbul etherbase to node key address
@@ -218,6 +246,7 @@ func New(stack *node.Node, config *Config, cons func(basic consensus.Engine) con
 	if eth.protocolManager, err = NewProtocolManager(chainConfig, checkpoint, config.SyncMode, config.NetworkId, eth.eventMux, eth.txPool, eth.engine, eth.blockchain, chainDb, cacheLimit, config.Whitelist, &stack.Config().NodeKey().PublicKey); err != nil {
 		return nil, err
 	}
+	eth.protocolManager.setSentries(sentries, sentryValidators)
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -258,7 +287,20 @@ func makeExtraData(extra []byte) []byte {
 func CreateConsensusEngine(ctx *node.Node, chainConfig *params.ChainConfig, config *Config, notify []string, noverify bool, db ethdb.Database, vmConfig *vm.Config) consensus.Engine {
 
 	if chainConfig.Tendermint != nil {
-		return tendermintBackend.New(&config.Tendermint, ctx.Config().NodeKey(), db, chainConfig, vmConfig)
+		if config.Tendermint.Signer == "" {
+			return tendermintBackend.New(&config.Tendermint, ctx.Config().NodeKey(), db, chainConfig, vmConfig)
+		}
+		signer, err := tendermintSigner.Dial(config.Tendermint.Signer)
+		if err != nil {
+			log.Crit("Failed to connect to the tendermint signer", "endpoint", config.Tendermint.Signer, "err", err)
+		}
+		// The peers know the committee members by their enode, so the
+		// validator must sign with the key of its node.
+		if node := crypto.PubkeyToAddress(ctx.Config().NodeKey().PublicKey); signer.Address() != node {
+			log.Crit("The tendermint signer key is not the node key", "signer", signer.Address(), "node", node)
+		}
+		log.Info("Connected to the tendermint signer", "endpoint", config.Tendermint.Signer, "address", signer.Address())
+		return tendermintBackend.NewWithSigner(&config.Tendermint, signer, db, chainConfig, vmConfig)
 	}
 
 	// Otherwise assume proof-of-work
@@ -299,7 +341,12 @@ func (s *Ethereum) APIs() []rpc.API {
 		apis = append(apis, rpc.API{
 			Namespace: "aut",
 			Version:   params.Version,
-			Service:   NewAutonityContractAPI(s.BlockChain(), s.BlockChain().GetAutonityContract()),
+			Service:   NewAutonityContractAPI(s.APIBackend, s.BlockChain().GetAutonityContract()),
+			Public:    true,
+		}, rpc.API{
+			Namespace: "aut",
+			Version:   params.Version,
+			Service:   NewPublicAutonityAPI(s),
 			Public:    true,
 		})
 	}
@@ -445,6 +492,72 @@ func (s *Ethereum) SetEtherbase(etherbase common.Address) {
 	s.miner.SetEtherbase(etherbase)
 }
 
+// blockByNumber returns the block the given number or tag resolves to, nil if
+// there is none. The pending block is only known by the miner.
+func (s *Ethereum) blockByNumber(number rpc.BlockNumber) (*types.Block, error) {
+	switch number {
+	case rpc.PendingBlockNumber:
+		return s.miner.PendingBlock(), nil
+	case rpc.LatestBlockNumber:
+		return s.blockchain.CurrentBlock(), nil
+	case rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+		return s.finalBlock(number)
+	default:
+		return s.blockchain.GetBlockByNumber(uint64(number)), nil
+	}
+}
+
+// finalBlock returns the block the finalized and safe tags resolve to. Tendermint
+// blocks are final as soon as they are committed with a quorum of committed seals,
+// and only blocks whose seals were verified make it into the chain, so it is the
+// chain head. Other engines do not provide finality.
+func (s *Ethereum) finalBlock(number rpc.BlockNumber) (*types.Block, error) {
+	if s.blockchain.Config().Tendermint == nil {
+		if number == rpc.SafeBlockNumber {
+			return nil, errors.New("safe block not found")
+		}
+		return nil, errors.New("finalized block not found")
+	}
+	return s.blockchain.CurrentBlock(), nil
+}
+
+// floorGasPrice raises a miner gas price to the minimum gas price of the
+// Autonity contract at the chain head, as no block can include transactions
+// under it anyway.
+func (s *Ethereum) floorGasPrice(price *big.Int) *big.Int {
+	minGasPrice, err := s.blockchain.GetMinGasPrice()
+	if err != nil || price.Cmp(minGasPrice) >= 0 {
+		return price
+	}
+	log.Warn("Raising miner gas price to the Autonity contract minimum", "provided", price, "updated", minGasPrice)
+	return minGasPrice
+}
+
+// minGasPriceLoop raises the miner gas price to the minimum gas price of the
+// Autonity contract again, or lowers it back to the configured one, whenever
+// the contract changes its price.
+func (s *Ethereum) minGasPriceLoop() {
+	minGasPrice, _ := s.blockchain.GetMinGasPrice()
+	for {
+		select {
+		case <-s.chainHeadCh:
+			price, err := s.blockchain.GetMinGasPrice()
+			if err != nil || (minGasPrice != nil && price.Cmp(minGasPrice) == 0) {
+				continue
+			}
+			minGasPrice = price
+
+			s.lock.RLock()
+			gasPrice := s.gasPrice
+			s.lock.RUnlock()
+			s.txPool.SetGasPrice(s.floorGasPrice(gasPrice))
+		// Err() channel will be closed when unsubscribing.
+		case <-s.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
 // StartMining starts the miner with the given number of CPU threads. If mining
 // is already running, this method adjust the number of threads allowed to use
 // and updates the minimum price required by the transaction pool.
@@ -466,7 +579,7 @@ func (s *Ethereum) StartMining(threads int) error {
 		s.lock.RLock()
 		price := s.gasPrice
 		s.lock.RUnlock()
-		s.txPool.SetGasPrice(price)
+		s.txPool.SetGasPrice(s.floorGasPrice(price))
 
 		// Configure the local mining address
 		eb, err := s.Etherbase()
@@ -523,7 +636,7 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 		protos[i].Attributes = []enr.Entry{s.currentEthEntry()}
 		protos[i].DialCandidates = s.dialCandidates
 	}
-	return protos
+	return append(protos, snap.MakeProtocols((*snapHandler)(s.protocolManager))...)
 }
 
 // Start implements node.Lifecycle, starting all internal goroutines needed by the
@@ -532,6 +645,9 @@ func (s *Ethereum) Start() error {
 	s.glienickeSub = s.blockchain.SubscribeAutonityEvents(s.glienickeCh)
 	go s.glienickeEventLoop(s.p2pServer)
 
+	s.chainHeadSub = s.blockchain.SubscribeChainHeadEvent(s.chainHeadCh)
+	go s.minGasPriceLoop()
+
 	s.startEthEntryUpdate(s.p2pServer.LocalNode())
 
 	// Start the bloom bits servicing goroutines
@@ -556,12 +672,17 @@ func (s *Ethereum) glienickeEventLoop(server *p2p.Server) {
 
 	savedList := rawdb.ReadEnodeWhitelist(s.chainDb)
 	log.Info("Reading Whitelist", "list", savedList.StrList)
-	server.UpdateWhitelist(savedList.List)
+	server.UpdateWhitelist(sentryWhitelist(savedList.List, s.sentries, s.sentryValidators))
 
 	for {
 		select {
 		case event := <-s.glienickeCh:
-			whitelist := append([]*enode.Node{}, event.Whitelist...)
+			// A validator behind sentries stays connected to them only.
+			if len(s.sentries) > 0 {
+				server.UpdateWhitelist(sentryWhitelist(event.Whitelist, s.sentries, nil))
+				continue
+			}
+			whitelist := sentryWhitelist(event.Whitelist, nil, s.sentryValidators)
 			// Filter the list of need to be dropped peers depending on TD.
 			for _, connectedPeer := range s.protocolManager.peers.Peers() {
 				found := false
@@ -597,6 +718,7 @@ func (s *Ethereum) Stop() error {
 	// Stop all the peer-related stuff first.
 	s.protocolManager.Stop()
 	s.glienickeSub.Unsubscribe()
+	s.chainHeadSub.Unsubscribe()
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
@@ -29,6 +29,7 @@ import (
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/core/rawdb"
 	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/eth/protocols/snap"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/log"
@@ -111,6 +112,11 @@ type Downloader struct {
 	stateDB    ethdb.Database  // Database to state sync into (and deduplicate via)
 	stateBloom *trie.SyncBloom // Bloom filter for fast trie node and contract code existence checks
 
+	// SnapSyncer downloads the state of the pivot over the snap protocol before
+	// any missing trie node is retrieved in snap sync mode (set by the owner).
+	SnapSyncer *snap.Syncer
+	snapSync   bool // Whether the state of the current fast sync cycle is snap synced
+
 	// Statistics
 	syncStatsChainOrigin uint64 // Origin block number where syncing started at
 	syncStatsChainHeight uint64 // Highest block number known when syncing started
@@ -415,6 +421,11 @@ func (d *Downloader) synchronise(id string, hash common.Hash, td *big.Int, mode
 
 	defer d.Cancel() // No matter what, we can't leave the cancel channel open
 
+	// Snap sync is a fast sync whose state is retrieved over the snap protocol
+	d.snapSync = mode == SnapSync
+	if d.snapSync {
+		mode = FastSync
+	}
 	// Atomically set the requested sync mode
 	atomic.StoreUint32(&d.mode, uint32(mode))
 
@@ -1710,7 +1721,7 @@ func (d *Downloader) processFastSyncContent() error {
 	// Start syncing state of the reported head block. This should get us most of
 	// the state of the pivot block.
 	d.pivotLock.RLock()
-	sync := d.syncState(d.pivotHeader.Root)
+	sync := d.syncState(d.pivotHeader)
 	d.pivotLock.RUnlock()
 
 	defer func() {
@@ -1762,7 +1773,7 @@ func (d *Downloader) processFastSyncContent() error {
 		if oldPivot == nil {
 			if pivot.Root != sync.root {
 				sync.Cancel()
-				sync = d.syncState(pivot.Root)
+				sync = d.syncState(pivot)
 
 				go closeOnErr(sync)
 			}
@@ -1800,7 +1811,7 @@ func (d *Downloader) processFastSyncContent() error {
 			// If new pivot block found, cancel old state retrieval and restart
 			if oldPivot != P {
 				sync.Cancel()
-				sync = d.syncState(P.Header.Root)
+				sync = d.syncState(P.Header)
 
 				go closeOnErr(sync)
 				oldPivot = P
@@ -17,6 +17,7 @@
 package downloader
 
 import (
+	"errors"
 	"fmt"
 	"hash"
 	"sync"
@@ -25,6 +26,8 @@ import (
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/core/rawdb"
 	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/eth/protocols/snap"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/log"
 	"github.com/clearmatics/autonity/trie"
@@ -59,10 +62,10 @@ type stateSyncStats struct {
 	pending    uint64 // Number of still pending state entries
 }
 
-// syncState starts downloading state with the given root hash.
-func (d *Downloader) syncState(root common.Hash) *stateSync {
+// syncState starts downloading the state of the given pivot header.
+func (d *Downloader) syncState(pivot *types.Header) *stateSync {
 	// Create the state sync
-	s := newStateSync(d, root)
+	s := newStateSync(d, pivot)
 	select {
 	case d.stateSyncStart <- s:
 		// If we tell the statesync to restart with a new root, we also need
@@ -269,7 +272,42 @@ type stateSync struct {
 	done       chan struct{}  // Channel to signal termination completion
 	err        error          // Any error hit during sync (set before completion)
 
-	root common.Hash
+	pivot *types.Header // Header of the block whose state is synced
+	root  common.Hash
+}
+
+// snapSync downloads the state over the snap protocol before the missing trie
+// nodes are retrieved, rescheduling the trie sync on top of what was written.
+// The sync cycle is aborted if the pivot is rejected by the consensus engine,
+// failing the synchronisation with the peer which sent it. The trie sync alone
+// is run if the snap sync fails for any other reason than a cancellation.
+func (s *stateSync) snapSync() error {
+	var (
+		cancel = make(chan struct{})
+		done   = make(chan struct{})
+	)
+	defer close(done)
+	go func() {
+		select {
+		case <-s.cancel:
+		case <-s.d.cancelCh:
+		case <-done:
+			return
+		}
+		close(cancel)
+	}()
+	err := s.d.SnapSyncer.Sync(s.pivot, cancel)
+	switch {
+	case err == snap.ErrCancelled:
+		return errCancelStateFetch
+	case errors.Is(err, snap.ErrInvalidPivot):
+		log.Warn("Snap sync pivot rejected", "number", s.pivot.Number, "root", s.root, "err", err)
+		return fmt.Errorf("%w: %v", errInvalidChain, err)
+	case err != nil:
+		log.Warn("Snap sync failed, falling back to trie sync", "root", s.root, "err", err)
+	}
+	s.sched = state.NewStateSync(s.root, s.d.stateDB, s.d.stateBloom)
+	return nil
 }
 
 // trieTask represents a single trie node download task, containing a set of
@@ -287,10 +325,10 @@ type codeTask struct {
 
 // newStateSync creates a new state trie download scheduler. This method does not
 // yet start the sync. The user needs to call run to initiate.
-func newStateSync(d *Downloader, root common.Hash) *stateSync {
+func newStateSync(d *Downloader, pivot *types.Header) *stateSync {
 	return &stateSync{
 		d:         d,
-		sched:     state.NewStateSync(root, d.stateDB, d.stateBloom),
+		sched:     state.NewStateSync(pivot.Root, d.stateDB, d.stateBloom),
 		keccak:    sha3.NewLegacyKeccak256(),
 		trieTasks: make(map[common.Hash]*trieTask),
 		codeTasks: make(map[common.Hash]*codeTask),
@@ -298,7 +336,8 @@ func newStateSync(d *Downloader, root common.Hash) *stateSync {
 		cancel:    make(chan struct{}),
 		done:      make(chan struct{}),
 		started:   make(chan struct{}),
-		root:      root,
+		pivot:     pivot,
+		root:      pivot.Root,
 	}
 }
 
@@ -330,6 +369,11 @@ func (s *stateSync) Cancel() error {
 // and timeouts.
 func (s *stateSync) loop() (err error) {
 	close(s.started)
+	if s.d.snapSync && s.d.SnapSyncer != nil {
+		if err := s.snapSync(); err != nil {
+			return err
+		}
+	}
 	// Listen for new peer events to assign tasks to them
 	newPeer := make(chan *peerConnection, 1024)
 	peerSub := s.d.peers.SubscribeNewPeers(newPeer)
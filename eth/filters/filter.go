@@ -142,6 +142,20 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end == -1 {
 		end = head
 	}
+	if isFinalTag(f.begin) {
+		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.begin))
+		if header == nil || err != nil {
+			return nil, err
+		}
+		f.begin = header.Number.Int64()
+	}
+	if isFinalTag(f.end) {
+		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.end))
+		if header == nil || err != nil {
+			return nil, err
+		}
+		end = header.Number.Uint64()
+	}
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
 		logs []*types.Log
@@ -163,6 +177,11 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	return logs, err
 }
 
+// isFinalTag reports whether a filter limit is the finalized or safe block tag.
+func isFinalTag(number int64) bool {
+	return number == rpc.FinalizedBlockNumber.Int64() || number == rpc.SafeBlockNumber.Int64()
+}
+
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
@@ -47,6 +47,12 @@ type OracleBackend interface {
 	ChainConfig() *params.ChainConfig
 }
 
+// MinGasPriceBackend is implemented by the oracle backends knowing the minimum
+// gas price of the Autonity contract, under which no price is suggested.
+type MinGasPriceBackend interface {
+	MinGasPrice(ctx context.Context, header *types.Header) (*big.Int, error)
+}
+
 // Oracle recommends gas prices based on the content of recent
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
@@ -161,6 +167,16 @@ func (gpo *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	if price.Cmp(gpo.maxPrice) > 0 {
 		price = new(big.Int).Set(gpo.maxPrice)
 	}
+	// Never suggest a price the transaction pools reject, even above the cap
+	if backend, ok := gpo.backend.(MinGasPriceBackend); ok {
+		minGasPrice, err := backend.MinGasPrice(ctx, head)
+		if err != nil {
+			return lastPrice, err
+		}
+		if price.Cmp(minGasPrice) < 0 {
+			price = new(big.Int).Set(minGasPrice)
+		}
+	}
 	gpo.cacheLock.Lock()
 	gpo.lastHead = headHash
 	gpo.lastPrice = price
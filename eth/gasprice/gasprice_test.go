@@ -116,3 +116,40 @@ func TestSuggestPrice(t *testing.T) {
 		t.Fatalf("Gas price mismatch, want %d, got %d", expect, got)
 	}
 }
+
+// minGasPriceBackend is a testBackend knowing the minimum gas price of the
+// Autonity contract.
+type minGasPriceBackend struct {
+	*testBackend
+	minGasPrice *big.Int
+}
+
+func (b *minGasPriceBackend) MinGasPrice(ctx context.Context, header *types.Header) (*big.Int, error) {
+	return b.minGasPrice, nil
+}
+
+func TestSuggestPriceMinGasPrice(t *testing.T) {
+	config := Config{
+		Blocks:     3,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+		MaxPrice:   big.NewInt(40 * params.GWei),
+	}
+	for _, c := range []struct {
+		minGasPrice int64
+		expect      int64
+	}{
+		{minGasPrice: 10, expect: 30}, // the sampled price prevails
+		{minGasPrice: 31, expect: 31}, // the minimum gas price prevails
+		{minGasPrice: 50, expect: 50}, // the minimum gas price prevails over the cap
+	} {
+		backend := &minGasPriceBackend{newTestBackend(t), big.NewInt(c.minGasPrice * params.GWei)}
+		got, err := NewOracle(backend, config).SuggestPrice(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+		}
+		if expect := big.NewInt(c.expect * params.GWei); got.Cmp(expect) != 0 {
+			t.Fatalf("Gas price mismatch, want %d, got %d", expect, got)
+		}
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/clearmatics/autonity/crypto"
 	"github.com/clearmatics/autonity/eth/downloader"
 	"github.com/clearmatics/autonity/eth/fetcher"
+	"github.com/clearmatics/autonity/eth/protocols/snap"
 	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/log"
@@ -69,6 +70,7 @@ type ProtocolManager struct {
 	forkFilter forkid.Filter // Fork ID filter, constant across the lifetime of the node
 
 	fastSync  uint32 // Flag whether fast sync is enabled (gets disabled if we already have blocks)
+	snapSync  uint32 // Flag whether fast sync should operate on top of the snap protocol
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
 	checkpointNumber uint64      // Block number for the sync progress validator to cross reference
@@ -107,6 +109,11 @@ type ProtocolManager struct {
 	enodesWhitelist     []*enode.Node
 	enodesWhitelistLock sync.RWMutex
 
+	// the sentry nodes of a validator and the validators of a sentry node,
+	// see sentryWhitelist
+	sentries         []*enode.Node
+	sentryValidators []*enode.Node
+
 	engine consensus.Engine
 	pub    *ecdsa.PublicKey
 }
@@ -154,6 +161,9 @@ func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCh
 		} else {
 			// If fast sync was requested and our database is empty, grant it
 			manager.fastSync = uint32(1)
+			if mode == downloader.SnapSync {
+				manager.snapSync = uint32(1)
+			}
 		}
 	}
 
@@ -172,6 +182,16 @@ func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCh
 	}
 	manager.downloader = downloader.New(manager.checkpointNumber, chaindb, stateBloom, manager.eventMux, blockchain, nil, manager.removePeer)
 
+	// The state root of a snap sync pivot is only trusted once its header, and
+	// so its committed seals, were verified against its parent.
+	verifyPivot := func(header *types.Header) error {
+		if blockchain.GetHeaderByHash(header.ParentHash) == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		return engine.VerifyHeader(blockchain, header, true)
+	}
+	manager.downloader.SnapSyncer = snap.NewSyncer(chaindb, stateBloom, verifyPivot)
+
 	// Construct the fetcher (short sync)
 	validator := func(header *types.Header) error {
 		return engine.VerifyHeader(blockchain, header, true)
@@ -342,7 +362,7 @@ func (pm *ProtocolManager) glienickeEventLoop() {
 		select {
 		case event := <-pm.whitelistCh:
 			pm.enodesWhitelistLock.Lock()
-			pm.enodesWhitelist = event.Whitelist
+			pm.enodesWhitelist = sentryWhitelist(event.Whitelist, pm.sentries, pm.sentryValidators)
 			pm.enodesWhitelistLock.Unlock()
 		// Err() channel will be closed when unsubscribing.
 		case <-pm.whitelistSub.Err():
@@ -1052,6 +1072,20 @@ func (pm *ProtocolManager) FindPeers(targets map[common.Address]struct{}) map[co
 	return m
 }
 
+func (pm *ProtocolManager) Peers() map[common.Address]consensus.Peer {
+	m := make(map[common.Address]consensus.Peer)
+
+	for _, p := range pm.peers.Peers() {
+		pubKey := p.Node().Pubkey()
+		if pubKey == nil {
+			continue
+		}
+		m[crypto.PubkeyToAddress(*pubKey)] = p
+	}
+
+	return m
+}
+
 // NodeInfo represents a short summary of the Ethereum sub-protocol metadata
 // known about the host peer.
 type NodeInfo struct {
@@ -0,0 +1,50 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/eth/protocols/snap"
+)
+
+// snapHandler implements the snap.Backend interface to handle the various network
+// packets that are sent as replies or broadcasts.
+type snapHandler ProtocolManager
+
+func (h *snapHandler) Chain() *core.BlockChain { return h.blockchain }
+
+// RunPeer is invoked when a peer joins on the `snap` protocol. Only whitelisted
+// peers are served and synced from.
+func (h *snapHandler) RunPeer(peer *snap.Peer, hand snap.Handler) error {
+	if err := (*ProtocolManager)(h).IsInWhitelist(peer.Node().ID(), 0, peer.Log()); err != nil {
+		return err
+	}
+	syncer := h.downloader.SnapSyncer
+	if err := syncer.Register(peer); err != nil {
+		peer.Log().Error("Snap peer registration failed", "err", err)
+		return err
+	}
+	defer syncer.Unregister(peer.ID())
+
+	return hand(peer)
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *snapHandler) Handle(peer *snap.Peer, packet snap.Packet) error {
+	return h.downloader.SnapSyncer.Deliver(peer.ID(), packet)
+}
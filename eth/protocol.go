@@ -42,8 +42,9 @@ const protocolName = "eth"
 // ProtocolVersions are the supported versions of the eth protocol (first is primary).
 var ProtocolVersions = []uint{eth65, eth64, eth63}
 
-// protocolLengths are the number of implemented message corresponding to different protocol versions.
-var protocolLengths = map[uint]uint64{eth65: 19, eth64: 19, eth63: 19}
+// protocolLengths are the number of implemented message corresponding to different protocol versions,
+// including the message codes of the tendermint engine (0x11 to 0x13).
+var protocolLengths = map[uint]uint64{eth65: 20, eth64: 20, eth63: 20}
 
 const protocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
@@ -0,0 +1,356 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/core"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state/snapshot"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/light"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/p2p"
+	"github.com/clearmatics/autonity/trie"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+)
+
+// Handler is a callback to invoke from an outside runner after the boilerplate
+// exchanges have passed.
+type Handler func(peer *Peer) error
+
+// Backend defines the data retrieval methods to serve remote requests and the
+// callback methods to invoke on remote deliveries.
+type Backend interface {
+	// Chain retrieves the blockchain object to serve data.
+	Chain() *core.BlockChain
+
+	// RunPeer is invoked when a peer joins on the `snap` protocol. The handler
+	// should do any peer maintenance work, handshakes and validations. If all
+	// is passed, control should be given back to the `handler` to process the
+	// inbound messages going forward.
+	RunPeer(peer *Peer, handler Handler) error
+
+	// Handle is a callback to be invoked when a data packet is received from
+	// the remote peer. Only packets not consumed by the protocol handler will
+	// be forwarded to the backend.
+	Handle(peer *Peer, packet Packet) error
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `snap`.
+func MakeProtocols(backend Backend) []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		version := version // Closure
+
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return backend.RunPeer(newPeer(version, p, rw), func(peer *Peer) error {
+					return handle(backend, peer)
+				})
+			},
+		}
+	}
+	return protocols
+}
+
+// handle is the callback invoked to manage the life cycle of a `snap` peer.
+// When this function terminates, the peer is disconnected.
+func handle(backend Backend, peer *Peer) error {
+	for {
+		if err := handleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `snap`", "err", err)
+			return err
+		}
+	}
+}
+
+// handleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `snap` protocol. The remote connection is torn down upon
+// returning any error.
+func handleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	chain := backend.Chain()
+	switch msg.Code {
+	case GetAccountRangeMsg:
+		var req GetAccountRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return p2p.Send(peer.rw, AccountRangeMsg, ServiceGetAccountRangeQuery(chain.Snapshot(), chain.StateCache().TrieDB(), &req))
+
+	case AccountRangeMsg:
+		res := new(AccountRangePacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return backend.Handle(peer, res)
+
+	case GetStorageRangesMsg:
+		var req GetStorageRangesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return p2p.Send(peer.rw, StorageRangesMsg, ServiceGetStorageRangesQuery(chain.Snapshot(), chain.StateCache().TrieDB(), &req))
+
+	case StorageRangesMsg:
+		res := new(StorageRangesPacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return backend.Handle(peer, res)
+
+	case GetByteCodesMsg:
+		var req GetByteCodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return p2p.Send(peer.rw, ByteCodesMsg, ServiceGetByteCodesQuery(chain.StateCache().TrieDB().DiskDB(), &req))
+
+	case ByteCodesMsg:
+		res := new(ByteCodesPacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return backend.Handle(peer, res)
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// ServiceGetAccountRangeQuery assembles the response to an account range query.
+// An empty response is returned if the state at the requested root is not
+// available in the snapshot. The range is proven unless it covers the whole
+// account trie.
+func ServiceGetAccountRangeQuery(snaps *snapshot.Tree, triedb *trie.Database, req *GetAccountRangePacket) *AccountRangePacket {
+	res := &AccountRangePacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if snaps == nil {
+		return res
+	}
+	it, err := snaps.AccountIterator(req.Root, req.Origin)
+	if err != nil {
+		return res
+	}
+	defer it.Release()
+
+	var (
+		size      uint64
+		last      common.Hash
+		exhausted = true
+	)
+	for it.Next() {
+		hash, account := it.Hash(), common.CopyBytes(it.Account())
+
+		last = hash
+		size += uint64(common.HashLength + len(account))
+		res.Accounts = append(res.Accounts, &AccountData{Hash: hash, Body: account})
+
+		// If we've exceeded the request threshold, abort
+		if bytes.Compare(hash[:], req.Limit[:]) >= 0 || size > req.Bytes {
+			exhausted = false
+			break
+		}
+	}
+	if it.Error() != nil {
+		return &AccountRangePacket{ID: req.ID}
+	}
+	if exhausted && req.Origin == (common.Hash{}) {
+		return res
+	}
+	// Generate the Merkle proofs for the first and last account
+	tr, err := trie.New(req.Root, triedb)
+	if err != nil {
+		log.Debug("Failed to open account trie", "root", req.Root, "err", err)
+		return &AccountRangePacket{ID: req.ID}
+	}
+	proof := light.NewNodeSet()
+	if err := tr.Prove(req.Origin[:], 0, proof); err != nil {
+		log.Warn("Failed to prove account range", "origin", req.Origin, "err", err)
+		return &AccountRangePacket{ID: req.ID}
+	}
+	if last != (common.Hash{}) {
+		if err := tr.Prove(last[:], 0, proof); err != nil {
+			log.Warn("Failed to prove account range", "last", last, "err", err)
+			return &AccountRangePacket{ID: req.ID}
+		}
+	}
+	for _, blob := range proof.NodeList() {
+		res.Proof = append(res.Proof, blob)
+	}
+	return res
+}
+
+// ServiceGetStorageRangesQuery assembles the response to a storage ranges
+// query. The storage of every account but the last one is complete, the slots
+// of the last account are proven if they are incomplete or do not start at
+// the beginning of the storage trie.
+func ServiceGetStorageRangesQuery(snaps *snapshot.Tree, triedb *trie.Database, req *GetStorageRangesPacket) *StorageRangesPacket {
+	res := &StorageRangesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if snaps == nil {
+		return res
+	}
+	snap := snaps.Snapshot(req.Root)
+	if snap == nil {
+		return res
+	}
+	// The origin and limit only apply to a single account, the storage of the
+	// other ones is always served from the start.
+	var (
+		origin common.Hash
+		limit  = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+		size   uint64
+	)
+	if len(req.Origin) > 0 {
+		origin = common.BytesToHash(req.Origin)
+	}
+	if len(req.Limit) > 0 {
+		limit = common.BytesToHash(req.Limit)
+	}
+	for i, account := range req.Accounts {
+		// If we've exceeded the requested data limit, abort without opening
+		// a new storage range (that we'd need to prove due to exceeded size)
+		if size >= req.Bytes {
+			break
+		}
+		if i > 0 {
+			origin = common.Hash{}
+		}
+		it, err := snaps.StorageIterator(req.Root, account, origin)
+		if err != nil {
+			return &StorageRangesPacket{ID: req.ID}
+		}
+		var (
+			storage   []*StorageData
+			last      common.Hash
+			exhausted = true
+		)
+		for it.Next() {
+			hash, slot := it.Hash(), common.CopyBytes(it.Slot())
+
+			last = hash
+			size += uint64(common.HashLength + len(slot))
+			storage = append(storage, &StorageData{Hash: hash, Body: slot})
+
+			// If we've exceeded the request threshold, abort
+			if bytes.Compare(hash[:], limit[:]) >= 0 || size > req.Bytes {
+				exhausted = false
+				break
+			}
+		}
+		err = it.Error()
+		it.Release()
+		if err != nil {
+			return &StorageRangesPacket{ID: req.ID}
+		}
+		res.Slots = append(res.Slots, storage)
+
+		// If the whole storage was served, move on to the next account
+		if exhausted && origin == (common.Hash{}) {
+			continue
+		}
+		// Generate the Merkle proofs for the first and last storage slot, but
+		// only if the response was capped or the origin was set.
+		acc, err := snap.Account(account)
+		if err != nil || acc == nil {
+			return &StorageRangesPacket{ID: req.ID}
+		}
+		root := common.BytesToHash(acc.Root)
+		if len(acc.Root) == 0 {
+			root = emptyRoot
+		}
+		stTrie, err := trie.New(root, triedb)
+		if err != nil {
+			log.Debug("Failed to open storage trie", "root", root, "err", err)
+			return &StorageRangesPacket{ID: req.ID}
+		}
+		proof := light.NewNodeSet()
+		if err := stTrie.Prove(origin[:], 0, proof); err != nil {
+			log.Warn("Failed to prove storage range", "origin", origin, "err", err)
+			return &StorageRangesPacket{ID: req.ID}
+		}
+		if last != (common.Hash{}) {
+			if err := stTrie.Prove(last[:], 0, proof); err != nil {
+				log.Warn("Failed to prove storage range", "last", last, "err", err)
+				return &StorageRangesPacket{ID: req.ID}
+			}
+		}
+		for _, blob := range proof.NodeList() {
+			res.Proof = append(res.Proof, blob)
+		}
+		// Proof terminates the reply as proofs are only added if a node
+		// refuses to serve more data (exception when a contract fetch is
+		// finishing, but that's that).
+		break
+	}
+	return res
+}
+
+// ServiceGetByteCodesQuery assembles the response to a byte codes query.
+func ServiceGetByteCodesQuery(db ethdb.KeyValueReader, req *GetByteCodesPacket) *ByteCodesPacket {
+	res := &ByteCodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+	var bytes uint64
+	for _, hash := range req.Hashes {
+		if hash == emptyCode {
+			// Peers should not request the empty code, but if they do, at
+			// least sent them back a correct response without db lookups
+			res.Codes = append(res.Codes, []byte{})
+		} else if blob := rawdb.ReadCode(db, hash); len(blob) > 0 {
+			res.Codes = append(res.Codes, blob)
+			bytes += uint64(len(blob))
+		}
+		if bytes > req.Bytes {
+			break
+		}
+	}
+	return res
+}
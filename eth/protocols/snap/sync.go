@@ -0,0 +1,685 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state/snapshot"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/light"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/rlp"
+	"github.com/clearmatics/autonity/trie"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+	// emptyCode is the known hash of the empty EVM bytecode.
+	emptyCode = crypto.Keccak256Hash(nil)
+
+	// maxHash is the last hash of the account and storage key spaces.
+	maxHash = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+)
+
+const (
+	// maxRequestSize is the maximum number of bytes to request from a remote peer.
+	maxRequestSize = 512 * 1024
+
+	// maxCodeRequestCount is the maximum number of bytecode blobs to request in a
+	// single query. If this number is too low, we're not filling responses fully
+	// and waste round trip times. If it's too high, we're capping responses and
+	// waste bandwidth.
+	maxCodeRequestCount = maxRequestSize / (24 * 1024) * 4
+
+	// maxStorageSetRequestCount is the maximum number of contracts to request the
+	// storage of in a single query. If this number is too low, we're not filling
+	// responses fully and waste round trip times. If it's too high, we're capping
+	// responses and waste bandwidth.
+	maxStorageSetRequestCount = maxRequestSize / 1024
+
+	// requestTimeout is the maximum time a peer is allowed to spend on serving
+	// a single network request.
+	requestTimeout = 10 * time.Second
+
+	// pivotRecheckInterval is the interval at which the verification of a pivot
+	// whose parent is not yet known locally is retried.
+	pivotRecheckInterval = time.Second
+)
+
+var (
+	// ErrCancelled is returned from snap syncing if the operation was prematurely
+	// terminated.
+	ErrCancelled = errors.New("sync cancelled")
+
+	// ErrInvalidPivot is returned from snap syncing if the consensus engine
+	// rejected the header of the pivot, whose state root can't be trusted.
+	ErrInvalidPivot = errors.New("invalid pivot header")
+
+	// errNoPeers is returned if no peer is able to serve the state being synced.
+	errNoPeers = errors.New("no peer can serve the state")
+)
+
+// SyncPeer abstracts out the methods required for a peer to be synced against
+// with the goal of allowing the construction of mock peers without the full
+// blown networking.
+type SyncPeer interface {
+	// ID retrieves the peer's unique identifier.
+	ID() string
+
+	// RequestAccountRange fetches a batch of accounts rooted in a specific account
+	// trie, starting with the origin.
+	RequestAccountRange(id uint64, root, origin, limit common.Hash, bytes uint64) error
+
+	// RequestStorageRanges fetches a batch of storage slots belonging to one or
+	// more accounts. If slots from only one account is requested, an origin marker
+	// may also be used to retrieve from there.
+	RequestStorageRanges(id uint64, root common.Hash, accounts []common.Hash, origin, limit []byte, bytes uint64) error
+
+	// RequestByteCodes fetches a batch of bytecodes by hash.
+	RequestByteCodes(id uint64, hashes []common.Hash, bytes uint64) error
+
+	// Log retrieves the peer's own contextual logger.
+	Log() log.Logger
+}
+
+// request is a network request waiting for the response of a peer.
+type request struct {
+	peer    string      // Peer to which this request is assigned
+	deliver chan Packet // Channel to deliver the response on, closed if the peer drops
+}
+
+// Syncer is an Ethereum account and storage trie syncer based on snapshots and
+// the snap protocol. Its purpose is to download all the accounts and storage
+// slots of the state of a pivot block from remote peers, in ranges proven
+// against the state root, and to reassemble chunks of the state trie, on top
+// of which a state sync can be run to fix any gaps.
+//
+// The accounts are retrieved in order, along with the code and the storage of
+// each of them. The storage tries are committed when complete, before their
+// account is fed to the account trie, so that any node written to the database
+// is the root of a complete subtrie. A sync interrupted midway thus leaves a
+// database whose missing nodes can be retrieved by the trie node based state
+// sync.
+//
+// The state root of the pivot is only trusted once its header was verified,
+// committed seals included, by the consensus engine.
+type Syncer struct {
+	db          ethdb.KeyValueStore       // Database to store the trie nodes into (and dedup)
+	bloom       *trie.SyncBloom           // Bloom filter to deduplicate nodes for state fixup
+	verifyPivot func(*types.Header) error // Consensus verification of the pivot header
+
+	peers   map[string]SyncPeer // Currently active peers to download from
+	pending map[uint64]*request // Requests waiting for their response
+	nextID  uint64              // Identifier of the next request
+	dead    map[string]struct{} // Peers unable to serve the state currently synced
+	lock    sync.RWMutex        // Protects fields that can change outside of sync (peers, reqs, root)
+
+	// Counters of the current sync cycle, for logging purposes.
+	accountSynced  uint64             // Number of accounts downloaded
+	accountBytes   common.StorageSize // Number of account trie bytes persisted to disk
+	bytecodeSynced uint64             // Number of bytecodes downloaded
+	bytecodeBytes  common.StorageSize // Number of bytecode bytes downloaded
+	storageSynced  uint64             // Number of storage slots downloaded
+	storageBytes   common.StorageSize // Number of storage trie bytes persisted to disk
+	startTime      time.Time          // Time instance when snapshot sync started
+	logTime        time.Time          // Time instance when status was last reported
+}
+
+// NewSyncer creates a new snapshot syncer to download the Ethereum state over
+// the snap protocol. The headers of the pivots are verified with verifyPivot,
+// which returns consensus.ErrUnknownAncestor as long as the parent of the pivot
+// is not in the local header chain.
+func NewSyncer(db ethdb.KeyValueStore, bloom *trie.SyncBloom, verifyPivot func(*types.Header) error) *Syncer {
+	return &Syncer{
+		db:          db,
+		bloom:       bloom,
+		verifyPivot: verifyPivot,
+		peers:       make(map[string]SyncPeer),
+		pending:     make(map[uint64]*request),
+		dead:        make(map[string]struct{}),
+	}
+}
+
+// Register injects a new data source into the syncer's peerset.
+func (s *Syncer) Register(peer SyncPeer) error {
+	// Make sure the peer is not registered yet
+	id := peer.ID()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.peers[id]; ok {
+		log.Error("Snap peer already registered", "id", id)
+		return errors.New("already registered")
+	}
+	s.peers[id] = peer
+	return nil
+}
+
+// Unregister removes a data source from the syncer's peerset.
+func (s *Syncer) Unregister(id string) error {
+	// Remove all traces of the peer from the registry
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.peers[id]; !ok {
+		log.Error("Snap peer not registered", "id", id)
+		return errors.New("not registered")
+	}
+	delete(s.peers, id)
+
+	// Fail the requests assigned to the peer
+	for reqID, req := range s.pending {
+		if req.peer == id {
+			delete(s.pending, reqID)
+			close(req.deliver)
+		}
+	}
+	return nil
+}
+
+// Deliver hands a response of a peer over to the request waiting for it.
+func (s *Syncer) Deliver(peer string, packet Packet) error {
+	var id uint64
+	switch packet := packet.(type) {
+	case *AccountRangePacket:
+		id = packet.ID
+	case *StorageRangesPacket:
+		id = packet.ID
+	case *ByteCodesPacket:
+		id = packet.ID
+	default:
+		return fmt.Errorf("unexpected snap packet type: %T", packet)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	req, ok := s.pending[id]
+	if !ok || req.peer != peer {
+		// Requests timed out are dropped, the response may still come late
+		log.Debug("Unexpected snap response", "peer", peer, "reqid", id, "type", packet.Name())
+		return nil
+	}
+	delete(s.pending, id)
+	req.deliver <- packet
+	return nil
+}
+
+// Sync starts a sync cycle to iterate over the state trie of the given pivot
+// and reconstruct the nodes based on the snapshot leaves. The progress of a
+// cycle is not kept: a cycle interrupted, by a restart or by the pivot
+// moving, starts over from the first account. Only the state already
+// complete on disk is skipped, the whole state if its root is there, the
+// storage tries whose root is there and the bytecodes already stored.
+func (s *Syncer) Sync(pivot *types.Header, cancel <-chan struct{}) error {
+	if err := s.waitPivot(pivot, cancel); err != nil {
+		return err
+	}
+	root := pivot.Root
+	if root == emptyRoot {
+		return nil
+	}
+	if blob := rawdb.ReadTrieNode(s.db, root); len(blob) > 0 {
+		log.Debug("Snapshot sync already completed", "root", root)
+		return nil
+	}
+	s.lock.Lock()
+	s.dead = make(map[string]struct{})
+	s.lock.Unlock()
+
+	s.accountSynced, s.accountBytes = 0, 0
+	s.bytecodeSynced, s.bytecodeBytes = 0, 0
+	s.storageSynced, s.storageBytes = 0, 0
+	s.startTime, s.logTime = time.Now(), time.Now()
+
+	log.Info("Starting snapshot sync cycle", "number", pivot.Number, "root", root)
+	defer s.report(true)
+
+	var (
+		batch   = s.db.NewBatch()
+		accTrie = trie.NewStackTrie(&bloomWriter{batch: batch, bloom: s.bloom})
+		origin  common.Hash
+	)
+	for {
+		hashes, accounts, blobs, cont, err := s.fetchAccounts(root, origin, cancel)
+		if err != nil {
+			return err
+		}
+		if err := s.fetchByteCodes(accounts, batch, cancel); err != nil {
+			return err
+		}
+		if err := s.fetchStorage(root, hashes, accounts, batch, cancel); err != nil {
+			return err
+		}
+		// The storage of all the accounts are complete, feed them to the
+		// account trie.
+		for i, hash := range hashes {
+			accTrie.TryUpdate(hash[:], blobs[i])
+		}
+		s.accountSynced += uint64(len(hashes))
+		if err := s.flush(batch, &s.accountBytes, false); err != nil {
+			return err
+		}
+		s.report(false)
+		if !cont || len(hashes) == 0 {
+			break
+		}
+		origin = incHash(hashes[len(hashes)-1])
+	}
+	if got, _ := accTrie.Commit(); got != root {
+		return fmt.Errorf("state root mismatch: got %x, want %x", got, root)
+	}
+	return s.flush(batch, &s.accountBytes, true)
+}
+
+// waitPivot verifies the header of the pivot, waiting until its parent is
+// known if need be.
+func (s *Syncer) waitPivot(pivot *types.Header, cancel <-chan struct{}) error {
+	for {
+		err := s.verifyPivot(pivot)
+		if err != consensus.ErrUnknownAncestor {
+			if err != nil {
+				return fmt.Errorf("%w %d: %v", ErrInvalidPivot, pivot.Number, err)
+			}
+			return nil
+		}
+		select {
+		case <-time.After(pivotRecheckInterval):
+		case <-cancel:
+			return ErrCancelled
+		}
+	}
+}
+
+// fetchAccounts retrieves the next range of accounts from the origin, proven
+// against the state root, along with the accounts encoded as in the state trie.
+// It also reports if there are more accounts to sync.
+func (s *Syncer) fetchAccounts(root, origin common.Hash, cancel <-chan struct{}) ([]common.Hash, []*snapshot.Account, [][]byte, bool, error) {
+	for {
+		packet, peer, err := s.request(cancel, func(peer SyncPeer, id uint64) error {
+			return peer.RequestAccountRange(id, root, origin, maxHash, maxRequestSize)
+		})
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		res := packet.(*AccountRangePacket)
+		var (
+			hashes   = make([]common.Hash, len(res.Accounts))
+			accounts = make([]*snapshot.Account, len(res.Accounts))
+			keys     = make([][]byte, len(res.Accounts))
+			values   = make([][]byte, len(res.Accounts))
+		)
+		for i, account := range res.Accounts {
+			full, err := snapshot.FullAccount(account.Body)
+			if err == nil {
+				values[i], err = rlp.EncodeToBytes(full)
+			}
+			if err != nil {
+				peer.Log().Debug("Invalid account in snap response", "err", err)
+				values = nil
+				break
+			}
+			hashes[i], accounts[i], keys[i] = account.Hash, &full, common.CopyBytes(account.Hash[:])
+		}
+		if values != nil {
+			end := origin
+			if len(keys) > 0 {
+				end = hashes[len(hashes)-1]
+			}
+			cont, err := verifyRange(root, origin[:], end[:], keys, values, res.Proof)
+			if err == nil {
+				return hashes, accounts, values, cont, nil
+			}
+			peer.Log().Debug("Invalid account range", "root", root, "origin", origin, "err", err)
+		}
+		s.markDead(peer)
+	}
+}
+
+// fetchByteCodes retrieves the codes of the accounts missing in the database.
+func (s *Syncer) fetchByteCodes(accounts []*snapshot.Account, batch ethdb.Batch, cancel <-chan struct{}) error {
+	var missing []common.Hash
+	seen := make(map[common.Hash]struct{})
+	for _, account := range accounts {
+		hash := common.BytesToHash(account.CodeHash)
+		if _, ok := seen[hash]; ok || hash == emptyCode || len(account.CodeHash) == 0 {
+			continue
+		}
+		seen[hash] = struct{}{}
+		if len(rawdb.ReadCode(s.db, hash)) == 0 {
+			missing = append(missing, hash)
+		}
+	}
+	for len(missing) > 0 {
+		hashes := missing
+		if len(hashes) > maxCodeRequestCount {
+			hashes = hashes[:maxCodeRequestCount]
+		}
+		packet, peer, err := s.request(cancel, func(peer SyncPeer, id uint64) error {
+			return peer.RequestByteCodes(id, hashes, maxRequestSize)
+		})
+		if err != nil {
+			return err
+		}
+		// The peer may serve part of the codes only, keep the ones requested
+		// and retry the others.
+		res := packet.(*ByteCodesPacket)
+		requested := make(map[common.Hash]bool, len(hashes))
+		for _, hash := range hashes {
+			requested[hash] = true
+		}
+		hasher := sha3.NewLegacyKeccak256().(crypto.KeccakState)
+		for _, code := range res.Codes {
+			var hash common.Hash
+			hasher.Reset()
+			hasher.Write(code)
+			hasher.Read(hash[:])
+			if !requested[hash] {
+				continue
+			}
+			delete(requested, hash)
+			rawdb.WriteCode(batch, hash, code)
+			if s.bloom != nil {
+				s.bloom.Add(hash[:])
+			}
+			s.bytecodeSynced++
+			s.bytecodeBytes += common.StorageSize(len(code))
+		}
+		if len(requested) == len(hashes) {
+			peer.Log().Debug("Empty byte codes response")
+			s.markDead(peer)
+			continue
+		}
+		rest := missing[len(hashes):]
+		missing = missing[:0:0]
+		for _, hash := range hashes {
+			if requested[hash] {
+				missing = append(missing, hash)
+			}
+		}
+		missing = append(missing, rest...)
+	}
+	return nil
+}
+
+// storageTask is the download of the storage trie of an account.
+type storageTask struct {
+	account common.Hash     // Hash of the account
+	root    common.Hash     // Root of the storage trie
+	trie    *trie.StackTrie // Storage trie being assembled
+	origin  common.Hash     // Hash of the next slot to retrieve
+}
+
+// fetchStorage retrieves the storage of the accounts whose storage trie is
+// missing in the database and commits their storage tries.
+func (s *Syncer) fetchStorage(root common.Hash, hashes []common.Hash, accounts []*snapshot.Account, batch ethdb.Batch, cancel <-chan struct{}) error {
+	var tasks []*storageTask
+	for i, account := range accounts {
+		stRoot := common.BytesToHash(account.Root)
+		if len(account.Root) == 0 || stRoot == emptyRoot {
+			continue
+		}
+		if blob := rawdb.ReadTrieNode(s.db, stRoot); len(blob) > 0 {
+			continue
+		}
+		tasks = append(tasks, &storageTask{account: hashes[i], root: stRoot})
+	}
+	for len(tasks) > 0 {
+		// Request the storage of as many accounts as possible, unless the last
+		// retrieval of the first one was incomplete.
+		batchTasks := tasks
+		if len(batchTasks) > maxStorageSetRequestCount {
+			batchTasks = batchTasks[:maxStorageSetRequestCount]
+		}
+		var origin []byte
+		if batchTasks[0].trie != nil {
+			batchTasks = batchTasks[:1]
+			origin = common.CopyBytes(batchTasks[0].origin[:])
+		}
+		accountHashes := make([]common.Hash, len(batchTasks))
+		for i, task := range batchTasks {
+			accountHashes[i] = task.account
+		}
+		packet, peer, err := s.request(cancel, func(peer SyncPeer, id uint64) error {
+			var limit []byte
+			if origin != nil {
+				limit = maxHash[:]
+			}
+			return peer.RequestStorageRanges(id, root, accountHashes, origin, limit, maxRequestSize)
+		})
+		if err != nil {
+			return err
+		}
+		res := packet.(*StorageRangesPacket)
+		if len(res.Slots) == 0 {
+			peer.Log().Debug("Empty storage ranges response", "root", root)
+			s.markDead(peer)
+			continue
+		}
+		done, err := s.processStorage(res, batchTasks, origin, batch)
+		if err != nil {
+			peer.Log().Debug("Invalid storage ranges", "root", root, "err", err)
+			s.markDead(peer)
+
+			// Restart the downloads partially processed from scratch.
+			for _, task := range batchTasks {
+				task.trie, task.origin = nil, common.Hash{}
+			}
+			continue
+		}
+		tasks = tasks[done:]
+		if err := s.flush(batch, &s.storageBytes, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processStorage verifies the storage ranges of a response and feeds them to
+// the storage tries of the tasks, it returns the number of tasks completed.
+func (s *Syncer) processStorage(res *StorageRangesPacket, tasks []*storageTask, origin []byte, batch ethdb.Batch) (int, error) {
+	if len(res.Slots) > len(tasks) {
+		return 0, fmt.Errorf("too many storage ranges: have %d, want at most %d", len(res.Slots), len(tasks))
+	}
+	for i, slots := range res.Slots {
+		task := tasks[i]
+		keys := make([][]byte, len(slots))
+		values := make([][]byte, len(slots))
+		for j, slot := range slots {
+			keys[j], values[j] = common.CopyBytes(slot.Hash[:]), slot.Body
+		}
+		// Only the last range may be incomplete and carry a proof.
+		var proof [][]byte
+		var first, last []byte
+		if i == len(res.Slots)-1 && len(res.Proof) > 0 {
+			proof = res.Proof
+			first = common.Hash{}.Bytes()
+			if i == 0 && origin != nil {
+				first = origin
+			}
+			last = first
+			if len(keys) > 0 {
+				last = keys[len(keys)-1]
+			}
+		}
+		cont, err := verifyRange(task.root, first, last, keys, values, proof)
+		if err != nil {
+			return 0, err
+		}
+		if task.trie == nil {
+			task.trie = trie.NewStackTrie(&bloomWriter{batch: batch, bloom: s.bloom})
+		}
+		for j, key := range keys {
+			task.trie.TryUpdate(key, values[j])
+		}
+		s.storageSynced += uint64(len(keys))
+		if cont {
+			// The storage of the last account is incomplete, it is retrieved
+			// on its own from now on.
+			task.origin = incHash(common.BytesToHash(last))
+			return i, nil
+		}
+		if got, _ := task.trie.Commit(); got != task.root {
+			return 0, fmt.Errorf("storage root mismatch: got %x, want %x", got, task.root)
+		}
+	}
+	return len(res.Slots), nil
+}
+
+// request sends a request to a peer able to serve the state and waits for
+// its response. The peers failing to respond are skipped for the rest of the
+// sync cycle.
+func (s *Syncer) request(cancel <-chan struct{}, send func(peer SyncPeer, id uint64) error) (Packet, SyncPeer, error) {
+	for {
+		s.lock.Lock()
+		var peer SyncPeer
+		for id, p := range s.peers {
+			if _, ok := s.dead[id]; !ok {
+				peer = p
+				break
+			}
+		}
+		if peer == nil {
+			s.lock.Unlock()
+			return nil, nil, errNoPeers
+		}
+		id := s.nextID
+		s.nextID++
+		req := &request{peer: peer.ID(), deliver: make(chan Packet, 1)}
+		s.pending[id] = req
+		s.lock.Unlock()
+
+		if err := send(peer, id); err != nil {
+			peer.Log().Debug("Failed to send snap request", "err", err)
+			s.drop(id)
+			s.markDead(peer)
+			continue
+		}
+		timeout := time.NewTimer(requestTimeout)
+		select {
+		case packet, ok := <-req.deliver:
+			timeout.Stop()
+			if ok {
+				return packet, peer, nil
+			}
+			// The peer dropped, move on to another one
+		case <-timeout.C:
+			peer.Log().Debug("Snap request timed out", "reqid", id)
+			s.drop(id)
+			s.markDead(peer)
+		case <-cancel:
+			timeout.Stop()
+			s.drop(id)
+			return nil, nil, ErrCancelled
+		}
+	}
+}
+
+// drop removes a pending request.
+func (s *Syncer) drop(id uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pending, id)
+}
+
+// markDead excludes a peer from the requests of the current sync cycle.
+func (s *Syncer) markDead(peer SyncPeer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.dead[peer.ID()] = struct{}{}
+}
+
+// flush writes the batch out once it is large enough or forced to.
+func (s *Syncer) flush(batch ethdb.Batch, size *common.StorageSize, force bool) error {
+	if !force && batch.ValueSize() < ethdb.IdealBatchSize {
+		return nil
+	}
+	*size += common.StorageSize(batch.ValueSize())
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	return nil
+}
+
+// report calculates various status reports and provides it to the user.
+func (s *Syncer) report(force bool) {
+	if !force && time.Since(s.logTime) < 8*time.Second {
+		return
+	}
+	s.logTime = time.Now()
+	log.Info("State sync in progress", "accounts", s.accountSynced, "accountbytes", s.accountBytes,
+		"slots", s.storageSynced, "storagebytes", s.storageBytes,
+		"codes", s.bytecodeSynced, "codebytes", s.bytecodeBytes,
+		"elapsed", common.PrettyDuration(time.Since(s.startTime)))
+}
+
+// verifyRange verifies a range of keys and values proven with the given proof
+// nodes against a trie root, it reports whether there are more entries.
+func verifyRange(root common.Hash, first, last []byte, keys, values [][]byte, proof [][]byte) (bool, error) {
+	if len(proof) == 0 {
+		err, cont := trie.VerifyRangeProof(root, nil, nil, keys, values, nil)
+		return cont, err
+	}
+	nodes := make(light.NodeList, len(proof))
+	for i, node := range proof {
+		nodes[i] = node
+	}
+	err, cont := trie.VerifyRangeProof(root, first, last, keys, values, nodes.NodeSet())
+	return cont, err
+}
+
+// incHash returns the next hash, in lexicographical order (a.k.a plus one).
+func incHash(h common.Hash) common.Hash {
+	return common.BigToHash(new(big.Int).Add(h.Big(), common.Big1))
+}
+
+// bloomWriter is a wrapper around a batch that adds the keys written to the
+// sync bloom, so that the state sync healing the trie does not request them
+// again.
+type bloomWriter struct {
+	batch ethdb.Batch
+	bloom *trie.SyncBloom
+}
+
+// Put writes the key value pair into the batch and adds the key to the bloom.
+func (w *bloomWriter) Put(key, value []byte) error {
+	if w.bloom != nil {
+		w.bloom.Add(key)
+	}
+	return w.batch.Put(key, value)
+}
+
+// Delete removes the key from the batch.
+func (w *bloomWriter) Delete(key []byte) error {
+	return w.batch.Delete(key)
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/consensus"
+	"github.com/clearmatics/autonity/core/rawdb"
+	"github.com/clearmatics/autonity/core/state"
+	"github.com/clearmatics/autonity/core/state/snapshot"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/ethdb"
+	"github.com/clearmatics/autonity/log"
+	"github.com/clearmatics/autonity/trie"
+)
+
+// testSource is a database holding a state and its snapshot, served by the
+// test peers.
+type testSource struct {
+	db     ethdb.Database
+	triedb *trie.Database
+	snaps  *snapshot.Tree
+	root   common.Hash
+}
+
+// newTestSource creates a state with many accounts, contracts with small
+// storage tries and a contract whose storage does not fit in a single response.
+func newTestSource(t *testing.T) *testSource {
+	db := rawdb.NewMemoryDatabase()
+	sdb := state.NewDatabase(db)
+	statedb, err := state.New(common.Hash{}, sdb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5000; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		statedb.AddBalance(addr, big.NewInt(int64(i+1)))
+		statedb.SetNonce(addr, uint64(i))
+		if i%50 == 0 {
+			statedb.SetCode(addr, []byte{0x60, byte(i / 50)})
+			for j := 0; j < i%7+1; j++ {
+				statedb.SetState(addr, common.BigToHash(big.NewInt(int64(j+1))), common.BigToHash(big.NewInt(int64(i+j+1))))
+			}
+		}
+	}
+	large := common.HexToAddress("0x0100000000000000000000000000000000000000")
+	statedb.SetCode(large, []byte{0x60, 0xff})
+	for j := 0; j < 10000; j++ {
+		statedb.SetState(large, common.BigToHash(big.NewInt(int64(j+1))), common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	}
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	return &testSource{
+		db:     db,
+		triedb: sdb.TrieDB(),
+		snaps:  snapshot.New(db, sdb.TrieDB(), 16, root, false),
+		root:   root,
+	}
+}
+
+// testPeer is a mock peer serving the requests of a syncer from a test source.
+type testPeer struct {
+	id      string
+	source  *testSource
+	syncer  *Syncer
+	corrupt bool // Whether to drop an entry from every account range served
+	logger  log.Logger
+}
+
+func newTestPeer(id string, source *testSource, syncer *Syncer) *testPeer {
+	return &testPeer{id: id, source: source, syncer: syncer, logger: log.New("id", id)}
+}
+
+func (p *testPeer) ID() string      { return p.id }
+func (p *testPeer) Log() log.Logger { return p.logger }
+
+func (p *testPeer) RequestAccountRange(id uint64, root, origin, limit common.Hash, bytes uint64) error {
+	res := ServiceGetAccountRangeQuery(p.source.snaps, p.source.triedb, &GetAccountRangePacket{ID: id, Root: root, Origin: origin, Limit: limit, Bytes: bytes})
+	if p.corrupt && len(res.Accounts) > 2 {
+		res.Accounts = append(res.Accounts[:1], res.Accounts[2:]...)
+	}
+	go p.syncer.Deliver(p.id, res)
+	return nil
+}
+
+func (p *testPeer) RequestStorageRanges(id uint64, root common.Hash, accounts []common.Hash, origin, limit []byte, bytes uint64) error {
+	res := ServiceGetStorageRangesQuery(p.source.snaps, p.source.triedb, &GetStorageRangesPacket{ID: id, Root: root, Accounts: accounts, Origin: origin, Limit: limit, Bytes: bytes})
+	go p.syncer.Deliver(p.id, res)
+	return nil
+}
+
+func (p *testPeer) RequestByteCodes(id uint64, hashes []common.Hash, bytes uint64) error {
+	res := ServiceGetByteCodesQuery(p.source.db, &GetByteCodesPacket{ID: id, Hashes: hashes, Bytes: bytes})
+	go p.syncer.Deliver(p.id, res)
+	return nil
+}
+
+// checkState checks that the state of the root, storage and codes included,
+// is complete in the database.
+func checkState(t *testing.T, db ethdb.Database, root common.Hash) {
+	statedb, err := state.New(root, state.NewDatabase(db), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := state.NewNodeIterator(statedb)
+	for it.Next() {
+	}
+	if it.Error != nil {
+		t.Fatalf("state incomplete: %v", it.Error)
+	}
+}
+
+func TestSync(t *testing.T) {
+	source := newTestSource(t)
+	for _, corrupt := range []bool{false, true} {
+		t.Run(fmt.Sprintf("corrupt=%v", corrupt), func(t *testing.T) {
+			db := rawdb.NewMemoryDatabase()
+			syncer := NewSyncer(db, nil, func(*types.Header) error { return nil })
+
+			// A corrupt peer is marked dead and the good one is used instead.
+			bad := newTestPeer("bad", source, syncer)
+			bad.corrupt = corrupt
+			syncer.Register(bad)
+			syncer.Register(newTestPeer("good", source, syncer))
+
+			pivot := &types.Header{Number: big.NewInt(1), Root: source.root}
+			if err := syncer.Sync(pivot, make(chan struct{})); err != nil {
+				t.Fatal(err)
+			}
+			checkState(t, db, source.root)
+		})
+	}
+}
+
+func TestSyncNoPeers(t *testing.T) {
+	source := newTestSource(t)
+	db := rawdb.NewMemoryDatabase()
+	syncer := NewSyncer(db, nil, func(*types.Header) error { return nil })
+
+	bad := newTestPeer("bad", source, syncer)
+	bad.corrupt = true
+	syncer.Register(bad)
+
+	pivot := &types.Header{Number: big.NewInt(1), Root: source.root}
+	if err := syncer.Sync(pivot, make(chan struct{})); err != errNoPeers {
+		t.Fatalf("error mismatch: have %v, want %v", err, errNoPeers)
+	}
+	if blob := rawdb.ReadTrieNode(db, source.root); len(blob) != 0 {
+		t.Fatal("state root written without a complete state")
+	}
+}
+
+func TestSyncInvalidPivot(t *testing.T) {
+	source := newTestSource(t)
+	db := rawdb.NewMemoryDatabase()
+
+	errInvalidSeal := errors.New("invalid committed seal")
+	syncer := NewSyncer(db, nil, func(*types.Header) error { return errInvalidSeal })
+	syncer.Register(newTestPeer("good", source, syncer))
+
+	pivot := &types.Header{Number: big.NewInt(1), Root: source.root}
+	if err := syncer.Sync(pivot, make(chan struct{})); !errors.Is(err, ErrInvalidPivot) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrInvalidPivot)
+	}
+	if it := db.NewIterator(nil, nil); it.Next() {
+		t.Fatalf("state of an invalid pivot downloaded: %x", it.Key())
+	}
+}
+
+func TestSyncUnknownPivotParent(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	syncer := NewSyncer(db, nil, func(*types.Header) error { return consensus.ErrUnknownAncestor })
+
+	// The sync waits for the parent of the pivot until cancelled.
+	cancel := make(chan struct{})
+	time.AfterFunc(2*pivotRecheckInterval, func() { close(cancel) })
+
+	pivot := &types.Header{Number: big.NewInt(1), Root: common.Hash{1}}
+	if err := syncer.Sync(pivot, cancel); err != ErrCancelled {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrCancelled)
+	}
+}
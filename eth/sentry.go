@@ -0,0 +1,41 @@
+package eth
+
+import (
+	"github.com/clearmatics/autonity/p2p/enode"
+)
+
+// sentryWhitelist adapts the whitelist of the network to the sentry mode of
+// the consensus: a validator behind sentry nodes only connects to them, while
+// a sentry node stays connected to the validators it protects whether or not
+// they are whitelisted.
+func sentryWhitelist(whitelist, sentries, validators []*enode.Node) []*enode.Node {
+	if len(sentries) > 0 {
+		return append([]*enode.Node{}, sentries...)
+	}
+	// The configured enodes come first, the dialed address of a protected
+	// validator is usually not the one it is whitelisted with.
+	list := make([]*enode.Node, 0, len(validators)+len(whitelist))
+	list = append(list, validators...)
+	for _, node := range whitelist {
+		protected := false
+		for _, validator := range validators {
+			if node.ID() == validator.ID() {
+				protected = true
+				break
+			}
+		}
+		if !protected {
+			list = append(list, node)
+		}
+	}
+	return list
+}
+
+// setSentries sets the sentry nodes or the protected validators of the node,
+// which are always allowed to connect.
+func (pm *ProtocolManager) setSentries(sentries, validators []*enode.Node) {
+	pm.enodesWhitelistLock.Lock()
+	defer pm.enodesWhitelistLock.Unlock()
+	pm.sentries, pm.sentryValidators = sentries, validators
+	pm.enodesWhitelist = sentryWhitelist(pm.enodesWhitelist, sentries, validators)
+}
@@ -0,0 +1,40 @@
+package eth
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/p2p/enode"
+)
+
+func newSentryTestNodes(t *testing.T, n int) []*enode.Node {
+	nodes := make([]*enode.Node, n)
+	for i := range nodes {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[i] = enode.NewV4(&key.PublicKey, net.IP{127, 0, 0, 1}, 30303+i, 30303+i)
+	}
+	return nodes
+}
+
+func TestSentryWhitelist(t *testing.T) {
+	nodes := newSentryTestNodes(t, 4)
+	whitelist := nodes[:3]
+	// The protected validator is whitelisted under another address.
+	validator := enode.NewV4(nodes[2].Pubkey(), net.IP{10, 0, 0, 1}, 30303, 30303)
+
+	if list := sentryWhitelist(whitelist, nil, nil); !reflect.DeepEqual(list, whitelist) {
+		t.Errorf("whitelist mismatch: have %v, want %v", list, whitelist)
+	}
+	if list, want := sentryWhitelist(whitelist, nodes[3:], nil), nodes[3:]; !reflect.DeepEqual(list, want) {
+		t.Errorf("validator whitelist mismatch: have %v, want %v", list, want)
+	}
+	want := []*enode.Node{validator, nodes[0], nodes[1]}
+	if list := sentryWhitelist(whitelist, nil, []*enode.Node{validator}); !reflect.DeepEqual(list, want) {
+		t.Errorf("sentry whitelist mismatch: have %v, want %v", list, want)
+	}
+}
@@ -0,0 +1,204 @@
+// Package autonityclient provides a typed client for the Autonity contract.
+package autonityclient
+
+//go:generate abigen --abi ../../common/acdefault/generated/Autonity.abi --pkg autonityclient --type Autonity --out autonity.go
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/clearmatics/autonity/accounts/abi/bind"
+	"github.com/clearmatics/autonity/autonity"
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/common/hexutil"
+	"github.com/clearmatics/autonity/core/types"
+	"github.com/clearmatics/autonity/ethclient"
+	"github.com/clearmatics/autonity/rpc"
+)
+
+// The user types of the Autonity contract.
+const (
+	Participant uint8 = iota
+	Stakeholder
+	Validator
+)
+
+// Client wraps the view functions of the Autonity contract, served by the aut
+// RPC namespace, and its operator functions, sent as contract transactions.
+//
+// The view functions take the block to run against, the latest block is used
+// if number is nil. They are run with the contract ABI active at that block,
+// which might differ from the one the bindings were generated from if the
+// contract was upgraded since.
+type Client struct {
+	c        *rpc.Client
+	contract *Autonity
+}
+
+// Dial connects a client to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	return DialContext(context.Background(), rawurl)
+}
+
+// DialContext connects a client to the given URL with the given context.
+func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	contract, err := NewAutonity(autonity.ContractAddress, ethclient.NewClient(c))
+	if err != nil {
+		// The bindings only fail to parse their own ABI.
+		panic(err)
+	}
+	return &Client{c: c, contract: contract}
+}
+
+// Close closes the underlying RPC connection.
+func (ac *Client) Close() {
+	ac.c.Close()
+}
+
+// Contract returns the bindings of the Autonity contract, for the functions
+// the client does not wrap.
+func (ac *Client) Contract() *Autonity {
+	return ac.contract
+}
+
+// View functions
+
+// GetCommittee returns the committee elected at the given block.
+func (ac *Client) GetCommittee(ctx context.Context, number *big.Int) ([]AutonityCommitteeMember, error) {
+	var committee []AutonityCommitteeMember
+	err := ac.call(ctx, &committee, "getCommittee", number)
+	return committee, err
+}
+
+// GetUser returns the user of the given account, which is empty if there is no
+// such user.
+func (ac *Client) GetUser(ctx context.Context, account common.Address, number *big.Int) (*AutonityUser, error) {
+	var user AutonityUser
+	if err := ac.call(ctx, &user, "getUser", number, account); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetValidators returns the addresses of the validators.
+func (ac *Client) GetValidators(ctx context.Context, number *big.Int) ([]common.Address, error) {
+	var validators []common.Address
+	err := ac.call(ctx, &validators, "getValidators", number)
+	return validators, err
+}
+
+// GetStakeholders returns the addresses of the stakeholders.
+func (ac *Client) GetStakeholders(ctx context.Context, number *big.Int) ([]common.Address, error) {
+	var stakeholders []common.Address
+	err := ac.call(ctx, &stakeholders, "getStakeholders", number)
+	return stakeholders, err
+}
+
+// GetWhitelist returns the enodes allowed to join the network.
+func (ac *Client) GetWhitelist(ctx context.Context, number *big.Int) ([]string, error) {
+	var whitelist []string
+	err := ac.call(ctx, &whitelist, "getWhitelist", number)
+	return whitelist, err
+}
+
+// GetMinimumGasPrice returns the minimum gas price of the transactions.
+func (ac *Client) GetMinimumGasPrice(ctx context.Context, number *big.Int) (*big.Int, error) {
+	var price *big.Int
+	err := ac.call(ctx, &price, "getMinimumGasPrice", number)
+	return price, err
+}
+
+// GetVersion returns the version of the contract.
+func (ac *Client) GetVersion(ctx context.Context, number *big.Int) (string, error) {
+	var version string
+	err := ac.call(ctx, &version, "getVersion", number)
+	return version, err
+}
+
+// BalanceOf returns the stake held by the given account.
+func (ac *Client) BalanceOf(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := ac.call(ctx, &balance, "balanceOf", number, account)
+	return balance, err
+}
+
+// TotalSupply returns the total stake issued.
+func (ac *Client) TotalSupply(ctx context.Context, number *big.Int) (*big.Int, error) {
+	var supply *big.Int
+	err := ac.call(ctx, &supply, "totalSupply", number)
+	return supply, err
+}
+
+// RewardDistribution returns the rewards distributed to the stakeholders by
+// the given block.
+func (ac *Client) RewardDistribution(ctx context.Context, number *big.Int) (*types.RewardDistribution, error) {
+	var rewards *types.RewardDistribution
+	err := ac.call(ctx, &rewards, "getRewardDistribution", number)
+	return rewards, err
+}
+
+// call calls the aut RPC method of the given view function, with the block
+// number as last argument.
+func (ac *Client) call(ctx context.Context, result interface{}, method string, number *big.Int, args ...interface{}) error {
+	return ac.c.CallContext(ctx, result, "aut_"+method, append(args, toBlockNumArg(number))...)
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+// Operator functions
+
+// AddUser adds a user of the given type, which must be a participant if it
+// has no stake.
+func (ac *Client) AddUser(opts *bind.TransactOpts, address common.Address, stake *big.Int, enode string, userType uint8) (*types.Transaction, error) {
+	return ac.contract.AddUser(opts, address, stake, enode, userType)
+}
+
+// RemoveUser removes a user and burns its stake.
+func (ac *Client) RemoveUser(opts *bind.TransactOpts, address common.Address) (*types.Transaction, error) {
+	return ac.contract.RemoveUser(opts, address)
+}
+
+// ChangeUserType changes the type of a user.
+func (ac *Client) ChangeUserType(opts *bind.TransactOpts, address common.Address, userType uint8) (*types.Transaction, error) {
+	return ac.contract.ChangeUserType(opts, address, userType)
+}
+
+// Mint adds new stake to the balance of an account.
+func (ac *Client) Mint(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return ac.contract.Mint(opts, account, amount)
+}
+
+// Burn removes stake from the balance of an account.
+func (ac *Client) Burn(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return ac.contract.Burn(opts, account, amount)
+}
+
+// SetMinimumGasPrice sets the minimum gas price of the transactions.
+func (ac *Client) SetMinimumGasPrice(opts *bind.TransactOpts, price *big.Int) (*types.Transaction, error) {
+	return ac.contract.SetMinimumGasPrice(opts, price)
+}
+
+// SetCommitteeSize sets the maximum size of the committee.
+func (ac *Client) SetCommitteeSize(opts *bind.TransactOpts, size *big.Int) (*types.Transaction, error) {
+	return ac.contract.SetCommitteeSize(opts, size)
+}
+
+// UpgradeContract triggers the upgrade of the contract to the given bytecode
+// and ABI, which happens when the block of the transaction is finalized.
+func (ac *Client) UpgradeContract(opts *bind.TransactOpts, bytecode, abi, version string) (*types.Transaction, error) {
+	return ac.contract.UpgradeContract(opts, bytecode, abi, version)
+}
@@ -0,0 +1,86 @@
+package autonityclient
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/clearmatics/autonity/accounts/abi"
+	"github.com/clearmatics/autonity/common"
+	"github.com/clearmatics/autonity/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// testAutService serves view functions the way the aut namespace does, by
+// unpacking the packed results with the contract ABI.
+type testAutService struct {
+	abi       abi.ABI
+	user      AutonityUser
+	committee []AutonityCommitteeMember
+	numbers   []rpc.BlockNumber
+}
+
+func (s *testAutService) result(method string, number *rpc.BlockNumberOrHash, values ...interface{}) (interface{}, error) {
+	n, _ := number.Number()
+	s.numbers = append(s.numbers, n)
+	packed, err := s.abi.Methods[method].Outputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.abi.Unpack(method, packed)
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+func (s *testAutService) GetUser(account common.Address, number *rpc.BlockNumberOrHash) (interface{}, error) {
+	return s.result("getUser", number, s.user)
+}
+
+func (s *testAutService) GetCommittee(number *rpc.BlockNumberOrHash) (interface{}, error) {
+	return s.result("getCommittee", number, s.committee)
+}
+
+func (s *testAutService) GetMinimumGasPrice(number *rpc.BlockNumberOrHash) (interface{}, error) {
+	return s.result("getMinimumGasPrice", number, big.NewInt(5000))
+}
+
+func TestViewFunctions(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(AutonityABI))
+	require.NoError(t, err)
+	service := &testAutService{
+		abi: parsed,
+		user: AutonityUser{
+			Addr:     common.HexToAddress("0x01"),
+			UserType: Validator,
+			Stake:    big.NewInt(10),
+			Enode:    "enode://01@127.0.0.1:30303",
+		},
+		committee: []AutonityCommitteeMember{
+			{Addr: common.HexToAddress("0x01"), VotingPower: big.NewInt(10)},
+			{Addr: common.HexToAddress("0x02"), VotingPower: big.NewInt(20)},
+		},
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("aut", service))
+	defer server.Stop()
+	client := New(rpc.DialInProc(server))
+	defer client.Close()
+	ctx := context.Background()
+
+	user, err := client.GetUser(ctx, service.user.Addr, nil)
+	require.NoError(t, err)
+	require.Equal(t, service.user, *user)
+
+	committee, err := client.GetCommittee(ctx, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, service.committee, committee)
+
+	price, err := client.GetMinimumGasPrice(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5000), price)
+
+	require.Equal(t, []rpc.BlockNumber{rpc.LatestBlockNumber, 3, rpc.LatestBlockNumber}, service.numbers)
+}
@@ -80,7 +80,8 @@ func (ec *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Blo
 }
 
 // BlockByNumber returns a block from the current canonical chain. If number is nil, the
-// latest known block is returned.
+// latest known block is returned. The latest final block is returned for the
+// rpc.FinalizedBlockNumber and rpc.SafeBlockNumber numbers.
 //
 // Note that loading full blocks requires two requests. Use HeaderByNumber
 // if you don't need all transactions or uncle headers.
@@ -177,7 +178,8 @@ func (ec *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.He
 }
 
 // HeaderByNumber returns a block header from the current canonical chain. If number is
-// nil, the latest known header is returned.
+// nil, the latest known header is returned. The latest final header is returned for
+// the rpc.FinalizedBlockNumber and rpc.SafeBlockNumber numbers.
 func (ec *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	var head *types.Header
 	err := ec.c.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
@@ -293,6 +295,14 @@ func toBlockNumArg(number *big.Int) string {
 	if number.Cmp(pending) == 0 {
 		return "pending"
 	}
+	finalized := big.NewInt(int64(rpc.FinalizedBlockNumber))
+	if number.Cmp(finalized) == 0 {
+		return "finalized"
+	}
+	safe := big.NewInt(int64(rpc.SafeBlockNumber))
+	if number.Cmp(safe) == 0 {
+		return "safe"
+	}
 	return hexutil.EncodeBig(number)
 }
 
@@ -528,6 +538,49 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data))
 }
 
+// Tendermint consensus
+
+// BlockSigners returns the committee members which sealed the given block with
+// their voting power. The latest block is used if number is nil.
+func (ec *Client) BlockSigners(ctx context.Context, number *big.Int) (*types.BlockSigners, error) {
+	var signers *types.BlockSigners
+	err := ec.c.CallContext(ctx, &signers, "tendermint_getBlockSigners", toBlockNumArg(number))
+	if err == nil && signers == nil {
+		err = ethereum.NotFound
+	}
+	return signers, err
+}
+
+// BlockRound returns the consensus round the given block was decided at. The
+// latest block is used if number is nil.
+func (ec *Client) BlockRound(ctx context.Context, number *big.Int) (uint64, error) {
+	var round hexutil.Uint64
+	err := ec.c.CallContext(ctx, &round, "tendermint_getBlockRound", toBlockNumArg(number))
+	return uint64(round), err
+}
+
+// BlockProposer returns the committee member which proposed the given block.
+// The latest block is used if number is nil.
+func (ec *Client) BlockProposer(ctx context.Context, number *big.Int) (common.Address, error) {
+	var proposer common.Address
+	err := ec.c.CallContext(ctx, &proposer, "tendermint_getProposer", toBlockNumArg(number))
+	return proposer, err
+}
+
+// Quorum returns the voting power needed to decide the given block. The latest
+// block is used if number is nil.
+func (ec *Client) Quorum(ctx context.Context, number *big.Int) (uint64, error) {
+	var quorum uint64
+	err := ec.c.CallContext(ctx, &quorum, "tendermint_getQuorum", toBlockNumArg(number))
+	return quorum, err
+}
+
+// SubscribeFinalizedBlocks subscribes to notifications about the signers of
+// every block added to the chain on the given channel.
+func (ec *Client) SubscribeFinalizedBlocks(ctx context.Context, ch chan<- *types.BlockSigners) (ethereum.Subscription, error) {
+	return ec.c.Subscribe(ctx, "tendermint", ch, "newFinalizedBlocks")
+}
+
 func toCallArg(msg ethereum.CallMsg) interface{} {
 	arg := map[string]interface{}{
 		"from": msg.From,
@@ -31,6 +31,10 @@ type Batch interface {
 	// Write flushes any accumulated data to disk.
 	Write() error
 
+	// WriteSync flushes any accumulated data to disk and only returns once it
+	// reached stable storage, so that it survives a crash of the host.
+	WriteSync() error
+
 	// Reset resets the batch for reuse.
 	Reset()
 
@@ -274,7 +274,7 @@ func TestDatabaseSuite(t *testing.T, New func() ethdb.KeyValueStore) {
 		b.Delete([]byte("3"))
 		b.Put([]byte("3"), nil)
 
-		if err := b.Write(); err != nil {
+		if err := b.WriteSync(); err != nil {
 			t.Fatal(err)
 		}
 
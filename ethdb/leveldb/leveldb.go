@@ -446,6 +446,11 @@ func (b *batch) Write() error {
 	return b.db.Write(b.b, nil)
 }
 
+// WriteSync flushes any accumulated data to disk and syncs it.
+func (b *batch) WriteSync() error {
+	return b.db.Write(b.b, &opt.WriteOptions{Sync: true})
+}
+
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	b.b.Reset()
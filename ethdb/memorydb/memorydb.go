@@ -235,6 +235,12 @@ func (b *batch) Write() error {
 	return nil
 }
 
+// WriteSync flushes any accumulated data to the memory database, which has no
+// stable storage to sync.
+func (b *batch) WriteSync() error {
+	return b.Write()
+}
+
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	b.writes = b.writes[:0]
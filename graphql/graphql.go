@@ -20,6 +20,7 @@ package graphql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/clearmatics/autonity"
@@ -39,6 +40,14 @@ var (
 	errBlockInvariant = errors.New("block objects must be instantiated with at least one of num or hash")
 )
 
+// blockTags maps the values of the BlockTag enum to the block numbers they
+// stand for.
+var blockTags = map[string]rpc.BlockNumber{
+	"LATEST":    rpc.LatestBlockNumber,
+	"FINALIZED": rpc.FinalizedBlockNumber,
+	"SAFE":      rpc.SafeBlockNumber,
+}
+
 // Account represents an Ethereum account at a particular block.
 type Account struct {
 	backend       ethapi.Backend
@@ -904,6 +913,7 @@ type Resolver struct {
 func (r *Resolver) Block(ctx context.Context, args struct {
 	Number *hexutil.Uint64
 	Hash   *common.Hash
+	Tag    *string
 }) (*Block, error) {
 	var block *Block
 	if args.Number != nil {
@@ -919,6 +929,16 @@ func (r *Resolver) Block(ctx context.Context, args struct {
 			backend:      r.backend,
 			numberOrHash: &numberOrHash,
 		}
+	} else if args.Tag != nil {
+		number, ok := blockTags[*args.Tag]
+		if !ok {
+			return nil, fmt.Errorf("unknown block tag %q", *args.Tag)
+		}
+		numberOrHash := rpc.BlockNumberOrHashWithNumber(number)
+		block = &Block{
+			backend:      r.backend,
+			numberOrHash: &numberOrHash,
+		}
 	} else {
 		numberOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
 		block = &Block{
@@ -65,6 +65,34 @@ func TestGraphQLHTTPOnSamePort_GQLRequest_Successful(t *testing.T) {
 	assert.Equal(t, expected, string(bodyBytes))
 }
 
+// Tests that blocks can be fetched by tag, the test chain not being a Tendermint
+// one has no final block.
+func TestGraphQLBlockTag(t *testing.T) {
+	stack := createNode(t, true)
+	defer stack.Close()
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	for tag, expected := range map[string]string{
+		"LATEST":    "{\"data\":{\"block\":{\"number\":\"0x0\"}}}",
+		"FINALIZED": "{\"errors\":[{\"message\":\"finalized block not found\",\"path\":[\"block\"]}],\"data\":{\"block\":null}}",
+		"SAFE":      "{\"errors\":[{\"message\":\"safe block not found\",\"path\":[\"block\"]}],\"data\":{\"block\":null}}",
+	} {
+		body := strings.NewReader(fmt.Sprintf("{\"query\": \"{block(tag: %s){number}}\",\"variables\": null}", tag))
+		gqlReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/graphql", "127.0.0.1:9393"), body)
+		if err != nil {
+			t.Fatal("could not issue new http request ", err)
+		}
+		gqlReq.Header.Set("Content-Type", "application/json")
+		resp := doHTTPRequest(t, gqlReq)
+		bodyBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read from response body: %v", err)
+		}
+		assert.Equal(t, expected, string(bodyBytes), tag)
+	}
+}
+
 // Tests that a graphQL request is not handled successfully when graphql is not enabled on the specified endpoint
 func TestGraphQLHTTPOnSamePort_GQLRequest_Unsuccessful(t *testing.T) {
 	stack := createNode(t, false)
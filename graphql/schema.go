@@ -294,10 +294,20 @@ const schema string = `
       estimateGas(data: CallData!): Long!
     }
 
+    # BlockTag names a block resolved against the current state of the chain.
+    enum BlockTag {
+        # LATEST is the most recent known block.
+        LATEST
+        # FINALIZED is the most recent block that can no longer be reverted.
+        FINALIZED
+        # SAFE is the most recent block that is safe from chain reorganisations.
+        SAFE
+    }
+
     type Query {
-        # Block fetches an Ethereum block by number or by hash. If neither is
-        # supplied, the most recent known block is returned.
-        block(number: Long, hash: Bytes32): Block
+        # Block fetches an Ethereum block by number, by hash or by tag. If none
+        # is supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32, tag: BlockTag): Block
         # Blocks returns all the blocks between two numbers, inclusive. If
         # to is not supplied, it defaults to the most recent known block.
         blocks(from: Long!, to: Long): [Block!]!
@@ -362,6 +362,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceFinalize',
+			call: 'debug_traceFinalize',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceBlockByHash',
 			call: 'debug_traceBlockByHash',
@@ -396,6 +402,12 @@ web3._extend({
 			call: 'debug_storageRangeAt',
 			params: 5,
 		}),
+		new web3._extend.Method({
+			name: 'simulateContractUpgrade',
+			call: 'debug_simulateContractUpgrade',
+			params: 3,
+			inputFormatter: [null, null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getModifiedAccountsByNumber',
 			call: 'debug_getModifiedAccountsByNumber',
@@ -843,7 +855,8 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'getContractABI',
 			call: 'tendermint_getContractABI',
-			params: 0
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		}),
 		new web3._extend.Method({
 			name: 'getWhitelist',
@@ -854,6 +867,47 @@ web3._extend({
 			name: 'getCoreState',
 			call: 'tendermint_getCoreState',
 			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getEvidence',
+			call: 'tendermint_getEvidence',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getBLSKey',
+			call: 'tendermint_getBLSKey',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorUptime',
+			call: 'tendermint_getValidatorUptime',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockSigners',
+			call: 'tendermint_getBlockSigners',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockRound',
+			call: 'tendermint_getBlockRound',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter],
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'getProposer',
+			call: 'tendermint_getProposer',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getQuorum',
+			call: 'tendermint_getQuorum',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		})
 	]
 });
@@ -63,9 +63,26 @@ func (b *LesApiBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
 		return b.eth.blockchain.CurrentHeader(), nil
 	}
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		return b.finalHeader(number)
+	}
 	return b.eth.blockchain.GetHeaderByNumberOdr(ctx, uint64(number))
 }
 
+// finalHeader returns the header the finalized and safe tags resolve to. Tendermint
+// blocks are final as soon as they are committed with a quorum of committed seals,
+// and only headers whose seals were verified make it into the chain, so it is the
+// chain head. Other engines do not provide finality.
+func (b *LesApiBackend) finalHeader(number rpc.BlockNumber) (*types.Header, error) {
+	if b.eth.blockchain.Config().Tendermint == nil {
+		if number == rpc.SafeBlockNumber {
+			return nil, errors.New("safe block not found")
+		}
+		return nil, errors.New("finalized block not found")
+	}
+	return b.eth.blockchain.CurrentHeader(), nil
+}
+
 func (b *LesApiBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
 	if blockNr, ok := blockNrOrHash.Number(); ok {
 		return b.HeaderByNumber(ctx, blockNr)
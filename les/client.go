@@ -26,6 +26,7 @@ import (
 	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/common/mclock"
 	"github.com/clearmatics/autonity/consensus"
+	tendermintBackend "github.com/clearmatics/autonity/consensus/tendermint/backend"
 	"github.com/clearmatics/autonity/core"
 	"github.com/clearmatics/autonity/core/bloombits"
 	"github.com/clearmatics/autonity/core/rawdb"
@@ -34,6 +35,7 @@ import (
 	"github.com/clearmatics/autonity/eth/downloader"
 	"github.com/clearmatics/autonity/eth/filters"
 	"github.com/clearmatics/autonity/eth/gasprice"
+	"github.com/clearmatics/autonity/ethdb"
 	"github.com/clearmatics/autonity/event"
 	"github.com/clearmatics/autonity/internal/ethapi"
 	lpc "github.com/clearmatics/autonity/les/lespay/client"
@@ -104,7 +106,7 @@ func New(stack *node.Node, config *eth.Config) (*LightEthereum, error) {
 		eventMux:       stack.EventMux(),
 		reqDist:        newRequestDistributor(peers, &mclock.System{}),
 		accountManager: stack.AccountManager(),
-		engine:         eth.CreateConsensusEngine(stack, chainConfig, config, nil, false, chainDb, nil),
+		engine:         createConsensusEngine(stack, chainConfig, config, chainDb),
 		bloomRequests:  make(chan chan *bloombits.Retrieval),
 		bloomIndexer:   eth.NewBloomIndexer(chainDb, params.BloomBitsBlocksClient, params.HelperTrieConfirmations),
 		valueTracker:   lpc.NewValueTracker(lespayDb, &mclock.System{}, requestList, time.Minute, 1/float64(time.Hour), 1/float64(time.Hour*100), 1/float64(time.Hour*1000)),
@@ -132,6 +134,12 @@ func New(stack *node.Node, config *eth.Config) (*LightEthereum, error) {
 	if checkpoint == nil {
 		checkpoint = params.TrustedCheckpoints[genesisHash]
 	}
+	if chainConfig.Tendermint != nil && checkpoint != nil {
+		// The committee of a header is only known from the headers before it,
+		// which syncing from a checkpoint would skip.
+		log.Warn("Ignoring the trusted checkpoint of a tendermint chain", "section", checkpoint.SectionIndex)
+		checkpoint = nil
+	}
 	// Note: NewLightChain adds the trusted checkpoint so it needs an ODR with
 	// indexers already set but not started yet
 	if leth.blockchain, err = light.NewLightChain(leth.odr, leth.chainConfig, leth.engine, checkpoint); err != nil {
@@ -140,8 +148,11 @@ func New(stack *node.Node, config *eth.Config) (*LightEthereum, error) {
 	leth.chainReader = leth.blockchain
 	leth.txPool = light.NewTxPool(leth.chainConfig, leth.blockchain, leth.relay)
 
-	// Set up checkpoint oracle.
-	leth.oracle = leth.setupOracle(stack, genesisHash, config)
+	// Set up checkpoint oracle, tendermint chains are always synced from the
+	// genesis to follow the committee changes.
+	if chainConfig.Tendermint == nil {
+		leth.oracle = leth.setupOracle(stack, genesisHash, config)
+	}
 
 	// Note: AddChildIndexer starts the update process for the child
 	leth.bloomIndexer.AddChildIndexer(leth.bloomTrieIndexer)
@@ -181,6 +192,19 @@ func New(stack *node.Node, config *eth.Config) (*LightEthereum, error) {
 	return leth, nil
 }
 
+// createConsensusEngine creates the consensus engine verifying the headers of
+// the light chain. On tendermint chains, the engine checks the committed seals
+// of every header against the committee of its parent with the quorum rules of
+// the full nodes, and so follows the committee changes header by header, the
+// total difficulty being the height as every block has a difficulty of 1. It
+// never signs anything and doesn't connect to a remote signer.
+func createConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, config *eth.Config, db ethdb.Database) consensus.Engine {
+	if chainConfig.Tendermint != nil {
+		return tendermintBackend.New(&config.Tendermint, stack.Config().NodeKey(), db, chainConfig, nil)
+	}
+	return eth.CreateConsensusEngine(stack, chainConfig, config, nil, false, db, nil)
+}
+
 // vtSubscription implements serverPeerSubscriber
 type vtSubscription lpc.ValueTracker
 
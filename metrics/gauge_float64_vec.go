@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GaugeFloat64Vecs hold a family of GaugeFloat64s sharing a name, each of
+// them told apart by its values for a fixed set of labels. The reporters
+// supporting labels export them as such, e.g. as Prometheus labels, so that
+// the name of the metric need not carry the values.
+type GaugeFloat64Vec interface {
+	Labels() []string
+	With(values ...string) GaugeFloat64
+	Delete(values ...string)
+	Each(func(values []string, gauge GaugeFloat64))
+}
+
+// GetOrRegisterGaugeFloat64Vec returns an existing GaugeFloat64Vec or
+// constructs and registers a new StandardGaugeFloat64Vec with the given
+// labels.
+func GetOrRegisterGaugeFloat64Vec(name string, r Registry, labels ...string) GaugeFloat64Vec {
+	if nil == r {
+		r = DefaultRegistry
+	}
+	return r.GetOrRegister(name, NewGaugeFloat64Vec(labels...)).(GaugeFloat64Vec)
+}
+
+// NewGaugeFloat64Vec constructs a new StandardGaugeFloat64Vec with the given
+// labels.
+func NewGaugeFloat64Vec(labels ...string) GaugeFloat64Vec {
+	if !Enabled {
+		return NilGaugeFloat64Vec{}
+	}
+	return &StandardGaugeFloat64Vec{
+		labels: labels,
+		gauges: make(map[string]*labelledGaugeFloat64),
+	}
+}
+
+// NilGaugeFloat64Vec is a no-op GaugeFloat64Vec.
+type NilGaugeFloat64Vec struct{}
+
+// Labels is a no-op.
+func (NilGaugeFloat64Vec) Labels() []string { return nil }
+
+// With returns a no-op GaugeFloat64.
+func (NilGaugeFloat64Vec) With(values ...string) GaugeFloat64 { return NilGaugeFloat64{} }
+
+// Delete is a no-op.
+func (NilGaugeFloat64Vec) Delete(values ...string) {}
+
+// Each is a no-op.
+func (NilGaugeFloat64Vec) Each(func(values []string, gauge GaugeFloat64)) {}
+
+// StandardGaugeFloat64Vec is the standard implementation of a
+// GaugeFloat64Vec, it keeps a StandardGaugeFloat64 per set of label values
+// until it is deleted.
+type StandardGaugeFloat64Vec struct {
+	labels []string
+	mutex  sync.Mutex
+	gauges map[string]*labelledGaugeFloat64
+}
+
+type labelledGaugeFloat64 struct {
+	values []string
+	gauge  GaugeFloat64
+}
+
+// Labels returns the names of the labels.
+func (v *StandardGaugeFloat64Vec) Labels() []string {
+	return v.labels
+}
+
+// With returns the gauge of the given label values, in the order of the
+// labels, and creates it if needed.
+func (v *StandardGaugeFloat64Vec) With(values ...string) GaugeFloat64 {
+	key := v.key(values)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if g, ok := v.gauges[key]; ok {
+		return g.gauge
+	}
+	g := &labelledGaugeFloat64{
+		values: append([]string(nil), values...),
+		gauge:  NewGaugeFloat64(),
+	}
+	v.gauges[key] = g
+	return g.gauge
+}
+
+// Delete removes the gauge of the given label values.
+func (v *StandardGaugeFloat64Vec) Delete(values ...string) {
+	key := v.key(values)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.gauges, key)
+}
+
+// Each calls f with a snapshot of every gauge, sorted by label values.
+func (v *StandardGaugeFloat64Vec) Each(f func(values []string, gauge GaugeFloat64)) {
+	v.mutex.Lock()
+	keys := make([]string, 0, len(v.gauges))
+	for key := range v.gauges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	gauges := make([]*labelledGaugeFloat64, len(keys))
+	for i, key := range keys {
+		gauges[i] = v.gauges[key]
+	}
+	v.mutex.Unlock()
+
+	for _, g := range gauges {
+		f(g.values, g.gauge.Snapshot())
+	}
+}
+
+func (v *StandardGaugeFloat64Vec) key(values []string) string {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("%d label values given for labels %v", len(values), v.labels))
+	}
+	return strings.Join(values, "\xff")
+}
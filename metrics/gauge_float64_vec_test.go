@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGaugeFloat64Vec(t *testing.T) {
+	v := NewGaugeFloat64Vec("address", "role")
+	v.With("b", "validator").Update(2)
+	v.With("a", "participant").Update(1)
+	if g := v.With("b", "validator"); g.Value() != 2 {
+		t.Errorf("v.With(b, validator).Value(): 2 != %v\n", g.Value())
+	}
+	v.With("c", "stakeholder").Update(3)
+	v.Delete("c", "stakeholder")
+
+	var values [][]string
+	v.Each(func(labels []string, g GaugeFloat64) {
+		values = append(values, labels)
+	})
+	if expected := [][]string{{"a", "participant"}, {"b", "validator"}}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("v.Each(): %v != %v\n", expected, values)
+	}
+}
+
+func TestGetOrRegisterGaugeFloat64Vec(t *testing.T) {
+	r := NewRegistry()
+	GetOrRegisterGaugeFloat64Vec("foo", r, "address").With("a").Update(47.0)
+	if g := GetOrRegisterGaugeFloat64Vec("foo", r, "address").With("a"); g.Value() != 47.0 {
+		t.Fatal(g)
+	}
+}
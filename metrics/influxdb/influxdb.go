@@ -160,6 +160,25 @@ func (r *reporter) send() error {
 				},
 				Time: now,
 			})
+		case metrics.GaugeFloat64Vec:
+			labels := metric.Labels()
+			metric.Each(func(values []string, gauge metrics.GaugeFloat64) {
+				tags := make(map[string]string, len(r.tags)+len(labels))
+				for k, v := range r.tags {
+					tags[k] = v
+				}
+				for i, label := range labels {
+					tags[label] = values[i]
+				}
+				pts = append(pts, client.Point{
+					Measurement: fmt.Sprintf("%s%s.gauge", namespace, name),
+					Tags:        tags,
+					Fields: map[string]interface{}{
+						"value": gauge.Value(),
+					},
+					Time: now,
+				})
+			})
 		case metrics.Histogram:
 			ms := metric.Snapshot()
 			ps := ms.Percentiles([]float64{0.5, 0.75, 0.95, 0.99, 0.999, 0.9999})
@@ -30,6 +30,7 @@ var (
 	typeCounterTpl         = "# TYPE %s counter\n"
 	typeSummaryTpl         = "# TYPE %s summary\n"
 	keyValueTpl            = "%s %v\n\n"
+	keyLabelsValueTpl      = "%s{%s} %v\n"
 	keyQuantileTagValueTpl = "%s {quantile=\"%s\"} %v\n"
 )
 
@@ -58,6 +59,20 @@ func (c *collector) addGaugeFloat64(name string, m metrics.GaugeFloat64) {
 	c.writeGaugeCounter(name, m.Value())
 }
 
+func (c *collector) addGaugeFloat64Vec(name string, m metrics.GaugeFloat64Vec) {
+	name = mutateKey(name)
+	labels := m.Labels()
+	c.buff.WriteString(fmt.Sprintf(typeGaugeTpl, name))
+	m.Each(func(values []string, gauge metrics.GaugeFloat64) {
+		pairs := make([]string, len(labels))
+		for i, label := range labels {
+			pairs[i] = fmt.Sprintf("%s=%q", label, values[i])
+		}
+		c.buff.WriteString(fmt.Sprintf(keyLabelsValueTpl, name, strings.Join(pairs, ","), gauge.Value()))
+	})
+	c.buff.WriteRune('\n')
+}
+
 func (c *collector) addHistogram(name string, m metrics.Histogram) {
 	pv := []float64{0.5, 0.75, 0.95, 0.99, 0.999, 0.9999}
 	ps := m.Percentiles(pv)
@@ -28,6 +28,13 @@ func TestCollector(t *testing.T) {
 	gaugeFloat64.Update(34567.89)
 	c.addGaugeFloat64("test/gauge_float64", gaugeFloat64)
 
+	gaugeFloat64Vec := metrics.NewGaugeFloat64Vec("address", "role")
+	gaugeFloat64Vec.With("0x02", "validator").Update(2.5)
+	gaugeFloat64Vec.With("0x01", "stakeholder").Update(1)
+	gaugeFloat64Vec.With("0x03", "validator").Update(3)
+	gaugeFloat64Vec.Delete("0x03", "validator")
+	c.addGaugeFloat64Vec("test/gauge_float64_vec", gaugeFloat64Vec)
+
 	histogram := metrics.NewHistogram(&metrics.NilSample{})
 	c.addHistogram("test/histogram", histogram)
 
@@ -67,6 +74,10 @@ test_gauge 23456
 # TYPE test_gauge_float64 gauge
 test_gauge_float64 34567.89
 
+# TYPE test_gauge_float64_vec gauge
+test_gauge_float64_vec{address="0x01",role="stakeholder"} 1
+test_gauge_float64_vec{address="0x02",role="validator"} 2.5
+
 # TYPE test_histogram_count counter
 test_histogram_count 0
 
@@ -49,6 +49,8 @@ func Handler(reg metrics.Registry) http.Handler {
 				c.addGauge(name, m.Snapshot())
 			case metrics.GaugeFloat64:
 				c.addGaugeFloat64(name, m.Snapshot())
+			case metrics.GaugeFloat64Vec:
+				c.addGaugeFloat64Vec(name, m)
 			case metrics.Histogram:
 				c.addHistogram(name, m.Snapshot())
 			case metrics.Meter:
@@ -125,6 +125,10 @@ func (r *StandardRegistry) GetAll() map[string]map[string]interface{} {
 			values["value"] = metric.Value()
 		case GaugeFloat64:
 			values["value"] = metric.Value()
+		case GaugeFloat64Vec:
+			metric.Each(func(labels []string, gauge GaugeFloat64) {
+				values[strings.Join(labels, ",")] = gauge.Value()
+			})
 		case Healthcheck:
 			values["error"] = nil
 			metric.Check()
@@ -211,7 +215,7 @@ func (r *StandardRegistry) register(name string, i interface{}) error {
 		return DuplicateMetric(name)
 	}
 	switch i.(type) {
-	case Counter, Gauge, GaugeFloat64, Healthcheck, Histogram, Meter, Timer, ResettingTimer:
+	case Counter, Gauge, GaugeFloat64, GaugeFloat64Vec, Healthcheck, Histogram, Meter, Timer, ResettingTimer:
 		r.metrics[name] = i
 	}
 	return nil
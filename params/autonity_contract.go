@@ -6,9 +6,11 @@ import (
 	"reflect"
 
 	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
 
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/common/acdefault"
+	"github.com/clearmatics/autonity/common/hexutil"
 	"github.com/clearmatics/autonity/log"
 	"github.com/clearmatics/autonity/p2p/enode"
 )
@@ -85,6 +87,9 @@ func (ac *AutonityContractGenesis) Prepare() error {
 				return err
 			}
 		}
+		if err := ac.Users[i].validateBLSKey(); err != nil {
+			return err
+		}
 	}
 
 	if len(ac.GetValidatorUsers()) == 0 {
@@ -99,6 +104,9 @@ type User struct {
 	Enode   string          `json:"enode"`
 	Type    UserType        `json:"type"`
 	Stake   uint64          `json:"stake"`
+	// BLSKey is the BLS public key of the user followed by its proof of
+	// possession, used to verify its aggregated committed seals.
+	BLSKey hexutil.Bytes `json:"blsKey,omitempty"`
 }
 
 // getAddressFromEnode gets the account address from the user enode.
@@ -111,6 +119,9 @@ func (u *User) getAddressFromEnode() (common.Address, error) {
 }
 
 func (u *User) Validate() error {
+	if err := u.validateBLSKey(); err != nil {
+		return err
+	}
 	switch {
 	case !u.Type.IsValid():
 		return errors.New("incorrect user type")
@@ -137,6 +148,17 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// validateBLSKey checks the proof of possession of the user BLS key, if any.
+func (u *User) validateBLSKey() error {
+	if len(u.BLSKey) == 0 {
+		return nil
+	}
+	if _, err := bls.PublicKeyFromRegistration(u.BLSKey); err != nil {
+		return fmt.Errorf("invalid user.blsKey: %v", err)
+	}
+	return nil
+}
+
 //GetValidatorUsers - returns list of validators
 func (ac *AutonityContractGenesis) GetValidatorUsers() []User {
 	var users []User
@@ -1,11 +1,13 @@
 package params
 
 import (
+	"crypto/rand"
 	"net"
 	"testing"
 
 	"github.com/clearmatics/autonity/common"
 	"github.com/clearmatics/autonity/crypto"
+	"github.com/clearmatics/autonity/crypto/bls"
 	"github.com/clearmatics/autonity/p2p/enode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,3 +117,28 @@ func TestPrepareAutonityContract_AddsUserAddress(t *testing.T) {
 	require.NoError(t, contractConfig.Prepare())
 	assert.NotNil(t, contractConfig.Users[0].Address, "Failed to add user address")
 }
+
+func TestPrepareAutonityContract_InvalidBLSKey_Fail(t *testing.T) {
+	blsKey, err := bls.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := bls.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user := User{
+		Enode:  "enode://d73b857969c86415c0c000371bcebd9ed3cca6c376032b3f65e58e9e2b79276fbc6f59eb1e22fcd6356ab95f42a666f70afd4985933bd8f3e05beb1a2bf8fdde@172.25.0.11:30303",
+		Type:   UserValidator,
+		Stake:  1,
+		BLSKey: blsKey.Registration(),
+	}
+	contractConfig := &AutonityContractGenesis{
+		Bytecode: "some code",
+		ABI:      "some abi",
+		Users:    []User{user},
+	}
+	require.NoError(t, contractConfig.Prepare())
+
+	// the proof of possession must be the one of the registered key.
+	user.BLSKey = append(blsKey.PublicKey().Bytes(), otherKey.Prove().Bytes()...)
+	contractConfig.Users = []User{user}
+	assert.Error(t, contractConfig.Prepare(), "Expecting Prepare to return error")
+}
@@ -531,6 +531,22 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.EWASMBlock, newcfg.EWASMBlock, head) {
 		return newCompatError("ewasm fork block", c.EWASMBlock, newcfg.EWASMBlock)
 	}
+	if c.Tendermint != nil && newcfg.Tendermint != nil &&
+		isForkIncompatible(c.Tendermint.AggregatedSealBlock, newcfg.Tendermint.AggregatedSealBlock, head) {
+		return newCompatError("Tendermint aggregated seal block", c.Tendermint.AggregatedSealBlock, newcfg.Tendermint.AggregatedSealBlock)
+	}
+	if c.Tendermint != nil && newcfg.Tendermint != nil &&
+		isForkIncompatible(c.Tendermint.DowntimeBlock, newcfg.Tendermint.DowntimeBlock, head) {
+		return newCompatError("Tendermint downtime block", c.Tendermint.DowntimeBlock, newcfg.Tendermint.DowntimeBlock)
+	}
+	if c.Tendermint != nil && newcfg.Tendermint != nil &&
+		isForkIncompatible(c.Tendermint.EpochBlock, newcfg.Tendermint.EpochBlock, head) {
+		return newCompatError("Tendermint epoch block", c.Tendermint.EpochBlock, newcfg.Tendermint.EpochBlock)
+	}
+	if c.Tendermint != nil && newcfg.Tendermint != nil && isForked(c.Tendermint.EpochBlock, head) &&
+		c.Tendermint.EpochLength != newcfg.Tendermint.EpochLength {
+		return newCompatError("Tendermint epoch length", c.Tendermint.EpochBlock, newcfg.Tendermint.EpochBlock)
+	}
 	return nil
 }
 
@@ -20,6 +20,8 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	tendermint "github.com/clearmatics/autonity/consensus/tendermint/config"
 )
 
 func TestCheckCompatible(t *testing.T) {
@@ -87,6 +89,34 @@ func TestCheckCompatible(t *testing.T) {
 				RewindTo:     30,
 			},
 		},
+		{
+			stored:  &ChainConfig{Tendermint: &tendermint.Config{}},
+			new:     &ChainConfig{Tendermint: &tendermint.Config{EpochBlock: big.NewInt(50), EpochLength: 10}},
+			head:    40,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{Tendermint: &tendermint.Config{}},
+			new:    &ChainConfig{Tendermint: &tendermint.Config{EpochBlock: big.NewInt(30), EpochLength: 10}},
+			head:   40,
+			wantErr: &ConfigCompatError{
+				What:         "Tendermint epoch block",
+				StoredConfig: nil,
+				NewConfig:    big.NewInt(30),
+				RewindTo:     29,
+			},
+		},
+		{
+			stored: &ChainConfig{Tendermint: &tendermint.Config{EpochBlock: big.NewInt(30), EpochLength: 10}},
+			new:    &ChainConfig{Tendermint: &tendermint.Config{EpochBlock: big.NewInt(30), EpochLength: 20}},
+			head:   40,
+			wantErr: &ConfigCompatError{
+				What:         "Tendermint epoch length",
+				StoredConfig: big.NewInt(30),
+				NewConfig:    big.NewInt(30),
+				RewindTo:     29,
+			},
+		},
 	}
 
 	for _, test := range tests {
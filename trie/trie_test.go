@@ -701,6 +701,7 @@ func (b *spongeBatch) Put(key, value []byte) error {
 func (b *spongeBatch) Delete(key []byte) error             { panic("implement me") }
 func (b *spongeBatch) ValueSize() int                      { return 100 }
 func (b *spongeBatch) Write() error                        { return nil }
+func (b *spongeBatch) WriteSync() error                    { return nil }
 func (b *spongeBatch) Reset()                              {}
 func (b *spongeBatch) Replay(w ethdb.KeyValueWriter) error { return nil }
 